@@ -0,0 +1,64 @@
+// Command dataset builds the file the dataset movie provider loads (movies.providers dataset) from the
+// IMDb dataset files downloaded from https://datasets.imdbws.com
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	repo "github.com/zenkobert/sbtest-2/repository"
+)
+
+const (
+	exitOK         = 0
+	exitBuildError = 1
+	exitUsageError = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("dataset", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dir := fs.String("dir", ".", "directory with title.basics, title.ratings, title.episode, title.principals and name.basics, .tsv or .tsv.gz")
+	out := fs.String("out", "imdb.dataset", "file to write the dataset to, replaced once it is complete")
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	if err != nil || fs.NArg() > 0 {
+		return exitUsageError
+	}
+
+	// the running service may be reading out, it only gets replaced by a complete file
+	file, err := ioutil.TempFile(filepath.Dir(*out), ".dataset-*")
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitBuildError
+	}
+	defer os.Remove(file.Name())
+
+	start := time.Now()
+	count, err := repo.BuildDataset(*dir, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), *out)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitBuildError
+	}
+
+	fmt.Fprintf(stdout, "%d titles written to %s in %s\n", count, *out, time.Since(start).Round(time.Millisecond))
+	return exitOK
+}
@@ -0,0 +1,148 @@
+// Command import uploads a watch history exported from Letterboxd or IMDb to the Watchlist service
+// and prints what couldn't be imported
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	server "github.com/zenkobert/sbtest-2/delivery/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	exitOK          = 0
+	exitImportError = 1
+	exitUsageError  = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: import [flags] export.csv")
+		fs.PrintDefaults()
+	}
+	addr := fs.String("addr", "localhost:8080", "address of the GRPC server")
+	token := fs.String("token", "", "bearer token of the watchlist owner (env IMPORT_TOKEN)")
+	format := fs.String("format", "", "letterboxd or imdb, detected from the CSV header when empty")
+	watchlistID := fs.String("watchlist", "", "ID of the watchlist to add the movies to, a new one is created when empty")
+	name := fs.String("name", "", "name of the new watchlist")
+	unwatched := fs.Bool("unwatched", false, "the CSV lists movies to watch, don't mark them as watched")
+	useTLS := fs.Bool("tls", false, "connect with TLS")
+	caFile := fs.String("ca-file", "", "PEM CA bundle the server is verified with, system roots when empty, implies -tls")
+	timeout := fs.Duration("timeout", 10*time.Minute, "how long the import may take, lookups are throttled so big ones are slow")
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	if err != nil {
+		return exitUsageError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsageError
+	}
+	if *token == "" {
+		*token = os.Getenv("IMPORT_TOKEN")
+	}
+	if *token == "" {
+		fmt.Fprintln(stderr, "a bearer token is required, set -token or IMPORT_TOKEN")
+		return exitUsageError
+	}
+
+	content, err := ioutil.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsageError
+	}
+
+	transport := grpc.WithInsecure()
+	if *useTLS || *caFile != "" {
+		creds := credentials.NewTLS(nil)
+		if *caFile != "" {
+			creds, err = credentials.NewClientTLSFromFile(*caFile, "")
+			if err != nil {
+				fmt.Fprintln(stderr, err)
+				return exitUsageError
+			}
+		}
+		transport = grpc.WithTransportCredentials(creds)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, *addr, transport)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitImportError
+	}
+	defer conn.Close()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	resp, err := server.NewWatchlistClient(conn).ImportHistory(ctx, &server.ImportHistoryRequest{
+		Content:       content,
+		Format:        *format,
+		Unwatched:     *unwatched,
+		WatchlistId:   *watchlistID,
+		WatchlistName: *name,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "import failed: %s\n", status.Convert(err).Message())
+		return exitImportError
+	}
+
+	printResult(stdout, resp)
+	return exitOK
+}
+
+func printResult(w io.Writer, resp *server.ImportHistoryResponse) {
+	fmt.Fprintf(w, "watchlist %s: %d rows, %d movies added, %d watched, %d rated\n",
+		resp.WatchlistId, resp.Rows, resp.Added, resp.Watched, resp.Rated)
+
+	for _, group := range []struct {
+		name   string
+		issues []*server.ImportIssue
+	}{
+		{"unmatched", resp.Unmatched},
+		{"ambiguous", resp.Ambiguous},
+		{"failed", resp.Failed},
+	} {
+		if len(group.issues) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n%s (%d)\n", group.name, len(group.issues))
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, issue := range group.issues {
+			fmt.Fprintf(tw, "  row %d\t%s\t%s\t%s\n", issue.Row, describeRow(issue), issue.ImdbId, issue.Reason)
+			for _, candidate := range issue.Candidates {
+				fmt.Fprintf(tw, "\t  %s (%s)\t%s\t\n", candidate.Title, candidate.Year, candidate.ImdbId)
+			}
+		}
+		tw.Flush()
+	}
+}
+
+func describeRow(issue *server.ImportIssue) string {
+	if issue.Year == 0 {
+		return issue.Title
+	}
+
+	return fmt.Sprintf("%s (%d)", issue.Title, issue.Year)
+}
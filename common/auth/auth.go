@@ -0,0 +1,125 @@
+// Package auth verifies the bearer tokens callers authenticate with. Tokens are JWTs signed
+// with HS256 by whoever shares the secret, their subject is the ID of the user
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// leeway tolerates clocks of the token issuer and of the service drifting apart
+const leeway = time.Minute
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token expired")
+)
+
+type (
+	header struct {
+		Alg string `json:"alg"`
+		Typ string `json:"typ,omitempty"`
+	}
+
+	claims struct {
+		Subject   string `json:"sub"`
+		ExpiresAt int64  `json:"exp,omitempty"`
+		NotBefore int64  `json:"nbf,omitempty"`
+	}
+)
+
+type Verifier struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func NewVerifier(secret string) *Verifier {
+	return &Verifier{
+		secret: []byte(secret),
+		now:    time.Now,
+	}
+}
+
+// Verify returns the subject of token once its signature and validity period are checked
+func (v *Verifier) Verify(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", ErrInvalidToken
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil || h.Alg != "HS256" {
+		// only HS256 is accepted, whatever else the token claims, "none" included
+		return "", ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(v.secret, parts[0]+"."+parts[1])) {
+		return "", ErrInvalidToken
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil || c.Subject == "" {
+		return "", ErrInvalidToken
+	}
+
+	now := v.now()
+	if c.ExpiresAt != 0 && now.After(time.Unix(c.ExpiresAt, 0).Add(leeway)) {
+		return "", ErrExpiredToken
+	}
+	if c.NotBefore != 0 && now.Add(leeway).Before(time.Unix(c.NotBefore, 0)) {
+		return "", ErrInvalidToken
+	}
+
+	return c.Subject, nil
+}
+
+// Sign returns a token for subject valid until expiresAt, or forever when it is zero
+func Sign(secret, subject string, expiresAt time.Time) (string, error) {
+	c := claims{Subject: subject}
+	if !expiresAt.IsZero() {
+		c.ExpiresAt = expiresAt.Unix()
+	}
+
+	h, err := encodeSegment(header{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := encodeSegment(c)
+	if err != nil {
+		return "", err
+	}
+
+	signed := h + "." + payload
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(secret), signed)), nil
+}
+
+func sign(secret []byte, signed string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+
+	return mac.Sum(nil)
+}
+
+func encodeSegment(v interface{}) (string, error) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(content), nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	content, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(content, v)
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "0123456789abcdef0123456789abcdef"
+
+func fixedVerifier(now time.Time) *Verifier {
+	v := NewVerifier(secret)
+	v.now = func() time.Time { return now }
+
+	return v
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2021, 9, 3, 12, 0, 0, 0, time.UTC)
+
+	t.Run("[Verify] signed token returns its subject", func(t *testing.T) {
+		token, err := Sign(secret, "user-1", now.Add(time.Hour))
+		if assert.Nil(t, err) {
+			subject, err := fixedVerifier(now).Verify(token)
+			assert.Nil(t, err)
+			assert.Equal(t, "user-1", subject)
+		}
+	})
+
+	t.Run("[Verify] token without expiry is valid", func(t *testing.T) {
+		token, _ := Sign(secret, "user-1", time.Time{})
+		subject, err := fixedVerifier(now).Verify(token)
+		assert.Nil(t, err)
+		assert.Equal(t, "user-1", subject)
+	})
+
+	t.Run("[Verify] expired token, past the leeway", func(t *testing.T) {
+		token, _ := Sign(secret, "user-1", now.Add(-2*time.Minute))
+		_, err := fixedVerifier(now).Verify(token)
+		assert.Equal(t, ErrExpiredToken, err)
+
+		token, _ = Sign(secret, "user-1", now.Add(-30*time.Second))
+		_, err = fixedVerifier(now).Verify(token)
+		assert.Nil(t, err)
+	})
+
+	t.Run("[Verify] token signed with another secret", func(t *testing.T) {
+		token, _ := Sign("another secret of at least 32 bytes", "user-1", time.Time{})
+		_, err := fixedVerifier(now).Verify(token)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("[Verify] tampered claims", func(t *testing.T) {
+		token, _ := Sign(secret, "user-1", time.Time{})
+		parts := strings.Split(token, ".")
+		parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-2"}`))
+		_, err := fixedVerifier(now).Verify(strings.Join(parts, "."))
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("[Verify] unsigned token", func(t *testing.T) {
+		h := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+		c := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-1"}`))
+		_, err := fixedVerifier(now).Verify(h + "." + c + ".")
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("[Verify] token not valid yet", func(t *testing.T) {
+		h := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256"}`))
+		c := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-1","nbf":` + "1630674000" + `}`))
+		signed := h + "." + c
+		token := signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(secret), signed))
+		_, err := fixedVerifier(now).Verify(token)
+		assert.Equal(t, ErrInvalidToken, err)
+		_, err = fixedVerifier(now.Add(time.Hour)).Verify(token)
+		assert.Nil(t, err)
+	})
+
+	t.Run("[Verify] malformed tokens", func(t *testing.T) {
+		for _, token := range []string{"", "abc", "a.b", "a.b.c", "a.b.c.d"} {
+			_, err := fixedVerifier(now).Verify(token)
+			assert.Equal(t, ErrInvalidToken, err, token)
+		}
+	})
+}
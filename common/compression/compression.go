@@ -0,0 +1,100 @@
+// Package compression registers the compressors GRPC servers and clients negotiate: gzip and zstd.
+// Servers answer with the compressor of the request, clients pick one with grpc.UseCompressor
+package compression
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// names of the compressors, None sends messages uncompressed
+const (
+	None = "none"
+	Gzip = gzip.Name
+	Zstd = "zstd"
+)
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// zstdCompressor reuses encoders and decoders, creating them is much more expensive than compressing a message
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+	done bool
+}
+
+func (c *zstdCompressor) Name() string {
+	return Zstd
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	encoder, ok := c.encoders.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+		encoder, err = zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		encoder.Reset(w)
+	}
+
+	return &zstdWriter{Encoder: encoder, pool: &c.encoders}, nil
+}
+
+func (w *zstdWriter) Close() error {
+	defer w.pool.Put(w.Encoder)
+
+	return w.Encoder.Close()
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	decoder, ok := c.decoders.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+		// the concurrent decoder starts goroutines that would outlive the message
+		decoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := decoder.Reset(r)
+	if err != nil {
+		c.decoders.Put(decoder)
+		return nil, err
+	}
+
+	return &zstdReader{Decoder: decoder, pool: &c.decoders}, nil
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		r.done = true
+		// GRPC reads messages to the end, the decoder can serve the next one
+		r.pool.Put(r.Decoder)
+	}
+
+	return n, err
+}
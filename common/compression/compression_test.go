@@ -0,0 +1,89 @@
+package compression
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func roundTrip(t *testing.T, compressor encoding.Compressor, payload []byte) []byte {
+	var compressed bytes.Buffer
+	w, err := compressor.Compress(&compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(payload)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Less(t, compressed.Len(), len(payload))
+
+	r, err := compressor.Decompress(&compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return decompressed
+}
+
+func TestZstdCompressor(t *testing.T) {
+	t.Run("[zstdCompressor] registered for GRPC", func(t *testing.T) {
+		assert.NotNil(t, encoding.GetCompressor(Zstd))
+		assert.NotNil(t, encoding.GetCompressor(Gzip))
+	})
+
+	t.Run("[zstdCompressor] round trips with pooled encoders and decoders", func(t *testing.T) {
+		compressor := encoding.GetCompressor(Zstd)
+		for i := 0; i < 5; i++ {
+			payload := []byte(strings.Repeat("Iron Man, Iron Man 2, Iron Man 3. ", 10*(i+1)))
+			assert.Equal(t, payload, roundTrip(t, compressor, payload))
+		}
+	})
+
+	t.Run("[zstdCompressor] corrupted input", func(t *testing.T) {
+		r, err := encoding.GetCompressor(Zstd).Decompress(bytes.NewReader([]byte("not zstd")))
+		if err == nil {
+			_, err = ioutil.ReadAll(r)
+		}
+		assert.Error(t, err)
+	})
+}
+
+func TestGRPCCompression(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for _, name := range []string{Gzip, Zstd} {
+		t.Run("[GRPC] the server answers "+name+" requests", func(t *testing.T) {
+			resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}, grpc.UseCompressor(name))
+			if assert.Nil(t, err) {
+				assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+			}
+		})
+	}
+}
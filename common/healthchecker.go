@@ -0,0 +1,7 @@
+package common
+
+import "context"
+
+type HealthChecker interface {
+	Check(ctx context.Context) error
+}
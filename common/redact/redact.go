@@ -0,0 +1,210 @@
+package redact
+
+import (
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const Placeholder = "REDACTED"
+
+var (
+	mutex   = &sync.RWMutex{}
+	secrets []string
+
+	// OMDb api keys travel as a query param, hide any of them even if it was never registered
+	apiKeyParam = regexp.MustCompile(`(?i)(apikey=)[^&\s"']+`)
+)
+
+// Register adds secret values that must never leave the process in logs,
+// errors, trace attributes or gRPC status messages
+func Register(values ...string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+
+		secrets = append(secrets, value)
+		if escaped := url.QueryEscape(value); escaped != value {
+			secrets = append(secrets, escaped)
+		}
+	}
+}
+
+func String(s string) string {
+	s = apiKeyParam.ReplaceAllString(s, "${1}"+Placeholder)
+
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, Placeholder)
+	}
+
+	return s
+}
+
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// Error returns err with its message redacted, errors.Is and errors.As still see the original
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	message := String(err.Error())
+	if message == err.Error() {
+		return err
+	}
+
+	return &redactedError{message: message, err: err}
+}
+
+// Status redacts the message of a gRPC status error and the strings of its details, keeping its code.
+// Details of a type this binary doesn't know can't be looked into, they are dropped
+func Status(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return Error(err)
+	}
+
+	proto := st.Proto()
+	message := String(proto.Message)
+	redacted := message != proto.Message
+	details := make([]*anypb.Any, 0, len(proto.Details))
+	for _, detail := range proto.Details {
+		unpacked, err := detail.UnmarshalNew()
+		if err != nil {
+			redacted = true
+			continue
+		}
+		if !redactMessage(unpacked.ProtoReflect()) {
+			details = append(details, detail)
+			continue
+		}
+
+		redacted = true
+		if repacked, err := anypb.New(unpacked); err == nil {
+			details = append(details, repacked)
+		}
+	}
+	if !redacted {
+		return err
+	}
+
+	proto.Message = message
+	proto.Details = details
+	return status.ErrorProto(proto)
+}
+
+// redactMessage redacts the strings of m and of the messages it holds, it tells whether any had a secret
+func redactMessage(m protoreflect.Message) bool {
+	// m can't be changed while ranging over it
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(field protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, field)
+		return true
+	})
+
+	redacted := false
+	for _, field := range fields {
+		switch {
+		case field.IsList():
+			list := m.Mutable(field).List()
+			for i := 0; i < list.Len(); i++ {
+				if value, ok := redactValue(field, list.Get(i)); ok {
+					list.Set(i, value)
+					redacted = true
+				}
+			}
+		case field.IsMap():
+			entries := m.Mutable(field).Map()
+			var keys []protoreflect.MapKey
+			entries.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+				keys = append(keys, key)
+				return true
+			})
+			for _, key := range keys {
+				if value, ok := redactValue(field.MapValue(), entries.Get(key)); ok {
+					entries.Set(key, value)
+					redacted = true
+				}
+			}
+		case field.Message() != nil:
+			if redactMessage(m.Mutable(field).Message()) {
+				redacted = true
+			}
+		default:
+			if value, ok := redactValue(field, m.Get(field)); ok {
+				m.Set(field, value)
+				redacted = true
+			}
+		}
+	}
+
+	return redacted
+}
+
+// redactValue redacts value of field, a string or a message, it tells whether it had a secret
+func redactValue(field protoreflect.FieldDescriptor, value protoreflect.Value) (protoreflect.Value, bool) {
+	switch {
+	case field.Message() != nil:
+		return value, redactMessage(value.Message())
+	case field.Kind() == protoreflect.StringKind:
+		redacted := String(value.String())
+		return protoreflect.ValueOfString(redacted), redacted != value.String()
+	}
+
+	return value, false
+}
+
+// URL returns u as a string with secrets hidden
+func URL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	return String(u.String())
+}
+
+type writer struct {
+	w io.Writer
+}
+
+// NewWriter redacts everything written to w, meant for log.SetOutput
+func NewWriter(w io.Writer) io.Writer {
+	return &writer{w: w}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	_, err := w.w.Write([]byte(String(string(p))))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
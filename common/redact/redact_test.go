@@ -0,0 +1,143 @@
+package redact
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	secrets = nil
+}
+
+func TestString(t *testing.T) {
+	t.Run("[String] registered secret is replaced everywhere", func(t *testing.T) {
+		defer reset()
+		Register("s3cr3t")
+
+		actual := String("key s3cr3t and again s3cr3t")
+		assert.Equal(t, "key REDACTED and again REDACTED", actual)
+	})
+
+	t.Run("[String] url escaped secret is replaced", func(t *testing.T) {
+		defer reset()
+		Register("a b/c")
+
+		actual := String("http://www.omdbapi.com/?key=" + url.QueryEscape("a b/c"))
+		assert.NotContains(t, actual, url.QueryEscape("a b/c"))
+	})
+
+	t.Run("[String] apikey query param is hidden without registration", func(t *testing.T) {
+		actual := String(`Get "http://www.omdbapi.com/?apikey=unknown&s=ironman": dial tcp`)
+		assert.Equal(t, `Get "http://www.omdbapi.com/?apikey=REDACTED&s=ironman": dial tcp`, actual)
+	})
+
+	t.Run("[String] empty secret is ignored", func(t *testing.T) {
+		defer reset()
+		Register("")
+
+		assert.Equal(t, "nothing to hide", String("nothing to hide"))
+	})
+}
+
+func TestError(t *testing.T) {
+	t.Run("[Error] nil error", func(t *testing.T) {
+		assert.Nil(t, Error(nil))
+	})
+
+	t.Run("[Error] message is redacted and the original is still reachable", func(t *testing.T) {
+		defer reset()
+		Register("s3cr3t")
+
+		original := &url.Error{Op: "Get", URL: "http://www.omdbapi.com/?apikey=s3cr3t", Err: errors.New("timeout")}
+		err := Error(original)
+
+		assert.NotContains(t, err.Error(), "s3cr3t")
+		var urlErr *url.Error
+		assert.True(t, errors.As(err, &urlErr))
+	})
+
+	t.Run("[Error] clean error is returned as is", func(t *testing.T) {
+		original := errors.New("movie not found")
+		assert.Equal(t, original, Error(original))
+	})
+}
+
+func TestStatus(t *testing.T) {
+	t.Run("[Status] message is redacted, code and details are kept", func(t *testing.T) {
+		defer reset()
+		Register("s3cr3t")
+
+		st, _ := status.New(codes.Internal, "calling omdb with s3cr3t").WithDetails(&errdetails.ErrorInfo{Reason: "UPSTREAM"})
+		err := Status(st.Err())
+
+		actual, _ := status.FromError(err)
+		assert.Equal(t, codes.Internal, actual.Code())
+		assert.Equal(t, "calling omdb with REDACTED", actual.Message())
+		assert.Len(t, actual.Details(), 1)
+	})
+
+	t.Run("[Status] secrets in the details are redacted", func(t *testing.T) {
+		defer reset()
+		Register("s3cr3t")
+
+		st, _ := status.New(codes.Unavailable, "omdb is down").WithDetails(
+			&errdetails.ErrorInfo{Reason: "UPSTREAM", Metadata: map[string]string{"url": "http://www.omdbapi.com/?apikey=s3cr3t&i=tt0371746"}},
+			&errdetails.DebugInfo{StackEntries: []string{"calling omdb with s3cr3t"}, Detail: "key s3cr3t"},
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "searchword", Description: "s3cr3t is no title"}}},
+		)
+		err := Status(st.Err())
+
+		actual, _ := status.FromError(err)
+		assert.Equal(t, codes.Unavailable, actual.Code())
+		assert.Equal(t, "omdb is down", actual.Message())
+		if assert.Len(t, actual.Details(), 3) {
+			info := actual.Details()[0].(*errdetails.ErrorInfo)
+			assert.Equal(t, "UPSTREAM", info.Reason)
+			assert.Equal(t, "http://www.omdbapi.com/?apikey=REDACTED&i=tt0371746", info.Metadata["url"])
+			debug := actual.Details()[1].(*errdetails.DebugInfo)
+			assert.Equal(t, []string{"calling omdb with REDACTED"}, debug.StackEntries)
+			assert.Equal(t, "key REDACTED", debug.Detail)
+			violation := actual.Details()[2].(*errdetails.BadRequest).FieldViolations[0]
+			assert.Equal(t, "REDACTED is no title", violation.Description)
+		}
+		assert.NotContains(t, fmt.Sprint(actual.Proto()), "s3cr3t")
+	})
+
+	t.Run("[Status] clean status is returned as is", func(t *testing.T) {
+		st, _ := status.New(codes.NotFound, "movie not found").WithDetails(&errdetails.ErrorInfo{Reason: "MOVIE_NOT_FOUND"})
+		assert.Equal(t, st.Err(), Status(st.Err()))
+	})
+
+	t.Run("[Status] non status error", func(t *testing.T) {
+		defer reset()
+		Register("s3cr3t")
+
+		err := Status(fmt.Errorf("wrapped: %w", errors.New("s3cr3t")))
+		assert.Equal(t, "wrapped: REDACTED", err.Error())
+	})
+}
+
+func TestNewWriter(t *testing.T) {
+	t.Run("[NewWriter] log lines are redacted", func(t *testing.T) {
+		defer reset()
+		Register("s3cr3t")
+
+		out := &bytes.Buffer{}
+		logger := log.New(NewWriter(out), "", 0)
+		logger.Println("api key is s3cr3t")
+
+		assert.Equal(t, "api key is REDACTED\n", out.String())
+	})
+}
@@ -0,0 +1,237 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	ClientAuthRequire  = "require"
+	ClientAuthOptional = "optional"
+)
+
+type Options struct {
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile enables mutual TLS, client certificates must be signed by one of these CAs
+	ClientCAFile string
+	// ClientAuth is either ClientAuthRequire (default) or ClientAuthOptional,
+	// the latter lets clients without certificate in, e.g. load balancer probes
+	ClientAuth string
+
+	// CAFile verifies the server certificate when dialing our own gRPC server, system roots when empty
+	CAFile string
+	// ServerName overrides the name checked against the server certificate when dialing
+	ServerName string
+}
+
+// Manager serves the current certificates and reloads them when their files change,
+// so rotating a certificate doesn't need a restart
+type Manager struct {
+	opts Options
+
+	mutex     *sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	rootCAs   *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+func NewManager(opts Options) (*Manager, error) {
+	m := &Manager{
+		opts:     opts,
+		mutex:    &sync.RWMutex{},
+		modTimes: map[string]time.Time{},
+	}
+
+	err := m.reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ServerConfig is resolved per handshake so reloaded certificates apply to new connections
+func (m *Manager) ServerConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			m.mutex.RLock()
+			defer m.mutex.RUnlock()
+
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   nextProtos,
+				Certificates: []tls.Certificate{*m.cert},
+			}
+			if m.clientCAs != nil {
+				cfg.ClientCAs = m.clientCAs
+				cfg.ClientAuth = tls.RequireAndVerifyClientCert
+				if m.opts.ClientAuth == ClientAuthOptional {
+					cfg.ClientAuth = tls.VerifyClientCertIfGiven
+				}
+			}
+
+			return cfg, nil
+		},
+	}
+}
+
+// ClientConfig is used by the gateway to dial the gRPC server, it presents the same
+// certificate so the hop also passes mutual TLS
+func (m *Manager) ClientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: m.opts.ServerName,
+		// the chain is verified in VerifyConnection instead, against the CAs loaded at handshake time
+		InsecureSkipVerify: true,
+		VerifyConnection:   m.verifyServer,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			m.mutex.RLock()
+			defer m.mutex.RUnlock()
+
+			return m.cert, nil
+		},
+	}
+}
+
+func (m *Manager) verifyServer(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("server presented no certificate")
+	}
+
+	m.mutex.RLock()
+	roots := m.rootCAs
+	m.mutex.RUnlock()
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		DNSName:       cs.ServerName,
+	})
+
+	return err
+}
+
+// Watch polls the certificate files every interval and reloads them on change until ctx is done.
+// A broken file is logged and the previous certificates stay in use
+func (m *Manager) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !m.changed() {
+				continue
+			}
+
+			err := m.reload()
+			if err != nil {
+				log.Printf("keeping the current TLS certificates, reload failed: %v\n", err)
+				continue
+			}
+			log.Println("TLS certificates reloaded")
+		}
+	}
+}
+
+func (m *Manager) files() []string {
+	files := []string{m.opts.CertFile, m.opts.KeyFile}
+	if m.opts.ClientCAFile != "" {
+		files = append(files, m.opts.ClientCAFile)
+	}
+	if m.opts.CAFile != "" {
+		files = append(files, m.opts.CAFile)
+	}
+
+	return files
+}
+
+func (m *Manager) changed() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, file := range m.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(m.modTimes[file]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Manager) reload() error {
+	modTimes := map[string]time.Time{}
+	for _, file := range m.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(m.opts.CertFile, m.opts.KeyFile)
+	if err != nil {
+		return fmt.Errorf("loading certificate: %w", err)
+	}
+
+	var clientCAs, rootCAs *x509.CertPool
+	if m.opts.ClientCAFile != "" {
+		clientCAs, err = loadPool(m.opts.ClientCAFile)
+		if err != nil {
+			return err
+		}
+	}
+	if m.opts.CAFile != "" {
+		rootCAs, err = loadPool(m.opts.CAFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.cert = &cert
+	m.clientCAs = clientCAs
+	m.rootCAs = rootCAs
+	m.modTimes = modTimes
+
+	return nil
+}
+
+func loadPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no certificate found in " + file)
+	}
+
+	return pool, nil
+}
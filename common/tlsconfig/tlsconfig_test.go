@@ -0,0 +1,322 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newAuthority(t *testing.T, dir, name string) *authority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(dir, name+".pem")
+	writePEM(t, file, "CERTIFICATE", der)
+
+	return &authority{cert: cert, key: key, file: file}
+}
+
+// issue writes a leaf certificate and its key signed by ca, valid for 127.0.0.1 and as client and server
+func (ca *authority) issue(t *testing.T, dir, name string, serial int64) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:     []string{"localhost"},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, name+".pem")
+	keyFile = filepath.Join(dir, name+"-key.pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	err := ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// handshake runs a TLS handshake over loopback and returns the errors of both sides
+func handshake(t *testing.T, server, client *tls.Config) (serverErr, clientErr error) {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			done <- err
+			return
+		}
+		defer conn.Close()
+		done <- conn.(*tls.Conn).Handshake()
+	}()
+
+	conn, clientErr := tls.Dial("tcp", listener.Addr().String(), client)
+	if clientErr == nil {
+		defer conn.Close()
+	}
+
+	return <-done, clientErr
+}
+
+func TestNewManager(t *testing.T) {
+	dir := t.TempDir()
+	ca := newAuthority(t, dir, "ca")
+	cert, key := ca.issue(t, dir, "server", 2)
+
+	t.Run("[NewManager] loads certificate and CAs", func(t *testing.T) {
+		m, err := NewManager(Options{CertFile: cert, KeyFile: key, ClientCAFile: ca.file, CAFile: ca.file})
+		if assert.Nil(t, err) {
+			assert.NotNil(t, m.cert)
+			assert.NotNil(t, m.clientCAs)
+			assert.NotNil(t, m.rootCAs)
+		}
+	})
+
+	t.Run("[NewManager] missing key file", func(t *testing.T) {
+		_, err := NewManager(Options{CertFile: cert, KeyFile: filepath.Join(dir, "missing.pem")})
+		assert.Error(t, err)
+	})
+
+	t.Run("[NewManager] CA file without certificate", func(t *testing.T) {
+		empty := filepath.Join(dir, "empty.pem")
+		writePEM(t, empty, "NOTHING", nil)
+
+		_, err := NewManager(Options{CertFile: cert, KeyFile: key, ClientCAFile: empty})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "no certificate found")
+		}
+	})
+}
+
+func TestHandshake(t *testing.T) {
+	dir := t.TempDir()
+	ca := newAuthority(t, dir, "ca")
+	serverCert, serverKey := ca.issue(t, dir, "server", 2)
+	clientCert, clientKey := ca.issue(t, dir, "client", 3)
+	rogue := newAuthority(t, dir, "rogue")
+	rogueCert, rogueKey := rogue.issue(t, dir, "intruder", 4)
+
+	serverManager := func(t *testing.T, clientAuth string) *Manager {
+		m, err := NewManager(Options{CertFile: serverCert, KeyFile: serverKey, ClientCAFile: ca.file, ClientAuth: clientAuth})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	clientManager := func(t *testing.T, cert, key string) *Manager {
+		m, err := NewManager(Options{CertFile: cert, KeyFile: key, CAFile: ca.file, ServerName: "127.0.0.1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	t.Run("[Handshake] mutual TLS with a trusted client certificate", func(t *testing.T) {
+		serverErr, clientErr := handshake(t, serverManager(t, ClientAuthRequire).ServerConfig(), clientManager(t, clientCert, clientKey).ClientConfig())
+		assert.Nil(t, serverErr)
+		assert.Nil(t, clientErr)
+	})
+
+	t.Run("[Handshake] client certificate from an unknown CA is rejected", func(t *testing.T) {
+		serverErr, _ := handshake(t, serverManager(t, ClientAuthRequire).ServerConfig(), clientManager(t, rogueCert, rogueKey).ClientConfig())
+		assert.Error(t, serverErr)
+	})
+
+	t.Run("[Handshake] client without certificate is rejected when required", func(t *testing.T) {
+		client := &tls.Config{RootCAs: x509.NewCertPool(), InsecureSkipVerify: true}
+		serverErr, _ := handshake(t, serverManager(t, ClientAuthRequire).ServerConfig(), client)
+		assert.Error(t, serverErr)
+	})
+
+	t.Run("[Handshake] client without certificate is accepted when optional", func(t *testing.T) {
+		client := &tls.Config{InsecureSkipVerify: true}
+		serverErr, clientErr := handshake(t, serverManager(t, ClientAuthOptional).ServerConfig(), client)
+		assert.Nil(t, serverErr)
+		assert.Nil(t, clientErr)
+	})
+
+	t.Run("[Handshake] server certificate from an unknown CA is rejected", func(t *testing.T) {
+		server, err := NewManager(Options{CertFile: rogueCert, KeyFile: rogueKey})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, clientErr := handshake(t, server.ServerConfig(), clientManager(t, clientCert, clientKey).ClientConfig())
+		if assert.Error(t, clientErr) {
+			assert.Contains(t, clientErr.Error(), "unknown authority")
+		}
+	})
+
+	t.Run("[Handshake] server certificate for another name is rejected", func(t *testing.T) {
+		client, err := NewManager(Options{CertFile: clientCert, KeyFile: clientKey, CAFile: ca.file, ServerName: "movies.example.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, clientErr := handshake(t, serverManager(t, ClientAuthRequire).ServerConfig(), client.ClientConfig())
+		assert.Error(t, clientErr)
+	})
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	ca := newAuthority(t, dir, "ca")
+	cert, key := ca.issue(t, dir, "server", 2)
+
+	m, err := NewManager(Options{CertFile: cert, KeyFile: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial := func() int64 {
+		m.mutex.RLock()
+		defer m.mutex.RUnlock()
+
+		leaf, err := x509.ParseCertificate(m.cert.Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return leaf.SerialNumber.Int64()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.Watch(ctx, 10*time.Millisecond)
+
+	t.Run("[Watch] broken certificate keeps the current one", func(t *testing.T) {
+		err := ioutil.WriteFile(cert, []byte("garbage"), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		future := time.Now().Add(time.Minute)
+		os.Chtimes(cert, future, future)
+
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, int64(2), serial())
+	})
+
+	t.Run("[Watch] rotated certificate is picked up", func(t *testing.T) {
+		ca.issue(t, dir, "server", 5)
+		future := time.Now().Add(2 * time.Minute)
+		os.Chtimes(cert, future, future)
+		os.Chtimes(key, future, future)
+
+		assert.Eventually(t, func() bool { return serial() == 5 }, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestGRPCOverMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newAuthority(t, dir, "ca")
+	cert, key := ca.issue(t, dir, "server", 2)
+
+	m, err := NewManager(Options{CertFile: cert, KeyFile: key, ClientCAFile: ca.file, CAFile: ca.file})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(m.ServerConfig("h2"))))
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("[ClientConfig] gateway hop with the shared certificate", func(t *testing.T) {
+		conn, err := grpc.DialContext(ctx, listener.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(m.ClientConfig())))
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+		}
+	})
+
+	t.Run("[ServerConfig] plaintext client is refused", func(t *testing.T) {
+		conn, err := grpc.DialContext(ctx, listener.Addr().String(), grpc.WithInsecure())
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/zenkobert/sbtest-2/common/redact"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var grpcStatusCodeKey = attribute.Key("rpc.grpc.status_code")
+
+// metadataCarrier adapts gRPC metadata to the propagation.TextMapCarrier interface
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// UnaryClientInterceptor records a client span and injects its context into the outgoing metadata
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := tracer().Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.RPCSystemKey.String("grpc"), semconv.RPCMethodKey.String(method)),
+	)
+	defer span.End()
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+
+	err := invoker(metadata.NewOutgoingContext(ctx, md), method, req, reply, cc, opts...)
+	RecordStatus(span, err)
+
+	return err
+}
+
+// StartServerSpan continues the trace found in the incoming metadata, if any
+func StartServerSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	}
+
+	return tracer().Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.RPCSystemKey.String("grpc"), semconv.RPCMethodKey.String(method)),
+	)
+}
+
+func RecordStatus(span trace.Span, err error) {
+	st, _ := status.FromError(err)
+	span.SetAttributes(grpcStatusCodeKey.Int64(int64(st.Code())))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, redact.String(st.Message()))
+	}
+}
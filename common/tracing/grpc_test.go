@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	t.Run("[UnaryClientInterceptor] injects traceparent into outgoing metadata", func(t *testing.T) {
+		newRecorder()
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+
+		var traceparent []string
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			traceparent = md.Get("traceparent")
+			return nil
+		}
+
+		err := UnaryClientInterceptor(context.TODO(), "/movie.SearchMovie/SearchMovie", nil, nil, nil, invoker)
+		assert.Nil(t, err)
+		assert.Len(t, traceparent, 1)
+	})
+}
+
+func TestStartServerSpan(t *testing.T) {
+	t.Run("[StartServerSpan] continues trace from incoming metadata", func(t *testing.T) {
+		recorder := newRecorder()
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+
+		md := metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		ctx := metadata.NewIncomingContext(context.TODO(), md)
+
+		ctx, span := StartServerSpan(ctx, "/movie.SearchMovie/SearchMovie")
+		RecordStatus(span, status.Error(grpccodes.NotFound, "movie not found"))
+		span.End()
+
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.SpanContextFromContext(ctx).TraceID().String())
+
+		spans := recorder.Ended()
+		if assert.Len(t, spans, 1) {
+			assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+			assert.Equal(t, codes.Error, spans[0].Status().Code)
+		}
+	})
+}
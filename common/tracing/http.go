@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/zenkobert/sbtest-2/common"
+	"github.com/zenkobert/sbtest-2/common/redact"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type httpClient struct {
+	Client common.HTTPClient
+}
+
+// NewHTTPClient wraps client so every outgoing request is recorded as a client span
+func NewHTTPClient(client common.HTTPClient) common.HTTPClient {
+	return &httpClient{
+		Client: client,
+	}
+}
+
+func (c *httpClient) Do(req *http.Request) (*http.Response, error) {
+	ctx, span := tracer().Start(req.Context(), fmt.Sprintf("HTTP %s %s", req.Method, req.URL.Host),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(req.Method),
+			semconv.HTTPURLKey.String(redact.URL(req.URL)),
+			semconv.NetPeerNameKey.String(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	resp, err := c.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		span.SetStatus(codes.Error, "request failed")
+		return resp, err
+	}
+
+	if resp != nil {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(resp.StatusCode))
+	}
+
+	return resp, err
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// NewHandler starts a server span for every request reaching h, continuing any
+// trace context the caller sent in its headers
+func NewHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer().Start(ctx, fmt.Sprintf("HTTP %s %s", r.Method, r.URL.Path),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethodKey.String(r.Method),
+				semconv.HTTPTargetKey.String(r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(recorder, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(recorder.status))
+		if recorder.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(recorder.status))
+		}
+	})
+}
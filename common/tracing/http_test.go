@@ -0,0 +1,94 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	"github.com/zenkobert/sbtest-2/common/mocks"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHTTPClientDo(t *testing.T) {
+	t.Run("[Do] client span has redacted url and response status", func(t *testing.T) {
+		recorder := newRecorder()
+		httpClientMock := &mocks.HTTPClient{}
+		httpClientMock.On("Do", testify.Anything).Return(&http.Response{StatusCode: 200}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "http://www.omdbapi.com/?apikey=secret&i=tt1", nil)
+		_, err := NewHTTPClient(httpClientMock).Do(req)
+		assert.Nil(t, err)
+
+		spans := recorder.Ended()
+		if assert.Len(t, spans, 1) {
+			assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+			for _, attr := range spans[0].Attributes() {
+				assert.NotContains(t, attr.Value.Emit(), "secret")
+				if attr.Key == semconv.HTTPStatusCodeKey {
+					assert.Equal(t, int64(200), attr.Value.AsInt64())
+				}
+			}
+		}
+	})
+
+	t.Run("[Do] outgoing request carries the client span context", func(t *testing.T) {
+		newRecorder()
+		httpClientMock := &mocks.HTTPClient{}
+		httpClientMock.On("Do", testify.MatchedBy(func(req *http.Request) bool {
+			return trace.SpanContextFromContext(req.Context()).IsValid()
+		})).Return(&http.Response{StatusCode: 200}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "http://www.omdbapi.com/", nil)
+		_, err := NewHTTPClient(httpClientMock).Do(req)
+		assert.Nil(t, err)
+	})
+
+	t.Run("[Do] client error marks span as error", func(t *testing.T) {
+		recorder := newRecorder()
+		httpClientMock := &mocks.HTTPClient{}
+		httpClientMock.On("Do", testify.Anything).Return(nil, errors.New("error"))
+
+		req, _ := http.NewRequest(http.MethodGet, "http://www.omdbapi.com/", nil)
+		_, err := NewHTTPClient(httpClientMock).Do(req)
+		assert.Error(t, err)
+
+		spans := recorder.Ended()
+		if assert.Len(t, spans, 1) {
+			assert.Equal(t, codes.Error, spans[0].Status().Code)
+		}
+	})
+}
+
+func TestNewHandler(t *testing.T) {
+	t.Run("[NewHandler] continues trace from traceparent header", func(t *testing.T) {
+		recorder := newRecorder()
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+
+		var handlerCtx context.Context
+		handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handlerCtx = r.Context()
+			w.WriteHeader(http.StatusNotFound)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/movies/tt1", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		spanCtx := trace.SpanContextFromContext(handlerCtx)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanCtx.TraceID().String())
+
+		spans := recorder.Ended()
+		if assert.Len(t, spans, 1) {
+			assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+			assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newRecorder() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	return recorder
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("[Setup] exporter none", func(t *testing.T) {
+		shutdown, err := Setup(context.TODO(), Config{Exporter: ExporterNone})
+		if assert.Nil(t, err) {
+			assert.Nil(t, shutdown(context.TODO()))
+		}
+	})
+
+	t.Run("[Setup] exporter stdout", func(t *testing.T) {
+		shutdown, err := Setup(context.TODO(), Config{Exporter: ExporterStdout, ServiceName: "test"})
+		if assert.Nil(t, err) {
+			assert.Nil(t, shutdown(context.TODO()))
+		}
+	})
+
+	t.Run("[Setup] unknown exporter", func(t *testing.T) {
+		_, err := Setup(context.TODO(), Config{Exporter: "zipkin"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,907 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"github.com/zenkobert/sbtest-2/common/compression"
+	"github.com/zenkobert/sbtest-2/common/tlsconfig"
+	"github.com/zenkobert/sbtest-2/common/tracing"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	sourceDefault = "default"
+	sourceFile    = "file"
+	sourceEnv     = "env"
+	sourceFlag    = "flag"
+
+	mask = "********"
+
+	// minJWTSecretSize is the size of an HS256 key, shorter secrets are easier to brute force
+	minJWTSecretSize = 32
+
+	// maxAutocompleteLimit bounds the completions every prefix of every title keeps in memory
+	maxAutocompleteLimit = 100
+
+	// GatewayDial makes the REST gateway dial the GRPC server, over the network in two port mode
+	// and through an in-memory listener in single port mode
+	GatewayDial = "dial"
+	// GatewayDirect makes the REST gateway call the GRPC service in-process, skipping the GRPC transport
+	GatewayDirect = "direct"
+
+	// ProviderOMDb looks movies up with the OMDb API
+	ProviderOMDb = "omdb"
+	// ProviderDataset looks movies up in the IMDb dataset file built by cmd/dataset, offline
+	ProviderDataset = "dataset"
+	// ProviderTMDb looks movies up with the TMDb API
+	ProviderTMDb = "tmdb"
+)
+
+type (
+	GRPCConfig struct {
+		Port       string
+		Reflection bool
+	}
+
+	RESTConfig struct {
+		Port               string
+		Gateway            string
+		GatewayCompression string
+		Compression        []string
+		CompressionMinSize int
+	}
+
+	GraphQLConfig struct {
+		Enabled       bool
+		MaxDepth      int
+		MaxComplexity int
+	}
+
+	WatchlistConfig struct {
+		File string
+	}
+
+	ReviewConfig struct {
+		File string
+	}
+
+	// ImportConfig limits the watch history imports, their movie lookups share the OMDb quota with every other call.
+	// One import takes up to MaxRows / LookupsPerSecond seconds, an import still running when shutdown begins stops
+	// there and reports its rows left as not imported
+	ImportConfig struct {
+		LookupsPerSecond float64
+		MaxRows          int
+	}
+
+	AuthConfig struct {
+		JWTSecret  string
+		Moderators []string
+	}
+
+	ListenConfig struct {
+		Port string
+	}
+
+	// MoviesConfig lists the providers movies are looked up in, the first one that doesn't fail answers searches
+	// and the details of all of them are merged, each field from the first provider knowing it unless
+	// Precedence orders the providers otherwise for the field, e.g. plot=tmdb/omdb
+	MoviesConfig struct {
+		Providers  []string
+		Precedence []string
+	}
+
+	DatasetConfig struct {
+		File string
+	}
+
+	OMDbConfig struct {
+		BaseURL string
+		APIKey  string
+		Timeout time.Duration
+	}
+
+	TMDbConfig struct {
+		BaseURL      string
+		APIKey       string
+		ImageBaseURL string
+		Timeout      time.Duration
+	}
+
+	// FullTextConfig is where the full-text index of the movie details fetched is saved, and how often
+	FullTextConfig struct {
+		File          string
+		FlushInterval time.Duration
+	}
+
+	// SuggestConfig is how many searchwords are suggested for a search finding nothing, none when Max is 0,
+	// and whether the best one is searched instead
+	SuggestConfig struct {
+		Max   int
+		Retry bool
+	}
+
+	// AutocompleteConfig is how many completions a prefix has at most, the limit of the requests is capped by it
+	AutocompleteConfig struct {
+		Limit int
+	}
+
+	// SimilarConfig weighs what movies have in common when similar ones are ranked: the share of the genres,
+	// directors, writers and actors they have both, and how close their decade and their IMDb rating are
+	SimilarConfig struct {
+		GenresWeight   float64
+		DirectorWeight float64
+		WritersWeight  float64
+		CastWeight     float64
+		DecadeWeight   float64
+		RatingWeight   float64
+	}
+
+	CacheConfig struct {
+		Enabled    bool
+		TTL        time.Duration
+		MaxEntries int
+	}
+
+	LogConfig struct {
+		SearchLogFile string
+	}
+
+	TracingConfig struct {
+		Exporter     string
+		OTLPEndpoint string
+	}
+
+	HealthConfig struct {
+		Interval time.Duration
+		Timeout  time.Duration
+	}
+
+	ShutdownConfig struct {
+		Timeout time.Duration
+	}
+
+	TLSConfig struct {
+		CertFile       string
+		KeyFile        string
+		ClientCAFile   string
+		ClientAuth     string
+		CAFile         string
+		ServerName     string
+		ReloadInterval time.Duration
+	}
+
+	CORSConfig struct {
+		AllowedOrigins   []string
+		AllowedMethods   []string
+		AllowedHeaders   []string
+		ExposedHeaders   []string
+		AllowCredentials bool
+		MaxAge           time.Duration
+	}
+
+	Config struct {
+		GRPC         GRPCConfig
+		REST         RESTConfig
+		CORS         CORSConfig
+		GraphQL      GraphQLConfig
+		Watchlist    WatchlistConfig
+		Review       ReviewConfig
+		Import       ImportConfig
+		Auth         AuthConfig
+		Listen       ListenConfig
+		Movies       MoviesConfig
+		Dataset      DatasetConfig
+		OMDb         OMDbConfig
+		TMDb         TMDbConfig
+		Cache        CacheConfig
+		FullText     FullTextConfig
+		Suggest      SuggestConfig
+		Autocomplete AutocompleteConfig
+		Similar      SimilarConfig
+		Log          LogConfig
+		Tracing      TracingConfig
+		Health       HealthConfig
+		Shutdown     ShutdownConfig
+		TLS          TLSConfig
+
+		// PrintConfig asks to print the effective configuration and exit
+		PrintConfig bool
+
+		sources map[string]string
+	}
+)
+
+// field binds one setting to its config file key, env variable and flag name
+type field struct {
+	key    string
+	env    string
+	usage  string
+	secret bool
+	target interface{}
+}
+
+func Default() *Config {
+	return &Config{
+		GRPC: GRPCConfig{Port: "8080"},
+		REST: RESTConfig{
+			Port:               "8081",
+			Gateway:            GatewayDial,
+			GatewayCompression: compression.None,
+			Compression:        []string{compression.Zstd, compression.Gzip},
+			CompressionMinSize: 1024,
+		},
+		CORS: CORSConfig{
+			AllowedMethods: []string{"GET"},
+			AllowedHeaders: []string{"Accept", "Accept-Language", "Content-Type", "If-None-Match", "If-Modified-Since", "X-Request-Id"},
+			ExposedHeaders: []string{"ETag", "Retry-After", "X-Request-Id"},
+			MaxAge:         10 * time.Minute,
+		},
+		GraphQL: GraphQLConfig{
+			Enabled:       true,
+			MaxDepth:      15,
+			MaxComplexity: 500,
+		},
+		Watchlist: WatchlistConfig{File: "watchlists.json"},
+		Review:    ReviewConfig{File: "reviews.json"},
+		Import:    ImportConfig{LookupsPerSecond: 2, MaxRows: 500},
+		Movies:    MoviesConfig{Providers: []string{ProviderOMDb}},
+		Dataset:   DatasetConfig{File: "imdb.dataset"},
+		OMDb: OMDbConfig{
+			BaseURL: "http://www.omdbapi.com",
+			Timeout: 10 * time.Second,
+		},
+		TMDb: TMDbConfig{
+			BaseURL:      "https://api.themoviedb.org/3",
+			ImageBaseURL: "https://image.tmdb.org/t/p/w500",
+			Timeout:      10 * time.Second,
+		},
+		Cache: CacheConfig{
+			Enabled:    true,
+			TTL:        time.Hour,
+			MaxEntries: 1000,
+		},
+		FullText:     FullTextConfig{File: "fulltext.index", FlushInterval: time.Minute},
+		Suggest:      SuggestConfig{Max: 5},
+		Autocomplete: AutocompleteConfig{Limit: 10},
+		Log:          LogConfig{SearchLogFile: "search.log"},
+		Tracing:      TracingConfig{Exporter: tracing.ExporterNone},
+		Health:       HealthConfig{Interval: 30 * time.Second, Timeout: 5 * time.Second},
+		Shutdown:     ShutdownConfig{Timeout: 15 * time.Second},
+		Similar: SimilarConfig{
+			GenresWeight:   3,
+			DirectorWeight: 2,
+			WritersWeight:  1.5,
+			CastWeight:     2,
+			DecadeWeight:   1,
+			RatingWeight:   1,
+		},
+		TLS: TLSConfig{
+			ClientAuth:     tlsconfig.ClientAuthRequire,
+			ReloadInterval: time.Minute,
+		},
+	}
+}
+
+func (c *Config) fields() []field {
+	return []field{
+		{"grpc.port", "GRPC_PORT", "port the GRPC server listens to", false, &c.GRPC.Port},
+		{"grpc.reflection", "GRPC_REFLECTION", "register the GRPC server reflection service", false, &c.GRPC.Reflection},
+		{"rest.port", "REST_PORT", "port the REST HTTP server listens to", false, &c.REST.Port},
+		{"rest.gateway", "REST_GATEWAY", "how the REST gateway reaches the GRPC service: dial or direct", false, &c.REST.Gateway},
+		{"rest.gateway_compression", "REST_GATEWAY_COMPRESSION", "compressor the dialing gateway calls the GRPC server with: none, gzip or zstd", false, &c.REST.GatewayCompression},
+		{"rest.compression", "REST_COMPRESSION", "encodings REST responses are compressed with by order of preference: zstd and gzip, or none", false, &c.REST.Compression},
+		{"rest.compression_min_size", "REST_COMPRESSION_MIN_SIZE", "REST responses shorter than this many bytes are not compressed", false, &c.REST.CompressionMinSize},
+		{"cors.allowed_origins", "CORS_ALLOWED_ORIGINS", "origins allowed to call the REST API from a browser, * or https://*.example.com wildcards, CORS is off when empty", false, &c.CORS.AllowedOrigins},
+		{"cors.allowed_methods", "CORS_ALLOWED_METHODS", "methods allowed in cross-origin requests", false, &c.CORS.AllowedMethods},
+		{"cors.allowed_headers", "CORS_ALLOWED_HEADERS", "request headers allowed in cross-origin requests, * for any", false, &c.CORS.AllowedHeaders},
+		{"cors.exposed_headers", "CORS_EXPOSED_HEADERS", "response headers readable by cross-origin callers", false, &c.CORS.ExposedHeaders},
+		{"cors.allow_credentials", "CORS_ALLOW_CREDENTIALS", "allow cookies and authorization headers in cross-origin requests", false, &c.CORS.AllowCredentials},
+		{"cors.max_age", "CORS_MAX_AGE", "how long browsers may cache a preflight answer", false, &c.CORS.MaxAge},
+		{"graphql.enabled", "GRAPHQL_ENABLED", "serve GraphQL queries at /graphql on the REST server", false, &c.GraphQL.Enabled},
+		{"graphql.max_depth", "GRAPHQL_MAX_DEPTH", "how deep fields may be nested in a GraphQL query", false, &c.GraphQL.MaxDepth},
+		{"graphql.max_complexity", "GRAPHQL_MAX_COMPLEXITY", "most a GraphQL query may cost, a field costs 1 and one calling OMDb 10", false, &c.GraphQL.MaxComplexity},
+		{"watchlist.file", "WATCHLIST_FILE", "file the watchlists are stored in", false, &c.Watchlist.File},
+		{"review.file", "REVIEW_FILE", "file the reviews are stored in", false, &c.Review.File},
+		{"import.lookups_per_second", "IMPORT_LOOKUPS_PER_SECOND", "how many OMDb lookups a watch history import makes per second at most, an import takes up to import.max_rows / import.lookups_per_second seconds", false, &c.Import.LookupsPerSecond},
+		{"import.max_rows", "IMPORT_MAX_ROWS", "most rows an imported watch history may have", false, &c.Import.MaxRows},
+		{"auth.jwt_secret", "AUTH_JWT_SECRET", "HS256 secret of the bearer tokens, at least 32 bytes, enables the Watchlist and Review services", true, &c.Auth.JWTSecret},
+		{"auth.moderators", "AUTH_MODERATORS", "token subjects allowed to moderate reviews", false, &c.Auth.Moderators},
+		{"listen.port", "LISTEN_PORT", "serve GRPC and REST together on this port instead of grpc.port and rest.port", false, &c.Listen.Port},
+		{"movies.providers", "MOVIES_PROVIDERS", "where movies are looked up, in order: omdb, dataset and tmdb", false, &c.Movies.Providers},
+		{"movies.precedence", "MOVIES_PRECEDENCE", "providers fields are taken from first, like plot=tmdb/omdb, by JSON field name", false, &c.Movies.Precedence},
+		{"dataset.file", "DATASET_FILE", "IMDb dataset file built by cmd/dataset, read when movies.providers has dataset", false, &c.Dataset.File},
+		{"omdb.base_url", "OMDB_BASE_URL", "OMDb API base URL", false, &c.OMDb.BaseURL},
+		{"omdb.api_key", "API_KEY", "OMDb API key", true, &c.OMDb.APIKey},
+		{"omdb.timeout", "OMDB_TIMEOUT", "timeout of a single OMDb request", false, &c.OMDb.Timeout},
+		{"tmdb.base_url", "TMDB_BASE_URL", "TMDb API base URL", false, &c.TMDb.BaseURL},
+		{"tmdb.api_key", "TMDB_API_KEY", "TMDb API key (v3), read when movies.providers has tmdb", true, &c.TMDb.APIKey},
+		{"tmdb.image_base_url", "TMDB_IMAGE_BASE_URL", "base URL of the TMDb posters", false, &c.TMDb.ImageBaseURL},
+		{"tmdb.timeout", "TMDB_TIMEOUT", "timeout of a single TMDb request", false, &c.TMDb.Timeout},
+		{"cache.enabled", "CACHE_ENABLED", "cache OMDb responses in memory", false, &c.Cache.Enabled},
+		{"cache.ttl", "CACHE_TTL", "how long a cached OMDb response stays fresh", false, &c.Cache.TTL},
+		{"cache.max_entries", "CACHE_MAX_ENTRIES", "maximum number of cached OMDb responses", false, &c.Cache.MaxEntries},
+		{"fulltext.file", "FULLTEXT_FILE", "file the full-text index of the movie details is saved to", false, &c.FullText.File},
+		{"fulltext.flush_interval", "FULLTEXT_FLUSH_INTERVAL", "how often new movies of the full-text index are saved, and on shutdown", false, &c.FullText.FlushInterval},
+		{"suggest.max", "SUGGEST_MAX", "how many searchwords are suggested when a search finds nothing, 0 for none", false, &c.Suggest.Max},
+		{"suggest.retry", "SUGGEST_RETRY", "search the best suggestion instead when a search finds nothing", false, &c.Suggest.Retry},
+		{"autocomplete.limit", "AUTOCOMPLETE_LIMIT", "how many titles complete a prefix at most", false, &c.Autocomplete.Limit},
+		{"similar.genres_weight", "SIMILAR_GENRES_WEIGHT", "how much sharing genres makes movies similar", false, &c.Similar.GenresWeight},
+		{"similar.director_weight", "SIMILAR_DIRECTOR_WEIGHT", "how much sharing a director makes movies similar", false, &c.Similar.DirectorWeight},
+		{"similar.writers_weight", "SIMILAR_WRITERS_WEIGHT", "how much sharing writers makes movies similar", false, &c.Similar.WritersWeight},
+		{"similar.cast_weight", "SIMILAR_CAST_WEIGHT", "how much sharing actors makes movies similar", false, &c.Similar.CastWeight},
+		{"similar.decade_weight", "SIMILAR_DECADE_WEIGHT", "how much a release in the same decade makes movies similar, half as much for the next one", false, &c.Similar.DecadeWeight},
+		{"similar.rating_weight", "SIMILAR_RATING_WEIGHT", "how much a close IMDb rating makes movies similar", false, &c.Similar.RatingWeight},
+		{"log.search_log_file", "SEARCH_LOG_FILE", "file the search calls are logged into", false, &c.Log.SearchLogFile},
+		{"tracing.exporter", "TRACE_EXPORTER", "trace exporter: none, stdout or otlp", false, &c.Tracing.Exporter},
+		{"tracing.otlp_endpoint", "TRACE_OTLP_ENDPOINT", "OTLP collector address, e.g. localhost:4317", false, &c.Tracing.OTLPEndpoint},
+		{"health.interval", "HEALTH_CHECK_INTERVAL", "interval between dependency health checks", false, &c.Health.Interval},
+		{"health.timeout", "HEALTH_CHECK_TIMEOUT", "timeout of a single dependency health check", false, &c.Health.Timeout},
+		{"shutdown.timeout", "SHUTDOWN_TIMEOUT", "how long in-flight requests may drain on shutdown", false, &c.Shutdown.Timeout},
+		{"tls.cert_file", "TLS_CERT_FILE", "PEM certificate, enables TLS on both servers together with tls.key_file", false, &c.TLS.CertFile},
+		{"tls.key_file", "TLS_KEY_FILE", "PEM private key of tls.cert_file", false, &c.TLS.KeyFile},
+		{"tls.client_ca_file", "TLS_CLIENT_CA_FILE", "PEM CA bundle, enables mutual TLS with client certificates signed by it", false, &c.TLS.ClientCAFile},
+		{"tls.client_auth", "TLS_CLIENT_AUTH", "with mutual TLS, require or optional client certificates", false, &c.TLS.ClientAuth},
+		{"tls.ca_file", "TLS_CA_FILE", "PEM CA bundle the gateway verifies the GRPC server with, system roots when empty", false, &c.TLS.CAFile},
+		{"tls.server_name", "TLS_SERVER_NAME", "name the gateway expects in the GRPC server certificate, 127.0.0.1 when empty", false, &c.TLS.ServerName},
+		{"tls.reload_interval", "TLS_RELOAD_INTERVAL", "how often certificate files are checked for changes", false, &c.TLS.ReloadInterval},
+	}
+}
+
+// Load builds the configuration from, in increasing order of precedence:
+// defaults, the optional config file (YAML or TOML), environment variables and flags.
+// Environment variables may also come from an env file, real ones win over it
+func Load(args []string, lookupEnv func(string) (string, bool), output io.Writer) (*Config, error) {
+	cfg := Default()
+	cfg.sources = map[string]string{}
+
+	fields := cfg.fields()
+	for _, f := range fields {
+		cfg.sources[f.key] = sourceDefault
+	}
+
+	fs := flag.NewFlagSet("sbtest-2", flag.ContinueOnError)
+	fs.SetOutput(output)
+	configFile := fs.String("config", "", "path to a YAML or TOML config file (env CONFIG_FILE)")
+	envFile := fs.String("env-file", "shouldnotbeuploaded.env", "optional file with KEY=VALUE env variables")
+	fs.BoolVar(&cfg.PrintConfig, "print-config", false, "print the effective configuration with secrets masked and exit")
+
+	flagValues := make(map[string]*string, len(fields))
+	for _, f := range fields {
+		flagValues[f.key] = fs.String(flagName(f.key), "", fmt.Sprintf("%s (env %s)", f.usage, f.env))
+	}
+
+	err := fs.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	envFromFile := map[string]string{}
+	if _, err := os.Stat(*envFile); err == nil {
+		envFromFile, err = godotenv.Read(*envFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading env file %s: %w", *envFile, err)
+		}
+	}
+	getEnv := func(key string) (string, bool) {
+		value, ok := lookupEnv(key)
+		if ok && value != "" {
+			return value, true
+		}
+		value, ok = envFromFile[key]
+		return value, ok && value != ""
+	}
+
+	if *configFile == "" {
+		*configFile, _ = getEnv("CONFIG_FILE")
+	}
+
+	var errs []string
+	if *configFile != "" {
+		values, err := readFile(*configFile)
+		if err != nil {
+			return nil, err
+		}
+
+		known := make(map[string]bool, len(fields))
+		for _, f := range fields {
+			known[f.key] = true
+			if raw, ok := values[f.key]; ok {
+				errs = cfg.apply(errs, f, raw, sourceFile, fmt.Sprintf("%s in %s", f.key, *configFile))
+			}
+		}
+		for key := range values {
+			if !known[key] {
+				errs = append(errs, fmt.Sprintf("unknown key %s in %s", key, *configFile))
+			}
+		}
+	}
+
+	for _, f := range fields {
+		if raw, ok := getEnv(f.env); ok {
+			errs = cfg.apply(errs, f, raw, sourceEnv, "env "+f.env)
+		}
+	}
+
+	fs.Visit(func(fl *flag.Flag) {
+		for _, f := range fields {
+			if flagName(f.key) == fl.Name {
+				errs = cfg.apply(errs, f, *flagValues[f.key], sourceFlag, "flag --"+fl.Name)
+			}
+		}
+	})
+
+	errs = append(errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return cfg, fmt.Errorf("invalid configuration:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) apply(errs []string, f field, raw, source, origin string) []string {
+	err := setValue(f.target, raw)
+	if err != nil {
+		return append(errs, fmt.Sprintf("%s: %v", origin, err))
+	}
+
+	c.sources[f.key] = source
+	return errs
+}
+
+func (c *Config) validate() (errs []string) {
+	ports := []struct{ key, port string }{{"grpc.port", c.GRPC.Port}, {"rest.port", c.REST.Port}}
+	if c.SinglePort() {
+		ports = []struct{ key, port string }{{"listen.port", c.Listen.Port}}
+	}
+	for _, p := range ports {
+		port, err := strconv.Atoi(p.port)
+		if err != nil || port < 1 || port > 65535 {
+			errs = append(errs, fmt.Sprintf("%s must be a number between 1 and 65535, got %q", p.key, p.port))
+		}
+	}
+	if !c.SinglePort() && c.GRPC.Port == c.REST.Port {
+		errs = append(errs, fmt.Sprintf("grpc.port and rest.port must differ, both are %s", c.GRPC.Port))
+	}
+
+	errs = append(errs, c.validateMovies()...)
+
+	for _, d := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"omdb.timeout", c.OMDb.Timeout},
+		{"tmdb.timeout", c.TMDb.Timeout},
+		{"fulltext.flush_interval", c.FullText.FlushInterval},
+		{"health.interval", c.Health.Interval},
+		{"health.timeout", c.Health.Timeout},
+		{"shutdown.timeout", c.Shutdown.Timeout},
+	} {
+		if d.value <= 0 {
+			errs = append(errs, fmt.Sprintf("%s must be greater than 0, got %s", d.key, d.value))
+		}
+	}
+
+	if c.Cache.Enabled && c.Cache.TTL <= 0 {
+		errs = append(errs, fmt.Sprintf("cache.ttl must be greater than 0 when the cache is enabled, got %s", c.Cache.TTL))
+	}
+	if c.Cache.MaxEntries < 0 {
+		errs = append(errs, fmt.Sprintf("cache.max_entries can't be negative, got %d", c.Cache.MaxEntries))
+	}
+
+	if c.Suggest.Max < 0 {
+		errs = append(errs, fmt.Sprintf("suggest.max can't be negative, got %d", c.Suggest.Max))
+	}
+	if c.Autocomplete.Limit < 1 || c.Autocomplete.Limit > maxAutocompleteLimit {
+		errs = append(errs, fmt.Sprintf("autocomplete.limit must be between 1 and %d, got %d", maxAutocompleteLimit, c.Autocomplete.Limit))
+	}
+
+	for _, w := range []struct {
+		key   string
+		value float64
+	}{
+		{"similar.genres_weight", c.Similar.GenresWeight},
+		{"similar.director_weight", c.Similar.DirectorWeight},
+		{"similar.writers_weight", c.Similar.WritersWeight},
+		{"similar.cast_weight", c.Similar.CastWeight},
+		{"similar.decade_weight", c.Similar.DecadeWeight},
+		{"similar.rating_weight", c.Similar.RatingWeight},
+	} {
+		if w.value < 0 {
+			errs = append(errs, fmt.Sprintf("%s can't be negative, got %v", w.key, w.value))
+		}
+	}
+
+	if c.FullText.File == "" {
+		errs = append(errs, "fulltext.file can't be empty")
+	}
+
+	if c.Log.SearchLogFile == "" {
+		errs = append(errs, "log.search_log_file can't be empty")
+	}
+
+	switch c.REST.Gateway {
+	case GatewayDial, GatewayDirect:
+	default:
+		errs = append(errs, fmt.Sprintf("rest.gateway must be dial or direct, got %q", c.REST.Gateway))
+	}
+	switch c.REST.GatewayCompression {
+	case compression.None, compression.Gzip, compression.Zstd:
+	default:
+		errs = append(errs, fmt.Sprintf("rest.gateway_compression must be none, gzip or zstd, got %q", c.REST.GatewayCompression))
+	}
+	for _, encoding := range c.REST.Compression {
+		if encoding != compression.Gzip && encoding != compression.Zstd && (encoding != compression.None || len(c.REST.Compression) > 1) {
+			errs = append(errs, fmt.Sprintf("rest.compression must list gzip and zstd or be none, got %q", encoding))
+		}
+	}
+	if c.REST.CompressionMinSize < 0 {
+		errs = append(errs, fmt.Sprintf("rest.compression_min_size can't be negative, got %d", c.REST.CompressionMinSize))
+	}
+
+	if c.GraphQL.Enabled && (c.GraphQL.MaxDepth <= 0 || c.GraphQL.MaxComplexity <= 0) {
+		errs = append(errs, fmt.Sprintf("graphql.max_depth and graphql.max_complexity must be greater than 0, got %d and %d", c.GraphQL.MaxDepth, c.GraphQL.MaxComplexity))
+	}
+
+	if c.AuthEnabled() && len(c.Auth.JWTSecret) < minJWTSecretSize {
+		errs = append(errs, fmt.Sprintf("auth.jwt_secret must be at least %d bytes long, got %d", minJWTSecretSize, len(c.Auth.JWTSecret)))
+	}
+	if c.AuthEnabled() && c.Watchlist.File == "" {
+		errs = append(errs, "watchlist.file can't be empty when auth.jwt_secret is set")
+	}
+	if c.AuthEnabled() && c.Review.File == "" {
+		errs = append(errs, "review.file can't be empty when auth.jwt_secret is set")
+	}
+	if c.AuthEnabled() && (c.Import.LookupsPerSecond <= 0 || c.Import.MaxRows <= 0) {
+		errs = append(errs, fmt.Sprintf("import.lookups_per_second and import.max_rows must be greater than 0, got %v and %d", c.Import.LookupsPerSecond, c.Import.MaxRows))
+	}
+
+	errs = append(errs, c.validateTLS()...)
+	errs = append(errs, c.validateCORS()...)
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		errs = append(errs, fmt.Sprintf("tracing.exporter must be one of none, stdout or otlp, got %q", c.Tracing.Exporter))
+	}
+
+	return errs
+}
+
+func (c *Config) validateMovies() (errs []string) {
+	if len(c.Movies.Providers) == 0 {
+		errs = append(errs, "movies.providers can't be empty")
+	}
+
+	configured := map[string]bool{}
+	for _, provider := range c.Movies.Providers {
+		if configured[provider] {
+			errs = append(errs, fmt.Sprintf("movies.providers lists %s twice", provider))
+			continue
+		}
+		configured[provider] = true
+
+		switch provider {
+		case ProviderOMDb:
+			if !absoluteHTTPURL(c.OMDb.BaseURL) {
+				errs = append(errs, fmt.Sprintf("omdb.base_url must be an absolute http(s) URL, got %q", c.OMDb.BaseURL))
+			}
+			if c.OMDb.APIKey == "" {
+				errs = append(errs, "omdb.api_key is required, set API_KEY, --omdb-api-key or omdb.api_key in the config file")
+			}
+		case ProviderDataset:
+			if c.Dataset.File == "" {
+				errs = append(errs, "dataset.file can't be empty when movies.providers has dataset")
+			}
+		case ProviderTMDb:
+			for _, u := range []struct{ key, value string }{{"tmdb.base_url", c.TMDb.BaseURL}, {"tmdb.image_base_url", c.TMDb.ImageBaseURL}} {
+				if !absoluteHTTPURL(u.value) {
+					errs = append(errs, fmt.Sprintf("%s must be an absolute http(s) URL, got %q", u.key, u.value))
+				}
+			}
+			if c.TMDb.APIKey == "" {
+				errs = append(errs, "tmdb.api_key is required when movies.providers has tmdb, set TMDB_API_KEY, --tmdb-api-key or tmdb.api_key in the config file")
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("movies.providers must list omdb, dataset or tmdb, got %q", provider))
+		}
+	}
+
+	for _, entry := range c.Movies.Precedence {
+		field, providers, ok := parsePrecedence(entry)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("movies.precedence entries must look like field=provider/provider, got %q", entry))
+			continue
+		}
+		for _, provider := range providers {
+			if !configured[provider] {
+				errs = append(errs, fmt.Sprintf("movies.precedence of %s has %q, which is not in movies.providers", field, provider))
+			}
+		}
+	}
+
+	return errs
+}
+
+// Precedence maps the fields of movies.precedence to the providers they are taken from first
+func (c *Config) Precedence() map[string][]string {
+	precedence := map[string][]string{}
+	for _, entry := range c.Movies.Precedence {
+		if field, providers, ok := parsePrecedence(entry); ok {
+			precedence[field] = providers
+		}
+	}
+
+	return precedence
+}
+
+// parsePrecedence splits a field=provider/provider entry of movies.precedence
+func parsePrecedence(entry string) (field string, providers []string, ok bool) {
+	i := strings.Index(entry, "=")
+	if i <= 0 || i == len(entry)-1 {
+		return "", nil, false
+	}
+
+	field = strings.TrimSpace(entry[:i])
+	for _, provider := range strings.Split(entry[i+1:], "/") {
+		provider = strings.TrimSpace(provider)
+		if provider == "" {
+			return "", nil, false
+		}
+		providers = append(providers, provider)
+	}
+
+	return field, providers, field != ""
+}
+
+func absoluteHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// SinglePort tells whether GRPC and REST share listen.port rather than listening to their own ports
+func (c *Config) SinglePort() bool {
+	return c.Listen.Port != ""
+}
+
+// AuthEnabled tells whether callers can authenticate with tokens signed with auth.jwt_secret,
+// the Watchlist and Review services are only served when they can
+func (c *Config) AuthEnabled() bool {
+	return c.Auth.JWTSecret != ""
+}
+
+// TLSEnabled tells whether both servers and the gateway hop use TLS
+func (c *Config) TLSEnabled() bool {
+	return c.TLS.CertFile != ""
+}
+
+func (c *Config) validateTLS() (errs []string) {
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		errs = append(errs, "tls.cert_file and tls.key_file must be set together")
+	}
+	if !c.TLSEnabled() && (c.TLS.ClientCAFile != "" || c.TLS.CAFile != "") {
+		errs = append(errs, "tls.client_ca_file and tls.ca_file need tls.cert_file and tls.key_file")
+	}
+
+	for _, f := range []struct{ key, path string }{
+		{"tls.cert_file", c.TLS.CertFile},
+		{"tls.key_file", c.TLS.KeyFile},
+		{"tls.client_ca_file", c.TLS.ClientCAFile},
+		{"tls.ca_file", c.TLS.CAFile},
+	} {
+		if f.path == "" {
+			continue
+		}
+		if _, err := os.Stat(f.path); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", f.key, err))
+		}
+	}
+
+	switch c.TLS.ClientAuth {
+	case tlsconfig.ClientAuthRequire, tlsconfig.ClientAuthOptional:
+	default:
+		errs = append(errs, fmt.Sprintf("tls.client_auth must be require or optional, got %q", c.TLS.ClientAuth))
+	}
+
+	if c.TLS.ReloadInterval <= 0 {
+		errs = append(errs, fmt.Sprintf("tls.reload_interval must be greater than 0, got %s", c.TLS.ReloadInterval))
+	}
+
+	return errs
+}
+
+// RESTEncodings returns the encodings REST responses may be compressed with, none when compression is off
+func (c *Config) RESTEncodings() []string {
+	if len(c.REST.Compression) == 1 && c.REST.Compression[0] == compression.None {
+		return nil
+	}
+
+	return c.REST.Compression
+}
+
+// CORSEnabled tells whether the REST server answers cross-origin requests
+func (c *Config) CORSEnabled() bool {
+	return len(c.CORS.AllowedOrigins) > 0
+}
+
+func (c *Config) validateCORS() (errs []string) {
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				errs = append(errs, "cors.allowed_origins can't be * when cors.allow_credentials is set, list the origins")
+			}
+			continue
+		}
+
+		u, err := url.Parse(strings.Replace(origin, "*", "wildcard", 1))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" || u.RawQuery != "" ||
+			strings.Count(origin, "*") > 1 || (strings.Contains(origin, "*") && !strings.HasPrefix(u.Host, "wildcard.")) {
+			errs = append(errs, fmt.Sprintf("cors.allowed_origins must be * or origins like https://app.example.com or https://*.example.com, got %q", origin))
+		}
+	}
+
+	if c.CORSEnabled() && len(c.CORS.AllowedMethods) == 0 {
+		errs = append(errs, "cors.allowed_methods can't be empty when cors.allowed_origins is set")
+	}
+	if c.CORS.MaxAge < 0 {
+		errs = append(errs, fmt.Sprintf("cors.max_age can't be negative, got %s", c.CORS.MaxAge))
+	}
+
+	return errs
+}
+
+// Print writes the effective configuration and where each value came from, secrets are masked
+func (c *Config) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, f := range c.fields() {
+		value := formatValue(f.target)
+		if f.secret && value != "" {
+			value = mask
+		}
+
+		source := c.sources[f.key]
+		if source == "" {
+			source = sourceDefault
+		}
+		fmt.Fprintf(tw, "%s\t%s\t(%s)\n", f.key, value, source)
+	}
+
+	return tw.Flush()
+}
+
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	raw := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &raw)
+	case ".toml":
+		err = toml.Unmarshal(content, &raw)
+	default:
+		return nil, fmt.Errorf("config file %s must have a .yaml, .yml or .toml extension", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	err = flatten("", raw, values)
+	if err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+func flatten(prefix string, raw map[string]interface{}, values map[string]string) error {
+	for key, value := range raw {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			err := flatten(key, v, values)
+			if err != nil {
+				return err
+			}
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				switch item.(type) {
+				case map[string]interface{}, []interface{}:
+					return fmt.Errorf("%s: only lists of values are supported", key)
+				}
+				items = append(items, fmt.Sprint(item))
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+
+	return nil
+}
+
+func setValue(target interface{}, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	switch t := target.(type) {
+	case *string:
+		*t = raw
+	case *bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", raw)
+		}
+		*t = value
+	case *int:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", raw)
+		}
+		*t = value
+	case *float64:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", raw)
+		}
+		*t = value
+	case *time.Duration:
+		value, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration, use values like 500ms, 10s or 1h", raw)
+		}
+		*t = value
+	case *[]string:
+		// comma separated, an empty value clears the list
+		*t = nil
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*t = append(*t, item)
+			}
+		}
+	default:
+		return errors.New("unsupported setting type")
+	}
+
+	return nil
+}
+
+func formatValue(target interface{}) string {
+	switch t := target.(type) {
+	case *string:
+		return *t
+	case *bool:
+		return strconv.FormatBool(*t)
+	case *int:
+		return strconv.Itoa(*t)
+	case *float64:
+		return strconv.FormatFloat(*t, 'f', -1, 64)
+	case *time.Duration:
+		return t.String()
+	case *[]string:
+		return strings.Join(*t, ",")
+	}
+
+	return ""
+}
+
+func flagName(key string) string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(key)
+}
@@ -0,0 +1,470 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func envOf(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func writeTempFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	noEnvFile := []string{"--env-file", "does-not-exist.env"}
+
+	t.Run("[Load] defaults with only the api key set", func(t *testing.T) {
+		cfg, err := Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, "8080", cfg.GRPC.Port)
+			assert.Equal(t, "8081", cfg.REST.Port)
+			assert.Equal(t, "http://www.omdbapi.com", cfg.OMDb.BaseURL)
+			assert.Equal(t, "search.log", cfg.Log.SearchLogFile)
+			assert.Equal(t, time.Hour, cfg.Cache.TTL)
+			assert.Equal(t, "secret", cfg.OMDb.APIKey)
+		}
+	})
+
+	t.Run("[Load] missing api key is reported with how to set it", func(t *testing.T) {
+		_, err := Load(noEnvFile, envOf(nil), ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "omdb.api_key is required")
+			assert.Contains(t, err.Error(), "API_KEY")
+		}
+	})
+
+	t.Run("[Load] flags win over env, env wins over the config file", func(t *testing.T) {
+		path := writeTempFile(t, "config.yaml", `
+grpc:
+  port: 9000
+rest:
+  port: 9001
+omdb:
+  api_key: from-file
+  timeout: 3s
+cache:
+  max_entries: 10
+`)
+		env := envOf(map[string]string{"GRPC_PORT": "9100", "API_KEY": "from-env"})
+		args := append(noEnvFile, "--config", path, "--grpc-port", "9200")
+
+		cfg, err := Load(args, env, ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, "9200", cfg.GRPC.Port)
+			assert.Equal(t, "9001", cfg.REST.Port)
+			assert.Equal(t, "from-env", cfg.OMDb.APIKey)
+			assert.Equal(t, 3*time.Second, cfg.OMDb.Timeout)
+			assert.Equal(t, 10, cfg.Cache.MaxEntries)
+		}
+	})
+
+	t.Run("[Load] toml config file from CONFIG_FILE", func(t *testing.T) {
+		path := writeTempFile(t, "config.toml", `
+[omdb]
+api_key = "from-toml"
+base_url = "https://omdb.example.com"
+
+[cache]
+enabled = false
+`)
+		cfg, err := Load(noEnvFile, envOf(map[string]string{"CONFIG_FILE": path}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, "from-toml", cfg.OMDb.APIKey)
+			assert.Equal(t, "https://omdb.example.com", cfg.OMDb.BaseURL)
+			assert.False(t, cfg.Cache.Enabled)
+		}
+	})
+
+	t.Run("[Load] env file is a fallback for real env variables", func(t *testing.T) {
+		path := writeTempFile(t, "test.env", "API_KEY=from-env-file\nREST_PORT=7000\n")
+		env := envOf(map[string]string{"REST_PORT": "7001"})
+
+		cfg, err := Load([]string{"--env-file", path}, env, ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, "from-env-file", cfg.OMDb.APIKey)
+			assert.Equal(t, "7001", cfg.REST.Port)
+		}
+	})
+
+	t.Run("[Load] every invalid value is reported at once", func(t *testing.T) {
+		path := writeTempFile(t, "config.yml", `
+grpc:
+  port: abc
+omdb:
+  base_url: omdbapi.com
+  timeout: soon
+unknown:
+  key: 1
+`)
+		env := envOf(map[string]string{"API_KEY": "secret", "CACHE_ENABLED": "maybe"})
+		_, err := Load(append(noEnvFile, "--config", path), env, ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "grpc.port must be a number")
+			assert.Contains(t, err.Error(), "omdb.base_url must be an absolute http(s) URL")
+			assert.Contains(t, err.Error(), `omdb.timeout in `+path+`: "soon" is not a duration`)
+			assert.Contains(t, err.Error(), `env CACHE_ENABLED: "maybe" is not a boolean`)
+			assert.Contains(t, err.Error(), "unknown key unknown.key")
+		}
+	})
+
+	t.Run("[Load] same port for both servers", func(t *testing.T) {
+		env := envOf(map[string]string{"API_KEY": "secret", "GRPC_PORT": "8080", "REST_PORT": "8080"})
+		_, err := Load(noEnvFile, env, ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "must differ")
+		}
+	})
+
+	t.Run("[Load] tls files must exist and come in pairs", func(t *testing.T) {
+		env := envOf(map[string]string{
+			"API_KEY":         "secret",
+			"TLS_CERT_FILE":   "does-not-exist.pem",
+			"TLS_CA_FILE":     "ca.pem",
+			"TLS_CLIENT_AUTH": "sometimes",
+		})
+		_, err := Load(noEnvFile, env, ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "tls.cert_file and tls.key_file must be set together")
+			assert.Contains(t, err.Error(), "tls.cert_file: stat does-not-exist.pem")
+			assert.Contains(t, err.Error(), "tls.ca_file: stat ca.pem")
+			assert.Contains(t, err.Error(), `tls.client_auth must be require or optional, got "sometimes"`)
+		}
+	})
+
+	t.Run("[Load] tls enabled with existing files", func(t *testing.T) {
+		cert := writeTempFile(t, "cert.pem", "")
+		key := writeTempFile(t, "key.pem", "")
+		env := envOf(map[string]string{"API_KEY": "secret", "TLS_CERT_FILE": cert, "TLS_KEY_FILE": key})
+
+		cfg, err := Load(noEnvFile, env, ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.True(t, cfg.TLSEnabled())
+			assert.Equal(t, "require", cfg.TLS.ClientAuth)
+			assert.Equal(t, time.Minute, cfg.TLS.ReloadInterval)
+		}
+	})
+
+	t.Run("[Load] single port mode ignores the per server ports", func(t *testing.T) {
+		env := envOf(map[string]string{"API_KEY": "secret", "LISTEN_PORT": "9000", "GRPC_PORT": "8080", "REST_PORT": "8080"})
+		cfg, err := Load(noEnvFile, env, ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.True(t, cfg.SinglePort())
+			assert.Equal(t, "9000", cfg.Listen.Port)
+		}
+	})
+
+	t.Run("[Load] invalid single port", func(t *testing.T) {
+		_, err := Load(append(noEnvFile, "--listen-port", "http"), envOf(map[string]string{"API_KEY": "secret"}), ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `listen.port must be a number between 1 and 65535, got "http"`)
+		}
+	})
+
+	t.Run("[Load] rest gateway mode", func(t *testing.T) {
+		cfg, err := Load(append(noEnvFile, "--rest-gateway", "direct"), envOf(map[string]string{"API_KEY": "secret"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, GatewayDirect, cfg.REST.Gateway)
+		}
+
+		_, err = Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret", "REST_GATEWAY": "proxy"}), ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `rest.gateway must be dial or direct, got "proxy"`)
+		}
+	})
+
+	t.Run("[Load] cors lists from env and config file", func(t *testing.T) {
+		cfg, err := Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.False(t, cfg.CORSEnabled())
+			assert.Equal(t, []string{"GET"}, cfg.CORS.AllowedMethods)
+		}
+
+		path := writeTempFile(t, "config.yaml", `
+cors:
+  allowed_origins:
+    - https://app.example.com
+    - https://*.example.org
+  allowed_headers: []
+  max_age: 1h
+`)
+		env := envOf(map[string]string{"API_KEY": "secret", "CORS_ALLOWED_METHODS": "GET, POST ,"})
+		cfg, err = Load(append(noEnvFile, "--config", path), env, ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.True(t, cfg.CORSEnabled())
+			assert.Equal(t, []string{"https://app.example.com", "https://*.example.org"}, cfg.CORS.AllowedOrigins)
+			assert.Equal(t, []string{"GET", "POST"}, cfg.CORS.AllowedMethods)
+			assert.Empty(t, cfg.CORS.AllowedHeaders)
+			assert.Equal(t, time.Hour, cfg.CORS.MaxAge)
+		}
+	})
+
+	t.Run("[Load] invalid cors origins", func(t *testing.T) {
+		for _, origin := range []string{"app.example.com", "https://app.example.com/", "ftp://example.com", "https://*", "https://app.*.com", "https://*.*.com"} {
+			_, err := Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret", "CORS_ALLOWED_ORIGINS": origin}), ioutil.Discard)
+			if assert.Error(t, err, origin) {
+				assert.Contains(t, err.Error(), "cors.allowed_origins must be")
+			}
+		}
+
+		env := envOf(map[string]string{"API_KEY": "secret", "CORS_ALLOWED_ORIGINS": "*", "CORS_ALLOW_CREDENTIALS": "true"})
+		_, err := Load(noEnvFile, env, ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "cors.allowed_origins can't be * when cors.allow_credentials is set")
+		}
+	})
+
+	t.Run("[Load] graphql limits", func(t *testing.T) {
+		_, err := Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret", "GRAPHQL_MAX_DEPTH": "0"}), ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "graphql.max_depth and graphql.max_complexity must be greater than 0")
+		}
+
+		// limits don't matter once GraphQL is off
+		cfg, err := Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret", "GRAPHQL_ENABLED": "false", "GRAPHQL_MAX_DEPTH": "0"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.False(t, cfg.GraphQL.Enabled)
+		}
+	})
+
+	t.Run("[Load] watchlists and reviews need a long enough jwt secret", func(t *testing.T) {
+		cfg, err := Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.False(t, cfg.AuthEnabled())
+			assert.Equal(t, "watchlists.json", cfg.Watchlist.File)
+			assert.Equal(t, "reviews.json", cfg.Review.File)
+		}
+
+		_, err = Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret", "AUTH_JWT_SECRET": "short"}), ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "auth.jwt_secret must be at least 32 bytes long, got 5")
+		}
+
+		env := envOf(map[string]string{
+			"API_KEY":         "secret",
+			"AUTH_JWT_SECRET": "0123456789abcdef0123456789abcdef",
+			"AUTH_MODERATORS": "admin, user-7",
+			"WATCHLIST_FILE":  "/data/watchlists.json",
+			"REVIEW_FILE":     "/data/reviews.json",
+		})
+		cfg, err = Load(noEnvFile, env, ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.True(t, cfg.AuthEnabled())
+			assert.Equal(t, "/data/watchlists.json", cfg.Watchlist.File)
+			assert.Equal(t, "/data/reviews.json", cfg.Review.File)
+			assert.Equal(t, []string{"admin", "user-7"}, cfg.Auth.Moderators)
+
+			var out bytes.Buffer
+			cfg.Print(&out)
+			assert.NotContains(t, out.String(), "0123456789abcdef")
+		}
+	})
+
+	t.Run("[Load] dataset provider needs no api key", func(t *testing.T) {
+		cfg, err := Load(noEnvFile, envOf(map[string]string{"MOVIES_PROVIDERS": "dataset"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, []string{ProviderDataset}, cfg.Movies.Providers)
+			assert.Equal(t, "imdb.dataset", cfg.Dataset.File)
+		}
+
+		_, err = Load(noEnvFile, envOf(map[string]string{"MOVIES_PROVIDERS": "imdb"}), ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `movies.providers must list omdb, dataset or tmdb, got "imdb"`)
+		}
+	})
+
+	t.Run("[Load] several providers with a precedence", func(t *testing.T) {
+		env := map[string]string{
+			"MOVIES_PROVIDERS":  "omdb,dataset,tmdb",
+			"MOVIES_PRECEDENCE": "plot=tmdb/omdb, poster = tmdb",
+			"API_KEY":           "secret",
+			"TMDB_API_KEY":      "tmdb-secret",
+		}
+		cfg, err := Load(noEnvFile, envOf(env), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, []string{ProviderOMDb, ProviderDataset, ProviderTMDb}, cfg.Movies.Providers)
+			assert.Equal(t, map[string][]string{"plot": {"tmdb", "omdb"}, "poster": {"tmdb"}}, cfg.Precedence())
+			assert.Equal(t, "https://api.themoviedb.org/3", cfg.TMDb.BaseURL)
+
+			var out bytes.Buffer
+			cfg.Print(&out)
+			assert.NotContains(t, out.String(), "tmdb-secret")
+		}
+
+		env = map[string]string{
+			"MOVIES_PROVIDERS":  "omdb,tmdb,omdb",
+			"MOVIES_PRECEDENCE": "plot=dataset,poster",
+			"API_KEY":           "secret",
+		}
+		_, err = Load(noEnvFile, envOf(env), ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "movies.providers lists omdb twice")
+			assert.Contains(t, err.Error(), "tmdb.api_key is required")
+			assert.Contains(t, err.Error(), `movies.precedence of plot has "dataset", which is not in movies.providers`)
+			assert.Contains(t, err.Error(), `movies.precedence entries must look like field=provider/provider, got "poster"`)
+		}
+	})
+
+	t.Run("[Load] import limits", func(t *testing.T) {
+		env := map[string]string{"API_KEY": "secret", "AUTH_JWT_SECRET": "0123456789abcdef0123456789abcdef"}
+		cfg, err := Load(noEnvFile, envOf(env), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, 2.0, cfg.Import.LookupsPerSecond)
+			assert.Equal(t, 500, cfg.Import.MaxRows)
+		}
+
+		env["IMPORT_LOOKUPS_PER_SECOND"] = "0.5"
+		cfg, err = Load(noEnvFile, envOf(env), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, 0.5, cfg.Import.LookupsPerSecond)
+		}
+
+		env["IMPORT_LOOKUPS_PER_SECOND"] = "fast"
+		env["IMPORT_MAX_ROWS"] = "0"
+		_, err = Load(noEnvFile, envOf(env), ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `env IMPORT_LOOKUPS_PER_SECOND: "fast" is not a number`)
+			assert.Contains(t, err.Error(), "import.lookups_per_second and import.max_rows must be greater than 0, got 2 and 0")
+		}
+	})
+
+	t.Run("[Load] full-text index", func(t *testing.T) {
+		cfg, err := Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, FullTextConfig{File: "fulltext.index", FlushInterval: time.Minute}, cfg.FullText)
+		}
+
+		_, err = Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret", "FULLTEXT_FLUSH_INTERVAL": "0s"}), ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "fulltext.flush_interval must be greater than 0, got 0s")
+		}
+	})
+
+	t.Run("[Load] suggestions", func(t *testing.T) {
+		cfg, err := Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, SuggestConfig{Max: 5}, cfg.Suggest)
+		}
+
+		cfg, err = Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret", "SUGGEST_MAX": "0", "SUGGEST_RETRY": "true"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, SuggestConfig{Max: 0, Retry: true}, cfg.Suggest)
+		}
+
+		_, err = Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret", "SUGGEST_MAX": "-1"}), ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "suggest.max can't be negative, got -1")
+		}
+	})
+
+	t.Run("[Load] autocomplete", func(t *testing.T) {
+		cfg, err := Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, AutocompleteConfig{Limit: 10}, cfg.Autocomplete)
+		}
+
+		cfg, err = Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret", "AUTOCOMPLETE_LIMIT": "20"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, AutocompleteConfig{Limit: 20}, cfg.Autocomplete)
+		}
+
+		for _, limit := range []string{"0", "101"} {
+			_, err = Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret", "AUTOCOMPLETE_LIMIT": limit}), ioutil.Discard)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "autocomplete.limit must be between 1 and 100, got "+limit)
+			}
+		}
+	})
+
+	t.Run("[Load] similar movie weights", func(t *testing.T) {
+		cfg, err := Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, SimilarConfig{GenresWeight: 3, DirectorWeight: 2, WritersWeight: 1.5, CastWeight: 2, DecadeWeight: 1, RatingWeight: 1}, cfg.Similar)
+		}
+
+		cfg, err = Load(append(noEnvFile, "--similar-cast-weight", "0.5"), envOf(map[string]string{"API_KEY": "secret", "SIMILAR_RATING_WEIGHT": "0"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, 0.5, cfg.Similar.CastWeight)
+			assert.Equal(t, 0.0, cfg.Similar.RatingWeight)
+		}
+
+		_, err = Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret", "SIMILAR_GENRES_WEIGHT": "-1"}), ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "similar.genres_weight can't be negative, got -1")
+		}
+	})
+
+	t.Run("[Load] compression", func(t *testing.T) {
+		cfg, err := Load(noEnvFile, envOf(map[string]string{"API_KEY": "secret"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Equal(t, []string{"zstd", "gzip"}, cfg.RESTEncodings())
+			assert.Equal(t, "none", cfg.REST.GatewayCompression)
+			assert.Equal(t, 1024, cfg.REST.CompressionMinSize)
+		}
+
+		env := envOf(map[string]string{"API_KEY": "secret", "REST_COMPRESSION": "none", "REST_GATEWAY_COMPRESSION": "zstd"})
+		cfg, err = Load(noEnvFile, env, ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.Empty(t, cfg.RESTEncodings())
+			assert.Equal(t, "zstd", cfg.REST.GatewayCompression)
+		}
+
+		env = envOf(map[string]string{"API_KEY": "secret", "REST_COMPRESSION": "br,none", "REST_GATEWAY_COMPRESSION": "lz4", "REST_COMPRESSION_MIN_SIZE": "-1"})
+		_, err = Load(noEnvFile, env, ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `rest.compression must list gzip and zstd or be none, got "br"`)
+			assert.Contains(t, err.Error(), `rest.compression must list gzip and zstd or be none, got "none"`)
+			assert.Contains(t, err.Error(), `rest.gateway_compression must be none, gzip or zstd, got "lz4"`)
+			assert.Contains(t, err.Error(), "rest.compression_min_size can't be negative")
+		}
+	})
+
+	t.Run("[Load] unsupported config file extension", func(t *testing.T) {
+		path := writeTempFile(t, "config.json", "{}")
+		_, err := Load(append(noEnvFile, "--config", path), envOf(nil), ioutil.Discard)
+		assert.Error(t, err)
+	})
+
+	t.Run("[Load] print-config flag", func(t *testing.T) {
+		cfg, err := Load(append(noEnvFile, "--print-config"), envOf(map[string]string{"API_KEY": "secret"}), ioutil.Discard)
+		if assert.Nil(t, err) {
+			assert.True(t, cfg.PrintConfig)
+		}
+	})
+}
+
+func TestPrint(t *testing.T) {
+	t.Run("[Print] secrets are masked and sources shown", func(t *testing.T) {
+		// a value no key contains, auth.jwt_secret has "secret" in its name
+		env := envOf(map[string]string{"API_KEY": "s3cr3t", "GRPC_PORT": "9000"})
+		cfg, err := Load([]string{"--env-file", os.DevNull, "--rest-port", "9001"}, env, ioutil.Discard)
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		out := &bytes.Buffer{}
+		assert.Nil(t, cfg.Print(out))
+		assert.NotContains(t, out.String(), "s3cr3t")
+		assert.Regexp(t, `omdb.api_key\s+\*+\s+\(env\)`, out.String())
+		assert.Regexp(t, `grpc.port\s+9000\s+\(env\)`, out.String())
+		assert.Regexp(t, `rest.port\s+9001\s+\(flag\)`, out.String())
+		assert.Regexp(t, `cache.ttl\s+1h0m0s\s+\(default\)`, out.String())
+	})
+}
@@ -0,0 +1,37 @@
+package docs
+
+import (
+	"bytes"
+	_ "embed"
+	"net/http"
+	"time"
+)
+
+// explorer lists the operations of the spec next to it and lets them be tried from the browser.
+// It is a single page without external scripts or styles so it works offline
+//
+//go:embed explorer.html
+var explorer []byte
+
+// SpecHandler serves the OpenAPI document
+func SpecHandler(spec []byte) http.Handler {
+	return contentHandler("openapi.json", spec)
+}
+
+// ExplorerHandler serves the API explorer, it loads the spec from openapi.json next to its own path
+func ExplorerHandler() http.Handler {
+	return contentHandler("docs.html", explorer)
+}
+
+// contentHandler serves content with the type guessed from name, handling HEAD and conditional requests
+func contentHandler(name string, content []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(content))
+	})
+}
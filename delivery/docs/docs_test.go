@@ -0,0 +1,274 @@
+package docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	server "github.com/zenkobert/sbtest-2/delivery/grpc"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type (
+	schema struct {
+		Ref                  string             `json:"$ref"`
+		Type                 string             `json:"type"`
+		Format               string             `json:"format"`
+		Items                *schema            `json:"items"`
+		Properties           map[string]*schema `json:"properties"`
+		AdditionalProperties *schema            `json:"additionalProperties"`
+	}
+
+	parameter struct {
+		Name string `json:"name"`
+		In   string `json:"in"`
+	}
+
+	operation struct {
+		OperationID string      `json:"operationId"`
+		Parameters  []parameter `json:"parameters"`
+		Responses   map[string]struct {
+			Schema schema `json:"schema"`
+		} `json:"responses"`
+	}
+
+	spec struct {
+		Paths       map[string]map[string]operation `json:"paths"`
+		Definitions map[string]*schema              `json:"definitions"`
+	}
+)
+
+func serve(t *testing.T, handler http.Handler, method string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, "/", nil))
+
+	return rec
+}
+
+func TestSpecHandler(t *testing.T) {
+	t.Run("[SpecHandler] serves the embedded spec as JSON", func(t *testing.T) {
+		rec := serve(t, SpecHandler(server.OpenAPISpec), http.MethodGet)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.True(t, json.Valid(rec.Body.Bytes()))
+		assert.Equal(t, server.OpenAPISpec, rec.Body.Bytes())
+	})
+
+	t.Run("[SpecHandler] HEAD has no body", func(t *testing.T) {
+		rec := serve(t, SpecHandler(server.OpenAPISpec), http.MethodHead)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Body.Bytes())
+	})
+
+	t.Run("[SpecHandler] other methods are not allowed", func(t *testing.T) {
+		rec := serve(t, SpecHandler(server.OpenAPISpec), http.MethodPost)
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+	})
+}
+
+func TestExplorerHandler(t *testing.T) {
+	t.Run("[ExplorerHandler] self-contained page loading the spec next to it", func(t *testing.T) {
+		rec := serve(t, ExplorerHandler(), http.MethodGet)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `fetch("openapi.json")`)
+		// offline: nothing is loaded from another origin
+		assert.NotRegexp(t, `(src|href)="(https?:)?//`, rec.Body.String())
+	})
+}
+
+// TestSpecMatchesProto fails when movie.proto changed without regenerating movie.swagger.json
+func TestSpecMatchesProto(t *testing.T) {
+	rec := serve(t, SpecHandler(server.OpenAPISpec), http.MethodGet)
+	var served spec
+	if err := json.Unmarshal(rec.Body.Bytes(), &served); err != nil {
+		t.Fatal(err)
+	}
+
+	file := server.File_delivery_grpc_movie_proto
+	prefix := string(file.Package())
+
+	t.Run("[Spec] every HTTP binding is documented with its parameters", func(t *testing.T) {
+		var paths []string
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				method := methods.Get(j)
+				rule, _ := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+				if rule == nil {
+					continue
+				}
+
+				verb, path := binding(rule)
+				fields := method.Input().Fields()
+				// path params are named after the JSON names of their fields
+				for k := 0; k < fields.Len(); k++ {
+					path = strings.Replace(path, "{"+string(fields.Get(k).Name())+"}", "{"+fields.Get(k).JSONName()+"}", 1)
+				}
+				paths = append(paths, verb+" "+path)
+				op, ok := served.Paths[path][verb]
+				if !assert.True(t, ok, "%s %s of %s is missing", verb, path, method.FullName()) {
+					continue
+				}
+
+				assert.Equal(t, string(services.Get(i).Name())+"_"+string(method.Name()), op.OperationID)
+				assert.Equal(t, "#/definitions/"+prefix+string(method.Output().Name()), op.Responses["200"].Schema.Ref)
+
+				var expected, actual []string
+				inBody := false
+				for k := 0; k < fields.Len(); k++ {
+					switch {
+					case strings.Contains(path, "{"+fields.Get(k).JSONName()+"}"):
+						expected = append(expected, "path:"+fields.Get(k).JSONName())
+					case rule.Body == "*":
+						// the other fields are the properties of one body param
+						inBody = true
+					default:
+						expected = append(expected, "query:"+fields.Get(k).JSONName())
+					}
+				}
+				if inBody {
+					expected = append(expected, "body:body")
+				}
+				for _, p := range op.Parameters {
+					actual = append(actual, p.In+":"+p.Name)
+				}
+				sort.Strings(expected)
+				sort.Strings(actual)
+				assert.Equal(t, expected, actual, "parameters of %s", method.FullName())
+			}
+		}
+
+		var documented []string
+		for path, operations := range served.Paths {
+			for verb := range operations {
+				documented = append(documented, verb+" "+path)
+			}
+		}
+		sort.Strings(paths)
+		sort.Strings(documented)
+		assert.Equal(t, paths, documented)
+	})
+
+	t.Run("[Spec] every response message is defined with its fields", func(t *testing.T) {
+		// error details such as SearchSuggestions are in no request or response, nor in the spec
+		used := map[protoreflect.FullName]bool{}
+		var use func(message protoreflect.MessageDescriptor)
+		use = func(message protoreflect.MessageDescriptor) {
+			if used[message.FullName()] || message.ParentFile() != file {
+				return
+			}
+			used[message.FullName()] = true
+			fields := message.Fields()
+			for j := 0; j < fields.Len(); j++ {
+				if fieldMessage := fields.Get(j).Message(); fieldMessage != nil {
+					use(fieldMessage)
+				}
+			}
+		}
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				use(methods.Get(j).Input())
+				use(methods.Get(j).Output())
+			}
+		}
+
+		messages := file.Messages()
+		for i := 0; i < messages.Len(); i++ {
+			message := messages.Get(i)
+			if strings.HasSuffix(string(message.Name()), "Request") || !used[message.FullName()] {
+				// requests are documented as parameters
+				continue
+			}
+
+			definition, ok := served.Definitions[prefix+string(message.Name())]
+			if !assert.True(t, ok, "definition of %s is missing", message.FullName()) {
+				continue
+			}
+
+			fields := message.Fields()
+			assert.Len(t, definition.Properties, fields.Len(), "fields of %s", message.FullName())
+			for j := 0; j < fields.Len(); j++ {
+				field := fields.Get(j)
+				property, ok := definition.Properties[field.JSONName()]
+				if assert.True(t, ok, "%s is missing", field.FullName()) {
+					assert.Equal(t, schemaOf(prefix, field), typeOf(property), "type of %s", field.FullName())
+				}
+			}
+		}
+	})
+}
+
+func binding(rule *annotations.HttpRule) (verb, path string) {
+	switch pattern := rule.Pattern.(type) {
+	case *annotations.HttpRule_Get:
+		return "get", pattern.Get
+	case *annotations.HttpRule_Post:
+		return "post", pattern.Post
+	case *annotations.HttpRule_Put:
+		return "put", pattern.Put
+	case *annotations.HttpRule_Patch:
+		return "patch", pattern.Patch
+	case *annotations.HttpRule_Delete:
+		return "delete", pattern.Delete
+	}
+
+	return "", ""
+}
+
+// schemaOf describes the OpenAPI type protoc-gen-openapiv2 generates for field
+func schemaOf(prefix string, field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		return "map[" + schemaOf(prefix, field.MapKey()) + "]" + schemaOf(prefix, field.MapValue())
+	}
+
+	var kind string
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		kind = "string"
+	case protoreflect.Int32Kind:
+		kind = "integer/int32"
+	case protoreflect.Int64Kind:
+		kind = "string/int64"
+	case protoreflect.DoubleKind:
+		kind = "number/double"
+	case protoreflect.BoolKind:
+		kind = "boolean"
+	case protoreflect.MessageKind:
+		kind = "#/definitions/" + prefix + string(field.Message().Name())
+	default:
+		kind = field.Kind().String()
+	}
+
+	if field.IsList() {
+		return "[]" + kind
+	}
+
+	return kind
+}
+
+func typeOf(s *schema) string {
+	switch {
+	case s.Ref != "":
+		return s.Ref
+	case s.Type == "array" && s.Items != nil:
+		return "[]" + typeOf(s.Items)
+	case s.Type == "object" && s.AdditionalProperties != nil:
+		// JSON object keys are strings
+		return "map[string]" + typeOf(s.AdditionalProperties)
+	case s.Format != "":
+		return s.Type + "/" + s.Format
+	}
+
+	return s.Type
+}
@@ -0,0 +1,62 @@
+package graphql
+
+import (
+	"errors"
+	"log"
+
+	"github.com/zenkobert/sbtest-2/common/redact"
+	server "github.com/zenkobert/sbtest-2/delivery/grpc"
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// codes of the errors that are not reasons of the GRPC service
+const (
+	CodeInvalidPage     = "INVALID_PAGE"
+	CodeInvalidRequest  = "INVALID_REQUEST"
+	CodeQueryTooDeep    = "QUERY_TOO_DEEP"
+	CodeQueryTooComplex = "QUERY_TOO_COMPLEX"
+	CodeInternal        = "INTERNAL"
+)
+
+// queryError is reported in the errors of the response with its code as the code extension,
+// the codes are the reasons of the GRPC service errors when there is one
+type queryError struct {
+	code    string
+	message string
+}
+
+func (err *queryError) Error() string {
+	return err.message
+}
+
+func (err *queryError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": err.code}
+}
+
+var (
+	errMethodNotAllowed = errors.New("GraphQL queries are sent with GET or POST")
+	errEmptyBody        = errors.New("the body must be a JSON object with the query")
+	errMissingQuery     = errors.New("query is missing")
+
+	missingQueryError  = &queryError{server.ReasonMissingSearchword, "query can't be empty"}
+	invalidImdbIDError = &queryError{server.ReasonInvalidImdbID, "id must be an IMDb ID such as tt0371746"}
+	invalidPageError   = &queryError{CodeInvalidPage, "page starts at 1"}
+)
+
+// usecaseError maps the errors of the usecase to query errors, errors the service doesn't know are logged
+// and reported without details
+func usecaseError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrQuotaExceeded):
+		return &queryError{server.ReasonQuotaExceeded, "OMDb request limit reached"}
+	case errors.Is(err, model.ErrUpstream):
+		return &queryError{server.ReasonUpstreamError, "OMDb answered with an error"}
+	case model.IsTimeout(err):
+		return &queryError{server.ReasonUpstreamTimeout, "OMDb did not answer in time"}
+	case errors.Is(err, model.ErrInvalidSearchword):
+		return &queryError{server.ReasonInvalidSearchword, err.Error()}
+	}
+
+	log.Println(redact.String(err.Error()))
+	return &queryError{CodeInternal, "unexpected error"}
+}
@@ -0,0 +1,118 @@
+// Package graphql serves the movie usecase as GraphQL, so a client can get search results
+// and the details of the movies it needs in one round trip. The schema is schema.graphql
+package graphql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// maxBodySize bounds the size of a POSTed request, queries are much shorter
+const maxBodySize = 1 << 20
+
+//go:embed schema.graphql
+var schema string
+
+type (
+	Options struct {
+		// MaxDepth is how deep fields may be nested in a query, introspection included
+		MaxDepth int
+		// MaxComplexity is the most a query may cost, a field costs 1 and one calling OMDb 10,
+		// fields in lists cost as many times as the list may have elements
+		MaxComplexity int
+		// Record logs the searches and lookups to the search log like the GRPC interceptor, under the name of
+		// the same GRPC method. They are not logged when it is nil
+		Record func(record string)
+	}
+
+	request struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+
+	handler struct {
+		MovieUsecase model.MovieUsecase
+		record       func(record string)
+		schema       *graphql.Schema
+		limits       *limits
+	}
+)
+
+// NewHandler serves GraphQL queries POSTed as JSON, or sent as GET query params
+func NewHandler(usecase model.MovieUsecase, options Options) (http.Handler, error) {
+	parsed, err := graphql.ParseSchema(schema, &resolver{MovieUsecase: usecase, record: options.Record},
+		graphql.UseFieldResolvers(), graphql.UseStringDescriptions(), graphql.MaxDepth(options.MaxDepth))
+	if err != nil {
+		return nil, err
+	}
+
+	return &handler{MovieUsecase: usecase, record: options.Record, schema: parsed, limits: newLimits(parsed.ASTSchema(), options.MaxComplexity)}, nil
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeRequest(w, r)
+	if err != nil {
+		status := http.StatusBadRequest
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			w.Header().Set("Allow", "GET, POST")
+			status = http.StatusMethodNotAllowed
+		}
+		writeResponse(w, status, &graphql.Response{Errors: []*gqlerrors.QueryError{queryErrorOf(&queryError{CodeInvalidRequest, err.Error()})}})
+		return
+	}
+
+	if err := h.limits.check(req.Query); err != nil {
+		writeResponse(w, http.StatusOK, &graphql.Response{Errors: []*gqlerrors.QueryError{queryErrorOf(err)}})
+		return
+	}
+
+	ctx := withLoader(r.Context(), newDetailLoader(r.Context(), h.MovieUsecase, h.record))
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	for _, err := range resp.Errors {
+		if err.Rule == maxDepthRule {
+			err.Extensions = map[string]interface{}{"code": CodeQueryTooDeep}
+		}
+	}
+	writeResponse(w, http.StatusOK, resp)
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request) (req request, err error) {
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			err = json.Unmarshal([]byte(variables), &req.Variables)
+		}
+	case http.MethodPost:
+		err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&req)
+		if err == io.EOF {
+			err = errEmptyBody
+		}
+	default:
+		err = errMethodNotAllowed
+	}
+	if err == nil && req.Query == "" {
+		err = errMissingQuery
+	}
+
+	return req, err
+}
+
+func queryErrorOf(err *queryError) *gqlerrors.QueryError {
+	return &gqlerrors.QueryError{Message: err.message, Extensions: err.Extensions()}
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp *graphql.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
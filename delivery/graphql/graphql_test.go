@@ -0,0 +1,423 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	mock "github.com/zenkobert/sbtest-2/domain/mocks"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var defaultOptions = Options{MaxDepth: 10, MaxComplexity: 500}
+
+type response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string            `json:"message"`
+		Extensions map[string]string `json:"extensions"`
+	} `json:"errors"`
+}
+
+func found(ids ...string) *model.MovieSearch {
+	movieSearch := &model.MovieSearch{TotalResults: fmt.Sprint(len(ids))}
+	for _, id := range ids {
+		movieSearch.Search = append(movieSearch.Search, model.SearchDetail{Title: "Title of " + id, ImdbID: id, Type: "movie"})
+	}
+
+	return movieSearch
+}
+
+// withDetails makes movieUsecaseMock know every movie but tt0000404
+func withDetails(movieUsecaseMock *mock.MovieUsecase) *mock.MovieUsecase {
+	movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, "tt0000404").Return(&model.MovieDetail{Error: "Incorrect IMDb ID."}, nil)
+	movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(func(_ context.Context, id string) *model.MovieDetail {
+		return &model.MovieDetail{
+			Title:   "Detail of " + id,
+			ImdbID:  id,
+			Ratings: []model.MovieRating{{Source: "Internet Movie Database", Value: "7.9/10"}},
+		}
+	}, nil)
+
+	return movieUsecaseMock
+}
+
+func serve(t *testing.T, usecase model.MovieUsecase, options Options, req *http.Request) (*httptest.ResponseRecorder, response) {
+	handler, err := NewHandler(usecase, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var resp response
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec, resp
+}
+
+func post(t *testing.T, usecase model.MovieUsecase, query string, variables map[string]interface{}) response {
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	rec, resp := serve(t, usecase, defaultOptions, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	return resp
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("[Search] results with the details in one request", func(t *testing.T) {
+		movieUsecaseMock := withDetails(&mock.MovieUsecase{})
+		movieUsecaseMock.On("SearchMovies", testify.Anything, "iron man", uint32(1), model.SearchFilter{}).Return(found("tt0000001", "tt0000002"), nil)
+
+		resp := post(t, movieUsecaseMock, `{ search(query: "iron man") { total results { imdbId title movie { title ratings { source value } } } } }`, nil)
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{
+			"total": float64(2),
+			"results": []interface{}{
+				map[string]interface{}{"imdbId": "tt0000001", "title": "Title of tt0000001", "movie": map[string]interface{}{
+					"title":   "Detail of tt0000001",
+					"ratings": []interface{}{map[string]interface{}{"source": "Internet Movie Database", "value": "7.9/10"}},
+				}},
+				map[string]interface{}{"imdbId": "tt0000002", "title": "Title of tt0000002", "movie": map[string]interface{}{
+					"title":   "Detail of tt0000002",
+					"ratings": []interface{}{map[string]interface{}{"source": "Internet Movie Database", "value": "7.9/10"}},
+				}},
+			},
+		}, resp.Data["search"])
+	})
+
+	t.Run("[Search] type, year and page", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, "alien", uint32(2), model.SearchFilter{Type: "series", Year: 1979}).Return(found("tt0000001"), nil)
+
+		resp := post(t, movieUsecaseMock, `query($year: Int) { search(query: " alien ", type: SERIES, year: $year, page: 2) { total } }`, map[string]interface{}{"year": 1979})
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"total": float64(1)}, resp.Data["search"])
+	})
+
+	t.Run("[Search] nothing found is an empty result", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{Error: "Movie not found!"}, nil)
+
+		resp := post(t, movieUsecaseMock, `{ search(query: "qwertyuiop") { total results { title } } }`, nil)
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"total": float64(0), "results": []interface{}{}}, resp.Data["search"])
+	})
+
+	t.Run("[Search] suggestions and the query searched instead", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, "iron mann", testify.Anything, testify.Anything).Return(&model.MovieSearch{Error: "Movie not found!", Suggestions: []string{"iron man"}}, nil)
+		movieUsecaseMock.On("SearchMovies", testify.Anything, "ironman", testify.Anything, testify.Anything).Return(&model.MovieSearch{
+			Search:       []model.SearchDetail{{Title: "Iron Man"}},
+			TotalResults: "1",
+			Suggestions:  []string{"iron man"},
+			Corrected:    "iron man",
+		}, nil)
+
+		resp := post(t, movieUsecaseMock, `{ search(query: "iron mann") { total suggestions correctedQuery } }`, nil)
+		assert.Equal(t, map[string]interface{}{"total": float64(0), "suggestions": []interface{}{"iron man"}, "correctedQuery": nil}, resp.Data["search"])
+
+		resp = post(t, movieUsecaseMock, `{ search(query: "ironman") { total suggestions correctedQuery } }`, nil)
+		assert.Equal(t, map[string]interface{}{"total": float64(1), "suggestions": []interface{}{"iron man"}, "correctedQuery": "iron man"}, resp.Data["search"])
+	})
+
+	testCases := []struct {
+		name  string
+		query string
+		err   error
+		code  string
+	}{
+		{"empty query", `{ search(query: "  ") { total } }`, nil, "MISSING_SEARCHWORD"},
+		{"page before the first", `{ search(query: "alien", page: 0) { total } }`, nil, CodeInvalidPage},
+		{"quota exceeded", `{ search(query: "alien") { total } }`, model.ErrQuotaExceeded, "QUOTA_EXCEEDED"},
+		{"upstream error", `{ search(query: "alien") { total } }`, model.ErrUpstream, "UPSTREAM_ERROR"},
+		{"timeout", `{ search(query: "alien") { total } }`, context.DeadlineExceeded, "UPSTREAM_TIMEOUT"},
+		{"unexpected error", `{ search(query: "alien") { total } }`, fmt.Errorf("boom"), CodeInternal},
+	}
+	for _, testCase := range testCases {
+		t.Run("[Search] "+testCase.name, func(t *testing.T) {
+			movieUsecaseMock := &mock.MovieUsecase{}
+			movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(nil, testCase.err)
+
+			resp := post(t, movieUsecaseMock, testCase.query, nil)
+			assert.Nil(t, resp.Data)
+			if assert.Len(t, resp.Errors, 1) {
+				assert.Equal(t, testCase.code, resp.Errors[0].Extensions["code"])
+				assert.NotContains(t, resp.Errors[0].Message, "boom")
+			}
+		})
+	}
+}
+
+func TestMovie(t *testing.T) {
+	t.Run("[Movie] detail by IMDb ID", func(t *testing.T) {
+		resp := post(t, withDetails(&mock.MovieUsecase{}), `{ movie(id: "tt0371746") { imdbId title boxOffice } }`, nil)
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"imdbId": "tt0371746", "title": "Detail of tt0371746", "boxOffice": ""}, resp.Data["movie"])
+	})
+
+	t.Run("[Movie] unknown movie is null", func(t *testing.T) {
+		resp := post(t, withDetails(&mock.MovieUsecase{}), `{ movie(id: "tt0000404") { title } }`, nil)
+		assert.Empty(t, resp.Errors)
+		assert.Contains(t, resp.Data, "movie")
+		assert.Nil(t, resp.Data["movie"])
+	})
+
+	t.Run("[Movie] malformed IMDb ID", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+
+		resp := post(t, movieUsecaseMock, `{ movie(id: "0371746") { title } }`, nil)
+		if assert.Len(t, resp.Errors, 1) {
+			assert.Equal(t, "INVALID_IMDB_ID", resp.Errors[0].Extensions["code"])
+		}
+		movieUsecaseMock.AssertNotCalled(t, "GetMovieDetailByID", testify.Anything, testify.Anything)
+	})
+}
+
+func TestDetailLoader(t *testing.T) {
+	t.Run("[detailLoader] each movie is fetched once per request", func(t *testing.T) {
+		movieUsecaseMock := withDetails(&mock.MovieUsecase{})
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(found("tt0000001", "tt0000002", "tt0000001"), nil)
+
+		resp := post(t, movieUsecaseMock, `{
+			search(query: "iron man") { results { movie { title } } }
+			movie(id: "tt0000002") { title }
+			again: movie(id: "tt0000002") { plot }
+		}`, nil)
+		assert.Empty(t, resp.Errors)
+		movieUsecaseMock.AssertNumberOfCalls(t, "GetMovieDetailByID", 2)
+	})
+
+	t.Run("[detailLoader] the details of a search are fetched concurrently", func(t *testing.T) {
+		var mutex sync.Mutex
+		inFlight, mostInFlight := 0, 0
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(
+			found("tt0000001", "tt0000002", "tt0000003", "tt0000004", "tt0000005", "tt0000006", "tt0000007", "tt0000008"), nil)
+		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Run(func(testify.Arguments) {
+			mutex.Lock()
+			inFlight++
+			if inFlight > mostInFlight {
+				mostInFlight = inFlight
+			}
+			mutex.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mutex.Lock()
+			inFlight--
+			mutex.Unlock()
+		}).Return(&model.MovieDetail{Title: "Iron Man"}, nil)
+
+		resp := post(t, movieUsecaseMock, `{ search(query: "iron man") { results { movie { title } } } }`, nil)
+		assert.Empty(t, resp.Errors)
+		movieUsecaseMock.AssertNumberOfCalls(t, "GetMovieDetailByID", 8)
+		assert.Equal(t, maxConcurrentLookups, mostInFlight)
+	})
+
+	t.Run("[detailLoader] a failed lookup only fails its field", func(t *testing.T) {
+		// the first matching expectation wins
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, "tt0000002").Return(nil, model.ErrUpstream)
+		withDetails(movieUsecaseMock)
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(found("tt0000001", "tt0000002"), nil)
+
+		resp := post(t, movieUsecaseMock, `{ search(query: "iron man") { results { imdbId movie { title } } } }`, nil)
+		if assert.Len(t, resp.Errors, 1) {
+			assert.Equal(t, "UPSTREAM_ERROR", resp.Errors[0].Extensions["code"])
+		}
+		results := resp.Data["search"].(map[string]interface{})["results"].([]interface{})
+		assert.Equal(t, map[string]interface{}{"title": "Detail of tt0000001"}, results[0].(map[string]interface{})["movie"])
+		assert.Nil(t, results[1].(map[string]interface{})["movie"])
+	})
+}
+
+func TestLimits(t *testing.T) {
+	nested := `{ search(query: "iron man") { results { movie { ratings { source } } } } }`
+
+	testCases := []struct {
+		name    string
+		options Options
+		query   string
+		code    string
+	}{
+		{"deep enough", Options{MaxDepth: 5, MaxComplexity: 500}, nested, ""},
+		{"too deep", Options{MaxDepth: 4, MaxComplexity: 500}, nested, CodeQueryTooDeep},
+		// search 10 + results 1 + 10 * (movie 10 + ratings 1 + 3 * source 1)
+		{"cheap enough", Options{MaxDepth: 10, MaxComplexity: 151}, nested, ""},
+		{"too complex", Options{MaxDepth: 10, MaxComplexity: 150}, nested, CodeQueryTooComplex},
+		{"fragments count", Options{MaxDepth: 10, MaxComplexity: 20}, `
+			{ a: movie(id: "tt1") { ...details } b: movie(id: "tt2") { ...details } }
+			fragment details on Movie { title ... on Movie { plot } }`, CodeQueryTooComplex},
+		{"introspection is free", Options{MaxDepth: 10, MaxComplexity: 1},
+			`{ __schema { types { name fields { type { ofType { ofType { name } } } } } } }`, ""},
+		{"introspection is as deep as its fields", Options{MaxDepth: 6, MaxComplexity: 1},
+			`{ __schema { types { name fields { type { ofType { ofType { name } } } } } } }`, CodeQueryTooDeep},
+		// search 10 + total 1, what's in the strings, the comments and the arguments isn't selected
+		{"variables, arguments and comments skipped", Options{MaxDepth: 10, MaxComplexity: 11}, `
+			query Search($query: String = "{ movie(id: \"tt1\") { plot } }") {
+				# { movie(id: "tt2") { title } }
+				search(query: $query, page: 1) @include(if: true) { total, __typename }
+			}`, ""},
+		{"aliases count by their field", Options{MaxDepth: 10, MaxComplexity: 21}, `{
+			first: search(query: """a "quoted" {block}""") { total }
+			second: search(query: "b") { total }
+		}`, CodeQueryTooComplex},
+	}
+	for _, testCase := range testCases {
+		t.Run("[limits] "+testCase.name, func(t *testing.T) {
+			movieUsecaseMock := withDetails(&mock.MovieUsecase{})
+			movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(found("tt0000001"), nil)
+
+			body, _ := json.Marshal(map[string]string{"query": testCase.query})
+			rec, resp := serve(t, movieUsecaseMock, testCase.options, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			if testCase.code == "" {
+				assert.Empty(t, resp.Errors)
+				return
+			}
+
+			if assert.Len(t, resp.Errors, 1) {
+				assert.Equal(t, testCase.code, resp.Errors[0].Extensions["code"])
+			}
+			movieUsecaseMock.AssertNotCalled(t, "SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+			movieUsecaseMock.AssertNotCalled(t, "GetMovieDetailByID", testify.Anything, testify.Anything)
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("[Handler] GET with query params", func(t *testing.T) {
+		params := url.Values{
+			"query":     {`query Detail($id: ID!) { movie(id: $id) { title } }`},
+			"variables": {`{"id": "tt0371746"}`},
+		}
+		rec, resp := serve(t, withDetails(&mock.MovieUsecase{}), defaultOptions, httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"title": "Detail of tt0371746"}, resp.Data["movie"])
+	})
+
+	t.Run("[Handler] syntax errors are reported by the executor", func(t *testing.T) {
+		rec, resp := serve(t, &mock.MovieUsecase{}, defaultOptions, httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape("{ movie("), nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Len(t, resp.Errors, 1)
+	})
+
+	testCases := []struct {
+		name   string
+		req    *http.Request
+		status int
+	}{
+		{"malformed body", httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":`)), http.StatusBadRequest},
+		{"empty body", httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(``)), http.StatusBadRequest},
+		{"no query", httptest.NewRequest(http.MethodGet, "/graphql", nil), http.StatusBadRequest},
+		{"malformed variables", httptest.NewRequest(http.MethodGet, "/graphql?query=%7B__typename%7D&variables=%7B", nil), http.StatusBadRequest},
+		{"other methods", httptest.NewRequest(http.MethodPut, "/graphql", nil), http.StatusMethodNotAllowed},
+	}
+	for _, testCase := range testCases {
+		t.Run("[Handler] "+testCase.name, func(t *testing.T) {
+			rec, resp := serve(t, &mock.MovieUsecase{}, defaultOptions, testCase.req)
+			assert.Equal(t, testCase.status, rec.Code)
+			if assert.Len(t, resp.Errors, 1) {
+				assert.Equal(t, CodeInvalidRequest, resp.Errors[0].Extensions["code"])
+			}
+		})
+	}
+}
+
+func TestRecord(t *testing.T) {
+	t.Run("[Record] searches and lookups logged and traced as their GRPC method", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		mutex := &sync.Mutex{}
+		records := []string{}
+		options := defaultOptions
+		options.Record = func(record string) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			records = append(records, record)
+		}
+		// the lookups get the context of their span, the calls they make are part of it
+		lookups := map[string]trace.SpanID{}
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Run(func(args testify.Arguments) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			lookups[args.String(1)] = trace.SpanContextFromContext(args.Get(0).(context.Context)).SpanID()
+		}).Return(&model.MovieDetail{Title: "Iron Man"}, nil)
+		movieUsecaseMock.On("SearchMovies", testify.Anything, "iron man", uint32(2), model.SearchFilter{}).Return(found("tt0000001"), nil)
+
+		body := `{"query": "{ search(query: \"iron man\", page: 2) { total results { movie { title } } } movie(id: \"tt0371746\") { title } }"}`
+		_, resp := serve(t, movieUsecaseMock, options, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+		assert.Empty(t, resp.Errors)
+		assert.ElementsMatch(t, []string{
+			`/movie.SearchMovie/SearchMovie/ searchword:"iron man" pagination:2`,
+			`/movie.SearchMovie/GetMovieDetail/ id:"tt0000001"`,
+			`/movie.SearchMovie/GetMovieDetail/ id:"tt0371746"`,
+		}, records)
+
+		names := []string{}
+		spans := map[trace.SpanID]bool{}
+		for _, span := range recorder.Ended() {
+			names = append(names, span.Name())
+			spans[span.SpanContext().SpanID()] = span.Name() == "/movie.SearchMovie/GetMovieDetail"
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Equal(t, codes.Unset, span.Status().Code)
+		}
+		assert.ElementsMatch(t, []string{"/movie.SearchMovie/SearchMovie", "/movie.SearchMovie/GetMovieDetail", "/movie.SearchMovie/GetMovieDetail"}, names)
+		if assert.Len(t, lookups, 2) {
+			assert.True(t, spans[lookups["tt0000001"]])
+			assert.True(t, spans[lookups["tt0371746"]])
+		}
+	})
+
+	t.Run("[Record] malformed IMDb IDs are logged, their span failed", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		records := []string{}
+		options := defaultOptions
+		options.Record = func(record string) {
+			records = append(records, record)
+		}
+
+		body := `{"query": "{ movie(id: \"0371746\") { title } }"}`
+		serve(t, &mock.MovieUsecase{}, options, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+		assert.Equal(t, []string{`/movie.SearchMovie/GetMovieDetail/ id:"0371746"`}, records)
+		if spans := recorder.Ended(); assert.Len(t, spans, 1) {
+			assert.Equal(t, codes.Error, spans[0].Status().Code)
+		}
+	})
+
+	t.Run("[Record] rejected searches are logged too, their span failed", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		records := []string{}
+		options := defaultOptions
+		options.Record = func(record string) {
+			records = append(records, record)
+		}
+
+		body := `{"query": "{ search(query: \"alien\", page: 0) { total } }"}`
+		serve(t, &mock.MovieUsecase{}, options, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+		assert.Equal(t, []string{`/movie.SearchMovie/SearchMovie/ searchword:"alien" pagination:0`}, records)
+		if spans := recorder.Ended(); assert.Len(t, spans, 1) {
+			assert.Equal(t, codes.Error, spans[0].Status().Code)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/types"
+)
+
+// fieldCosts are the costs of the fields calling OMDb, the other fields cost 1
+var fieldCosts = map[string]int{
+	"Query.search":     10,
+	"Query.movie":      10,
+	"SearchItem.movie": 10,
+}
+
+// listSizes are the most elements of the list fields, what is selected in them costs that many times
+var listSizes = map[string]int{
+	"SearchResult.results": 10,
+	"Movie.ratings":        3,
+}
+
+// maxDepthRule is the rule of the executor rejecting queries nested deeper than graphql.MaxDepth
+const maxDepthRule = "MaxDepthExceeded"
+
+// limits rejects queries costing more than maxComplexity before they run, so one request can't use up
+// the OMDb quota. How deep they nest fields is checked by the executor, see graphql.MaxDepth
+type limits struct {
+	schema        *types.Schema
+	maxComplexity int
+}
+
+func newLimits(schema *types.Schema, maxComplexity int) *limits {
+	return &limits{schema: schema, maxComplexity: maxComplexity}
+}
+
+// check returns why query can't run, nil when it can. Invalid queries are left to the executor to report
+func (l *limits) check(query string) *queryError {
+	document, ok := parseQuery(query)
+	if !ok {
+		return nil
+	}
+
+	for _, operation := range document.operations {
+		root := l.schema.EntryPoints[operation.kind]
+		if root == nil {
+			return nil
+		}
+		complexity, ok := l.complexityOf(document, operation.selections, root, map[string]bool{})
+		if !ok {
+			return nil
+		}
+		if complexity > l.maxComplexity {
+			return &queryError{CodeQueryTooComplex, fmt.Sprintf("query complexity is %d, the limit is %d", complexity, l.maxComplexity)}
+		}
+	}
+
+	return nil
+}
+
+// complexityOf adds up the costs of the fields of selections on parent, the fields of fragments included.
+// Introspection fields are left out, they only read the schema however deep they go. It isn't ok when the
+// query doesn't match the schema
+func (l *limits) complexityOf(document *queryDocument, selections []*querySelection, parent types.NamedType, spread map[string]bool) (complexity int, ok bool) {
+	for _, selection := range selections {
+		var cost int
+		switch {
+		case selection.spread != "":
+			fragment, found := document.fragments[selection.spread]
+			if !found || spread[selection.spread] {
+				return 0, false
+			}
+			spread[selection.spread] = true
+			cost, ok = l.complexityOf(document, fragment.selections, l.schema.Types[fragment.on], spread)
+			delete(spread, selection.spread)
+		case selection.field == "":
+			on := parent
+			if selection.on != "" {
+				on = l.schema.Types[selection.on]
+			}
+			cost, ok = l.complexityOf(document, selection.selections, on, spread)
+		case strings.HasPrefix(selection.field, "__"):
+			continue
+		default:
+			cost, ok = l.fieldComplexity(document, selection, parent, spread)
+		}
+		if !ok {
+			return 0, false
+		}
+		complexity += cost
+	}
+
+	return complexity, true
+}
+
+func (l *limits) fieldComplexity(document *queryDocument, selection *querySelection, parent types.NamedType, spread map[string]bool) (int, bool) {
+	var fields types.FieldsDefinition
+	switch parent := parent.(type) {
+	case *types.ObjectTypeDefinition:
+		fields = parent.Fields
+	case *types.InterfaceTypeDefinition:
+		fields = parent.Fields
+	}
+	definition := fields.Get(selection.field)
+	if definition == nil {
+		return 0, false
+	}
+
+	name := parent.TypeName() + "." + selection.field
+	cost, ok := fieldCosts[name]
+	if !ok {
+		cost = 1
+	}
+	size, ok := listSizes[name]
+	if !ok {
+		size = 1
+	}
+	selected, ok := l.complexityOf(document, selection.selections, namedType(definition.Type), spread)
+
+	return cost + size*selected, ok
+}
+
+// namedType is the type of the elements of the lists t may be, non null or not
+func namedType(t types.Type) types.NamedType {
+	for {
+		switch wrapped := t.(type) {
+		case *types.List:
+			t = wrapped.OfType
+		case *types.NonNull:
+			t = wrapped.OfType
+		case types.NamedType:
+			return wrapped
+		default:
+			return nil
+		}
+	}
+}
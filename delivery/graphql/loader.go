@@ -0,0 +1,120 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zenkobert/sbtest-2/common/tracing"
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+const (
+	// batchWait is how long a batch collects lookups before they are fetched, the resolvers of
+	// the items of a list run concurrently so their lookups land in the same batch
+	batchWait = 2 * time.Millisecond
+	// maxConcurrentLookups bounds the OMDb requests of one batch in flight at once
+	maxConcurrentLookups = 5
+)
+
+type loaderKey struct{}
+
+// detail is the outcome of one lookup, done is closed once it is known
+type detail struct {
+	done  chan struct{}
+	movie *movie
+	err   error
+}
+
+// detailLoader batches the movie detail lookups of one GraphQL request. Lookups are collected
+// for batchWait then fetched concurrently, and each IMDb ID is fetched once per request
+// however many times the query asks for it
+type detailLoader struct {
+	ctx          context.Context
+	MovieUsecase model.MovieUsecase
+	record       func(record string)
+
+	mutex   *sync.Mutex
+	details map[string]*detail
+	batch   []string
+}
+
+func newDetailLoader(ctx context.Context, usecase model.MovieUsecase, record func(record string)) *detailLoader {
+	return &detailLoader{
+		ctx:          ctx,
+		MovieUsecase: usecase,
+		record:       record,
+		mutex:        &sync.Mutex{},
+		details:      map[string]*detail{},
+	}
+}
+
+func withLoader(ctx context.Context, loader *detailLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, loader)
+}
+
+func loaderFrom(ctx context.Context) *detailLoader {
+	return ctx.Value(loaderKey{}).(*detailLoader)
+}
+
+// Load returns the movie with IMDb ID id, nil when OMDb doesn't know it
+func (l *detailLoader) Load(id string) (*movie, error) {
+	l.mutex.Lock()
+	d, ok := l.details[id]
+	if !ok {
+		d = &detail{done: make(chan struct{})}
+		l.details[id] = d
+		if len(l.batch) == 0 {
+			time.AfterFunc(batchWait, l.dispatch)
+		}
+		l.batch = append(l.batch, id)
+	}
+	l.mutex.Unlock()
+
+	<-d.done
+	return d.movie, d.err
+}
+
+// dispatch fetches the lookups collected so far
+func (l *detailLoader) dispatch() {
+	l.mutex.Lock()
+	batch := l.batch
+	l.batch = nil
+	details := make([]*detail, len(batch))
+	for i, id := range batch {
+		details[i] = l.details[id]
+	}
+	l.mutex.Unlock()
+
+	slots := make(chan struct{}, maxConcurrentLookups)
+	for i, id := range batch {
+		slots <- struct{}{}
+		go func(id string, d *detail) {
+			defer func() { <-slots }()
+			defer close(d.done)
+
+			d.movie, d.err = l.fetch(id)
+		}(id, details[i])
+	}
+}
+
+// fetch looks id up, logged and traced like a GetMovieDetail call whether the query asked for the movie or
+// for the movie of a search result
+func (l *detailLoader) fetch(id string) (result *movie, err error) {
+	ctx, span := call(l.ctx, l.record, detailMethod, detailRequest(id))
+	defer func() {
+		tracing.RecordStatus(span, err)
+		span.End()
+	}()
+
+	movieDetail, err := l.MovieUsecase.GetMovieDetailByID(ctx, id)
+	if err != nil {
+		return nil, usecaseError(err)
+	}
+
+	if movieDetail.Error != "" {
+		return nil, nil
+	}
+
+	return &movie{*movieDetail}, nil
+}
@@ -0,0 +1,235 @@
+package graphql
+
+import "strings"
+
+type (
+	// queryDocument is what limits needs of a query: the fields its operations and fragments select.
+	// Arguments, variables and directives are skipped, the executor validates them
+	queryDocument struct {
+		operations []*queryOperation
+		fragments  map[string]*queryFragment
+	}
+
+	queryOperation struct {
+		// kind is query, mutation or subscription
+		kind       string
+		selections []*querySelection
+	}
+
+	queryFragment struct {
+		on         string
+		selections []*querySelection
+	}
+
+	// querySelection is a field when field is set, a fragment spread when spread is set, an inline
+	// fragment on the type on, the type of the enclosing selection when empty, otherwise
+	querySelection struct {
+		field      string
+		spread     string
+		on         string
+		selections []*querySelection
+	}
+
+	// queryParser reads the tokens of a query one at a time, ok is unset once it isn't a query
+	queryParser struct {
+		query string
+		token string
+		ok    bool
+	}
+)
+
+// parseQuery reads the operations and fragments of query, it isn't ok when query is no GraphQL document
+func parseQuery(query string) (*queryDocument, bool) {
+	parser := &queryParser{query: query, ok: true}
+	parser.next()
+
+	document := &queryDocument{fragments: map[string]*queryFragment{}}
+	for parser.ok && parser.token != "" {
+		switch parser.token {
+		case "{":
+			document.operations = append(document.operations, &queryOperation{kind: "query", selections: parser.selectionSet()})
+		case "query", "mutation", "subscription":
+			operation := &queryOperation{kind: parser.token}
+			parser.next()
+			if isName(parser.token) {
+				parser.next()
+			}
+			parser.skipArguments()
+			parser.skipDirectives()
+			operation.selections = parser.selectionSet()
+			document.operations = append(document.operations, operation)
+		case "fragment":
+			parser.next()
+			name := parser.name()
+			parser.expect("on")
+			fragment := &queryFragment{on: parser.name()}
+			parser.skipDirectives()
+			fragment.selections = parser.selectionSet()
+			document.fragments[name] = fragment
+		default:
+			parser.ok = false
+		}
+	}
+
+	return document, parser.ok
+}
+
+func (parser *queryParser) selectionSet() []*querySelection {
+	parser.expect("{")
+	selections := []*querySelection{}
+	for parser.ok && parser.token != "}" {
+		selection := &querySelection{}
+		if parser.token == "..." {
+			parser.next()
+			switch {
+			case parser.token == "on":
+				parser.next()
+				selection.on = parser.name()
+			case isName(parser.token):
+				selection.spread = parser.name()
+			}
+			parser.skipDirectives()
+			if selection.spread == "" {
+				selection.selections = parser.selectionSet()
+			}
+		} else {
+			selection.field = parser.name()
+			if parser.token == ":" {
+				parser.next()
+				selection.field = parser.name()
+			}
+			parser.skipArguments()
+			parser.skipDirectives()
+			if parser.token == "{" {
+				selection.selections = parser.selectionSet()
+			}
+		}
+		selections = append(selections, selection)
+	}
+	parser.expect("}")
+
+	return selections
+}
+
+// skipArguments skips the arguments or the variable definitions in parentheses, if any
+func (parser *queryParser) skipArguments() {
+	if parser.token != "(" {
+		return
+	}
+
+	for depth := 0; parser.ok; {
+		switch parser.token {
+		case "":
+			parser.ok = false
+		case "(":
+			depth++
+		case ")":
+			depth--
+		}
+		parser.next()
+		if depth == 0 {
+			return
+		}
+	}
+}
+
+func (parser *queryParser) skipDirectives() {
+	for parser.ok && parser.token == "@" {
+		parser.next()
+		parser.name()
+		parser.skipArguments()
+	}
+}
+
+func (parser *queryParser) name() string {
+	name := parser.token
+	if !isName(name) {
+		parser.ok = false
+		return ""
+	}
+	parser.next()
+
+	return name
+}
+
+func (parser *queryParser) expect(token string) {
+	if parser.token != token {
+		parser.ok = false
+		return
+	}
+	parser.next()
+}
+
+// next reads the next token into token, empty at the end of the query. Commas and comments are skipped,
+// strings are a single token
+func (parser *queryParser) next() {
+	query := strings.TrimLeft(parser.query, " \t\r\n,\ufeff")
+	for strings.HasPrefix(query, "#") {
+		end := strings.IndexAny(query, "\r\n")
+		if end < 0 {
+			end = len(query)
+		}
+		query = strings.TrimLeft(query[end:], " \t\r\n,")
+	}
+
+	end := 0
+	switch {
+	case query == "":
+	case strings.HasPrefix(query, "..."):
+		end = 3
+	case strings.HasPrefix(query, `"""`):
+		end = -1
+		for i := 3; ; i += 3 {
+			closing := strings.Index(query[i:], `"""`)
+			if closing < 0 {
+				break
+			}
+			i += closing
+			if query[i-1] != '\\' {
+				end = i + 3
+				break
+			}
+		}
+	case query[0] == '"':
+		end = -1
+		for i := 1; i < len(query) && query[i] != '\n'; i++ {
+			if query[i] == '\\' {
+				i++
+			} else if query[i] == '"' {
+				end = i + 1
+				break
+			}
+		}
+	case isNameChar(query[0]) || query[0] == '-':
+		end = 1
+		for end < len(query) && (isNameChar(query[end]) || query[end] == '.' || query[end] == '+' || query[end] == '-') {
+			end++
+		}
+	default:
+		end = 1
+	}
+	if end <= 0 && query != "" {
+		// an unterminated string
+		parser.ok = false
+		end = len(query)
+	}
+
+	parser.token, parser.query = query[:end], query[end:]
+}
+
+func isName(token string) bool {
+	if token == "" || token[0] >= '0' && token[0] <= '9' {
+		return false
+	}
+	for i := 0; i < len(token); i++ {
+		if !isNameChar(token[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
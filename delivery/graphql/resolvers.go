@@ -0,0 +1,185 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/zenkobert/sbtest-2/common/tracing"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// the GRPC methods the queries are logged and traced as, the search log learns the searchwords of
+// searchMethod whichever server they were searched on
+const (
+	searchMethod = "/movie.SearchMovie/SearchMovie"
+	detailMethod = "/movie.SearchMovie/GetMovieDetail"
+)
+
+var imdbIDPattern = regexp.MustCompile(`^tt\d+$`)
+
+// resolver resolves the Query type of schema.graphql
+type resolver struct {
+	MovieUsecase model.MovieUsecase
+	record       func(record string)
+}
+
+// call logs to record and traces a query or a lookup as the GRPC interceptor does method, request is the
+// GRPC request it stands for in the prototext format
+func call(ctx context.Context, record func(record string), method, request string) (context.Context, trace.Span) {
+	if record != nil {
+		record(fmt.Sprintf("%s/ %s", method, request))
+	}
+
+	return tracing.StartServerSpan(ctx, method)
+}
+
+// detailRequest is the GetMovieDetail request of a lookup of id
+func detailRequest(id string) string {
+	return fmt.Sprintf("id:%s", strconv.Quote(id))
+}
+
+type searchArgs struct {
+	Query string
+	Type  *string
+	Year  *int32
+	Page  int32
+}
+
+func (r *resolver) Search(ctx context.Context, args searchArgs) (result *searchResult, err error) {
+	ctx, span := call(ctx, r.record, searchMethod, fmt.Sprintf("searchword:%s pagination:%d", strconv.Quote(args.Query), args.Page))
+	defer func() {
+		tracing.RecordStatus(span, err)
+		span.End()
+	}()
+
+	query, err := model.NormalizeSearchword(args.Query)
+	if err != nil {
+		return nil, usecaseError(err)
+	}
+	if query == "" {
+		return nil, missingQueryError
+	}
+
+	if args.Page < 1 {
+		return nil, invalidPageError
+	}
+
+	var filter model.SearchFilter
+	if args.Type != nil {
+		filter.Type = strings.ToLower(*args.Type)
+	}
+	if args.Year != nil {
+		filter.Year = int(*args.Year)
+	}
+
+	movieSearch, err := r.MovieUsecase.SearchMovies(ctx, query, uint32(args.Page), filter)
+	if err != nil {
+		return nil, usecaseError(err)
+	}
+
+	if movieSearch.Error != "" {
+		// nothing found is an empty result rather than an error, unlike the GRPC service
+		return &searchResult{suggestions: movieSearch.Suggestions}, nil
+	}
+
+	return &searchResult{search: movieSearch, suggestions: movieSearch.Suggestions}, nil
+}
+
+// Movie is looked up by the loader, which logs and traces the lookup. A malformed ID is logged and traced here,
+// it is never looked up
+func (r *resolver) Movie(ctx context.Context, args struct{ ID graphql.ID }) (*movie, error) {
+	id := string(args.ID)
+	if !imdbIDPattern.MatchString(id) {
+		_, span := call(ctx, r.record, detailMethod, detailRequest(id))
+		tracing.RecordStatus(span, invalidImdbIDError)
+		span.End()
+		return nil, invalidImdbIDError
+	}
+
+	return loaderFrom(ctx).Load(id)
+}
+
+type searchResult struct {
+	search      *model.MovieSearch
+	suggestions []string
+}
+
+func (r *searchResult) Total() int32 {
+	if r.search == nil {
+		return 0
+	}
+
+	total, _ := strconv.Atoi(r.search.TotalResults)
+	return int32(total)
+}
+
+func (r *searchResult) Results() []*searchItem {
+	if r.search == nil {
+		return []*searchItem{}
+	}
+
+	items := make([]*searchItem, 0, len(r.search.Search))
+	for _, detail := range r.search.Search {
+		items = append(items, &searchItem{detail})
+	}
+	return items
+}
+
+func (r *searchResult) Suggestions() []string {
+	if r.suggestions == nil {
+		return []string{}
+	}
+
+	return r.suggestions
+}
+
+func (r *searchResult) CorrectedQuery() *string {
+	if r.search == nil || r.search.Corrected == "" {
+		return nil
+	}
+
+	return &r.search.Corrected
+}
+
+type searchItem struct {
+	detail model.SearchDetail
+}
+
+func (r *searchItem) ImdbID() graphql.ID {
+	return graphql.ID(r.detail.ImdbID)
+}
+
+func (r *searchItem) Title() string {
+	return r.detail.Title
+}
+
+func (r *searchItem) Year() string {
+	return r.detail.Year
+}
+
+func (r *searchItem) Type() string {
+	return r.detail.Type
+}
+
+func (r *searchItem) Poster() string {
+	return r.detail.Poster
+}
+
+// Movie is looked up in a batch with the movies of the other results, see detailLoader
+func (r *searchItem) Movie(ctx context.Context) (*movie, error) {
+	return loaderFrom(ctx).Load(r.detail.ImdbID)
+}
+
+// movie resolves the Movie type from the fields of the detail, the schema names them the same
+type movie struct {
+	model.MovieDetail
+}
+
+func (r *movie) ImdbID() graphql.ID {
+	return graphql.ID(r.MovieDetail.ImdbID)
+}
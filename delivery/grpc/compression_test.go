@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zenkobert/sbtest-2/common/compression"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/proto"
+)
+
+// recordedResponses are the GRPC responses built from the OMDb responses in testdata
+func recordedResponses(tb testing.TB) map[string]proto.Message {
+	read := func(name string, v interface{}) {
+		content, err := ioutil.ReadFile("testdata/" + name)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if err := json.Unmarshal(content, v); err != nil {
+			tb.Fatal(err)
+		}
+	}
+
+	serv := &movieServer{}
+	detail, search := &model.MovieDetail{}, &model.MovieSearch{}
+	read("omdb_detail.json", detail)
+	read("omdb_search.json", search)
+
+	return map[string]proto.Message{
+		"detail": serv.convertMovieDetailToRPCResponse(detail),
+		"search": serv.convertMovieSearchToRPCResponse(search),
+	}
+}
+
+// BenchmarkCompression reports the size of the recorded responses on the wire with each GRPC compressor
+func BenchmarkCompression(b *testing.B) {
+	for name, message := range recordedResponses(b) {
+		payload, err := proto.Marshal(message)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for _, compressorName := range []string{compression.Gzip, compression.Zstd} {
+			compressor := encoding.GetCompressor(compressorName)
+			b.Run(name+"/"+compressorName, func(b *testing.B) {
+				var compressed bytes.Buffer
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					compressed.Reset()
+					w, err := compressor.Compress(&compressed)
+					if err != nil {
+						b.Fatal(err)
+					}
+					w.Write(payload)
+					w.Close()
+				}
+
+				b.ReportMetric(float64(len(payload)), "raw-bytes")
+				b.ReportMetric(float64(compressed.Len()), "wire-bytes")
+				b.ReportMetric(100*(1-float64(compressed.Len())/float64(len(payload))), "%saved")
+			})
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package grpc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zenkobert/sbtest-2/common/redact"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrorDomain is the ErrorInfo domain of the errors returned by the service
+const ErrorDomain = "movie.SearchMovie"
+
+// reasons attached as ErrorInfo to the errors returned by the service, they are part of the API
+// so clients can tell errors apart without parsing messages
+const (
+	ReasonMissingSearchword = "MISSING_SEARCHWORD"
+	ReasonMissingQuery      = "MISSING_QUERY"
+	ReasonMissingPrefix     = "MISSING_PREFIX"
+	ReasonInvalidSearchword = "INVALID_SEARCHWORD"
+	ReasonInvalidImdbID     = "INVALID_IMDB_ID"
+	ReasonMovieNotFound     = "MOVIE_NOT_FOUND"
+	ReasonQuotaExceeded     = "QUOTA_EXCEEDED"
+	ReasonUpstreamError     = "UPSTREAM_ERROR"
+	ReasonUpstreamTimeout   = "UPSTREAM_TIMEOUT"
+	ReasonUnauthenticated   = "UNAUTHENTICATED"
+	ReasonTokenExpired      = "TOKEN_EXPIRED"
+	ReasonWatchlistNotFound = "WATCHLIST_NOT_FOUND"
+	ReasonWatchlistFull     = "WATCHLIST_FULL"
+	ReasonItemNotFound      = "ITEM_NOT_FOUND"
+	ReasonInvalidWatchlist  = "INVALID_WATCHLIST"
+	ReasonInvalidOrder      = "INVALID_ORDER"
+	ReasonInvalidDate       = "INVALID_DATE"
+	ReasonPermissionDenied  = "PERMISSION_DENIED"
+	ReasonReviewNotFound    = "REVIEW_NOT_FOUND"
+	ReasonInvalidRating     = "INVALID_RATING"
+	ReasonInvalidReview     = "INVALID_REVIEW"
+	ReasonInvalidState      = "INVALID_REVIEW_STATE"
+	ReasonInvalidImport     = "INVALID_IMPORT"
+	ReasonImportTooLarge    = "IMPORT_TOO_LARGE"
+)
+
+// quotaRetryDelay is the retry hint sent with quota errors, OMDb doesn't tell when the daily limit resets
+const quotaRetryDelay = time.Hour
+
+// statusError builds a status carrying reason, and a retry hint when retryDelay is set
+func statusError(code codes.Code, reason, message string, retryDelay time.Duration) error {
+	st := status.New(code, message)
+
+	var err error
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: ErrorDomain}
+	if retryDelay > 0 {
+		st, err = st.WithDetails(info, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)})
+	} else {
+		st, err = st.WithDetails(info)
+	}
+	if err != nil {
+		// the details are well known messages, marshaling them doesn't fail
+		panic(err)
+	}
+
+	return st.Err()
+}
+
+// searchNotFoundError is movieNotFoundError with a SearchSuggestions detail, when there are suggestions
+func searchNotFoundError(suggestions []string) error {
+	if len(suggestions) == 0 {
+		return movieNotFoundError
+	}
+
+	st, err := status.Convert(movieNotFoundError).WithDetails(&SearchSuggestions{Suggestions: suggestions})
+	if err != nil {
+		panic(err)
+	}
+
+	return st.Err()
+}
+
+// usecaseError maps the errors of the usecase to statuses, errors the service doesn't know stay Internal
+func usecaseError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrQuotaExceeded):
+		return statusError(codes.ResourceExhausted, ReasonQuotaExceeded, "OMDb request limit reached", quotaRetryDelay)
+	case errors.Is(err, model.ErrUpstream):
+		return statusError(codes.Unavailable, ReasonUpstreamError, "OMDb answered with an error", 0)
+	case model.IsTimeout(err):
+		return statusError(codes.DeadlineExceeded, ReasonUpstreamTimeout, "OMDb did not answer in time", 0)
+	case errors.Is(err, model.ErrInvalidSearchword):
+		return statusError(codes.InvalidArgument, ReasonInvalidSearchword, err.Error(), 0)
+	}
+
+	return status.Error(codes.Internal, redact.String(err.Error()))
+}
+
+// UnauthenticatedError is returned for calls without a valid bearer token, reason tells which
+func UnauthenticatedError(reason, message string) error {
+	return statusError(codes.Unauthenticated, reason, message, 0)
+}
+
+// watchlistError maps the errors of the watchlist usecase to statuses, the movie lookups fail like usecaseError
+func watchlistError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrUnauthenticated):
+		return UnauthenticatedError(ReasonUnauthenticated, "a bearer token is required")
+	case errors.Is(err, model.ErrWatchlistNotFound):
+		return statusError(codes.NotFound, ReasonWatchlistNotFound, "watchlist not found", 0)
+	case errors.Is(err, model.ErrItemNotFound):
+		return statusError(codes.NotFound, ReasonItemNotFound, "the movie is not in the watchlist", 0)
+	case errors.Is(err, model.ErrMovieNotFound):
+		return movieNotFoundError
+	case errors.Is(err, model.ErrWatchlistFull):
+		return statusError(codes.FailedPrecondition, ReasonWatchlistFull, "the watchlist has as many movies as allowed", 0)
+	case errors.Is(err, model.ErrInvalidWatchlist):
+		return statusError(codes.InvalidArgument, ReasonInvalidWatchlist, "the name of a watchlist has 1 to 100 characters", 0)
+	case errors.Is(err, model.ErrInvalidOrder):
+		return statusError(codes.InvalidArgument, ReasonInvalidOrder, "imdb_ids must list every movie of the watchlist once", 0)
+	case errors.Is(err, model.ErrInvalidDate):
+		return statusError(codes.InvalidArgument, ReasonInvalidDate, "watched_on must be a day such as 2021-09-04", 0)
+	case errors.Is(err, model.ErrInvalidImport):
+		return statusError(codes.InvalidArgument, ReasonInvalidImport, err.Error(), 0)
+	case errors.Is(err, model.ErrImportTooLarge):
+		return statusError(codes.InvalidArgument, ReasonImportTooLarge, "the CSV has more rows than an import allows", 0)
+	}
+
+	return usecaseError(err)
+}
+
+// reviewError maps the errors of the review usecase to statuses, the movie lookups fail like usecaseError
+func reviewError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrUnauthenticated):
+		return UnauthenticatedError(ReasonUnauthenticated, "a bearer token is required")
+	case errors.Is(err, model.ErrPermissionDenied):
+		return statusError(codes.PermissionDenied, ReasonPermissionDenied, "only moderators are allowed to do this", 0)
+	case errors.Is(err, model.ErrReviewNotFound):
+		return statusError(codes.NotFound, ReasonReviewNotFound, "review not found", 0)
+	case errors.Is(err, model.ErrMovieNotFound):
+		return movieNotFoundError
+	case errors.Is(err, model.ErrInvalidRating):
+		return statusError(codes.InvalidArgument, ReasonInvalidRating, "rating must be from 1 to 10", 0)
+	case errors.Is(err, model.ErrInvalidReview):
+		return statusError(codes.InvalidArgument, ReasonInvalidReview, "a review or note has up to 1000 characters", 0)
+	case errors.Is(err, model.ErrInvalidReviewState):
+		return statusError(codes.InvalidArgument, ReasonInvalidState, "state must be published or hidden", 0)
+	}
+
+	return usecaseError(err)
+}
+
+// similarError maps the errors of the similar movies to statuses, excluding the watchlisted movies needs a caller
+func similarError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrUnauthenticated):
+		return UnauthenticatedError(ReasonUnauthenticated, "a bearer token is required to exclude the watchlisted movies")
+	case errors.Is(err, model.ErrMovieNotFound):
+		return movieNotFoundError
+	}
+
+	return usecaseError(err)
+}
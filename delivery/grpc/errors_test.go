@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	mock "github.com/zenkobert/sbtest-2/domain/mocks"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// reasonOf returns the ErrorInfo reason and retry delay attached to err
+func reasonOf(t *testing.T, err error) (reason string, retryDelay time.Duration) {
+	st, ok := status.FromError(err)
+	if !assert.True(t, ok, "%v is not a status", err) {
+		return "", 0
+	}
+
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			assert.Equal(t, ErrorDomain, detail.Domain)
+			reason = detail.Reason
+		case *errdetails.RetryInfo:
+			retryDelay = detail.RetryDelay.AsDuration()
+		}
+	}
+
+	return reason, retryDelay
+}
+
+func TestUsecaseError(t *testing.T) {
+	testCases := []struct {
+		name       string
+		err        error
+		code       codes.Code
+		reason     string
+		retryDelay time.Duration
+	}{
+		{"quota", model.ErrQuotaExceeded, codes.ResourceExhausted, ReasonQuotaExceeded, quotaRetryDelay},
+		{"wrapped quota", fmt.Errorf("search: %w", model.ErrQuotaExceeded), codes.ResourceExhausted, ReasonQuotaExceeded, quotaRetryDelay},
+		{"upstream", model.ErrUpstream, codes.Unavailable, ReasonUpstreamError, 0},
+		{"deadline", context.DeadlineExceeded, codes.DeadlineExceeded, ReasonUpstreamTimeout, 0},
+		{"net timeout", fmt.Errorf("Get omdb: %w", timeoutError{}), codes.DeadlineExceeded, ReasonUpstreamTimeout, 0},
+		{"invalid searchword", fmt.Errorf("%w: it is too long", model.ErrInvalidSearchword), codes.InvalidArgument, ReasonInvalidSearchword, 0},
+		{"unknown", errors.New("boom"), codes.Internal, "", 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("[usecaseError] "+testCase.name, func(t *testing.T) {
+			err := usecaseError(testCase.err)
+			assert.Equal(t, testCase.code, status.Code(err))
+
+			reason, retryDelay := reasonOf(t, err)
+			assert.Equal(t, testCase.reason, reason)
+			assert.Equal(t, testCase.retryDelay, retryDelay)
+		})
+	}
+}
+
+func TestWatchlistError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{"unauthenticated", model.ErrUnauthenticated, codes.Unauthenticated, ReasonUnauthenticated},
+		{"watchlist not found", model.ErrWatchlistNotFound, codes.NotFound, ReasonWatchlistNotFound},
+		{"item not found", model.ErrItemNotFound, codes.NotFound, ReasonItemNotFound},
+		{"movie not found", model.ErrMovieNotFound, codes.NotFound, ReasonMovieNotFound},
+		{"full", model.ErrWatchlistFull, codes.FailedPrecondition, ReasonWatchlistFull},
+		{"invalid name", model.ErrInvalidWatchlist, codes.InvalidArgument, ReasonInvalidWatchlist},
+		{"invalid order", model.ErrInvalidOrder, codes.InvalidArgument, ReasonInvalidOrder},
+		{"invalid date", model.ErrInvalidDate, codes.InvalidArgument, ReasonInvalidDate},
+		{"invalid import", fmt.Errorf("%w: bad row", model.ErrInvalidImport), codes.InvalidArgument, ReasonInvalidImport},
+		{"import too large", model.ErrImportTooLarge, codes.InvalidArgument, ReasonImportTooLarge},
+		{"quota of the movie lookups", model.ErrQuotaExceeded, codes.ResourceExhausted, ReasonQuotaExceeded},
+		{"unknown", errors.New("disk full"), codes.Internal, ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("[watchlistError] "+testCase.name, func(t *testing.T) {
+			err := watchlistError(testCase.err)
+			assert.Equal(t, testCase.code, status.Code(err))
+
+			reason, _ := reasonOf(t, err)
+			assert.Equal(t, testCase.reason, reason)
+		})
+	}
+}
+
+func TestReviewError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{"unauthenticated", model.ErrUnauthenticated, codes.Unauthenticated, ReasonUnauthenticated},
+		{"not a moderator", model.ErrPermissionDenied, codes.PermissionDenied, ReasonPermissionDenied},
+		{"review not found", model.ErrReviewNotFound, codes.NotFound, ReasonReviewNotFound},
+		{"movie not found", model.ErrMovieNotFound, codes.NotFound, ReasonMovieNotFound},
+		{"invalid rating", model.ErrInvalidRating, codes.InvalidArgument, ReasonInvalidRating},
+		{"invalid text", model.ErrInvalidReview, codes.InvalidArgument, ReasonInvalidReview},
+		{"invalid state", model.ErrInvalidReviewState, codes.InvalidArgument, ReasonInvalidState},
+		{"quota of the movie lookups", model.ErrQuotaExceeded, codes.ResourceExhausted, ReasonQuotaExceeded},
+		{"unknown", errors.New("disk full"), codes.Internal, ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("[reviewError] "+testCase.name, func(t *testing.T) {
+			err := reviewError(testCase.err)
+			assert.Equal(t, testCase.code, status.Code(err))
+
+			reason, _ := reasonOf(t, err)
+			assert.Equal(t, testCase.reason, reason)
+		})
+	}
+}
+
+func TestHandlerErrorReasons(t *testing.T) {
+	t.Run("[SearchMovie] missing searchword", func(t *testing.T) {
+		serv := &movieServer{&mock.MovieUsecase{}}
+		_, err := serv.SearchMovie(todoContext, &SearchMovieRequest{})
+		reason, _ := reasonOf(t, err)
+		assert.Equal(t, ReasonMissingSearchword, reason)
+	})
+
+	t.Run("[SearchMovie] quota exceeded", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(nil, model.ErrQuotaExceeded)
+
+		serv := &movieServer{movieUsecaseMock}
+		_, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: "ironman"})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("[GetMovieDetail] malformed id and not found", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(&model.MovieDetail{Error: "Incorrect IMDb ID."}, nil)
+
+		serv := &movieServer{movieUsecaseMock}
+		_, err := serv.GetMovieDetail(todoContext, &GetMovieDetailRequest{Id: "abc"})
+		reason, _ := reasonOf(t, err)
+		assert.Equal(t, ReasonInvalidImdbID, reason)
+
+		_, err = serv.GetMovieDetail(todoContext, &GetMovieDetailRequest{Id: "tt0000001"})
+		reason, _ = reasonOf(t, err)
+		assert.Equal(t, ReasonMovieNotFound, reason)
+	})
+}
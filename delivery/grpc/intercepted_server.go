@@ -0,0 +1,219 @@
+package grpc
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+)
+
+// interceptedMovieServer runs the unary interceptor around every unary call the way the GRPC server does,
+// for callers that skip the GRPC transport such as the in-process REST gateway
+type interceptedMovieServer struct {
+	server      SearchMovieServer
+	interceptor grpc.UnaryServerInterceptor
+}
+
+func NewInterceptedMovieServer(server SearchMovieServer, interceptor grpc.UnaryServerInterceptor) SearchMovieServer {
+	return &interceptedMovieServer{
+		server:      server,
+		interceptor: interceptor,
+	}
+}
+
+func (serv *interceptedMovieServer) SearchMovie(ctx context.Context, req *SearchMovieRequest) (*SearchMovieResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info("SearchMovie"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return serv.server.SearchMovie(ctx, req.(*SearchMovieRequest))
+	})
+	searchResp, _ := resp.(*SearchMovieResponse)
+
+	return searchResp, err
+}
+
+func (serv *interceptedMovieServer) GetMovieDetail(ctx context.Context, req *GetMovieDetailRequest) (*GetMovieDetailResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info("GetMovieDetail"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return serv.server.GetMovieDetail(ctx, req.(*GetMovieDetailRequest))
+	})
+	detailResp, _ := resp.(*GetMovieDetailResponse)
+
+	return detailResp, err
+}
+
+func (serv *interceptedMovieServer) FullTextSearch(ctx context.Context, req *FullTextSearchRequest) (*FullTextSearchResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info("FullTextSearch"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return serv.server.FullTextSearch(ctx, req.(*FullTextSearchRequest))
+	})
+	searchResp, _ := resp.(*FullTextSearchResponse)
+
+	return searchResp, err
+}
+
+func (serv *interceptedMovieServer) Autocomplete(ctx context.Context, req *AutocompleteRequest) (*AutocompleteResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info("Autocomplete"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return serv.server.Autocomplete(ctx, req.(*AutocompleteRequest))
+	})
+	completeResp, _ := resp.(*AutocompleteResponse)
+
+	return completeResp, err
+}
+
+func (serv *interceptedMovieServer) GetSimilarMovies(ctx context.Context, req *GetSimilarMoviesRequest) (*GetSimilarMoviesResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info("GetSimilarMovies"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return serv.server.GetSimilarMovies(ctx, req.(*GetSimilarMoviesRequest))
+	})
+	similarResp, _ := resp.(*GetSimilarMoviesResponse)
+
+	return similarResp, err
+}
+
+// AutocompleteStream isn't served without the GRPC transport, the REST gateway has no streaming binding
+func (serv *interceptedMovieServer) AutocompleteStream(stream SearchMovie_AutocompleteStreamServer) error {
+	return serv.server.AutocompleteStream(stream)
+}
+
+func (serv *interceptedMovieServer) info(method string) *grpc.UnaryServerInfo {
+	return &grpc.UnaryServerInfo{
+		Server:     serv.server,
+		FullMethod: "/" + SearchMovie_ServiceDesc.ServiceName + "/" + method,
+	}
+}
+
+// interceptedWatchlistServer is interceptedMovieServer for the Watchlist service
+type interceptedWatchlistServer struct {
+	server      WatchlistServer
+	interceptor grpc.UnaryServerInterceptor
+}
+
+func NewInterceptedWatchlistServer(server WatchlistServer, interceptor grpc.UnaryServerInterceptor) WatchlistServer {
+	return &interceptedWatchlistServer{
+		server:      server,
+		interceptor: interceptor,
+	}
+}
+
+func (serv *interceptedWatchlistServer) CreateWatchlist(ctx context.Context, req *CreateWatchlistRequest) (*WatchlistResponse, error) {
+	return serv.intercept(ctx, req, "CreateWatchlist", func(ctx context.Context) (*WatchlistResponse, error) {
+		return serv.server.CreateWatchlist(ctx, req)
+	})
+}
+
+func (serv *interceptedWatchlistServer) ListWatchlists(ctx context.Context, req *ListWatchlistsRequest) (*ListWatchlistsResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info("ListWatchlists"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return serv.server.ListWatchlists(ctx, req.(*ListWatchlistsRequest))
+	})
+	listsResp, _ := resp.(*ListWatchlistsResponse)
+
+	return listsResp, err
+}
+
+func (serv *interceptedWatchlistServer) ListItems(ctx context.Context, req *ListItemsRequest) (*WatchlistResponse, error) {
+	return serv.intercept(ctx, req, "ListItems", func(ctx context.Context) (*WatchlistResponse, error) {
+		return serv.server.ListItems(ctx, req)
+	})
+}
+
+func (serv *interceptedWatchlistServer) AddItem(ctx context.Context, req *AddItemRequest) (*WatchlistResponse, error) {
+	return serv.intercept(ctx, req, "AddItem", func(ctx context.Context) (*WatchlistResponse, error) {
+		return serv.server.AddItem(ctx, req)
+	})
+}
+
+func (serv *interceptedWatchlistServer) RemoveItem(ctx context.Context, req *RemoveItemRequest) (*WatchlistResponse, error) {
+	return serv.intercept(ctx, req, "RemoveItem", func(ctx context.Context) (*WatchlistResponse, error) {
+		return serv.server.RemoveItem(ctx, req)
+	})
+}
+
+func (serv *interceptedWatchlistServer) ReorderItems(ctx context.Context, req *ReorderItemsRequest) (*WatchlistResponse, error) {
+	return serv.intercept(ctx, req, "ReorderItems", func(ctx context.Context) (*WatchlistResponse, error) {
+		return serv.server.ReorderItems(ctx, req)
+	})
+}
+
+func (serv *interceptedWatchlistServer) MarkWatched(ctx context.Context, req *MarkWatchedRequest) (*WatchlistResponse, error) {
+	return serv.intercept(ctx, req, "MarkWatched", func(ctx context.Context) (*WatchlistResponse, error) {
+		return serv.server.MarkWatched(ctx, req)
+	})
+}
+
+func (serv *interceptedWatchlistServer) ImportHistory(ctx context.Context, req *ImportHistoryRequest) (*ImportHistoryResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info("ImportHistory"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return serv.server.ImportHistory(ctx, req.(*ImportHistoryRequest))
+	})
+	importResp, _ := resp.(*ImportHistoryResponse)
+
+	return importResp, err
+}
+
+// intercept runs call, a method answering with a watchlist, through the interceptor.
+// The interceptor may replace the context but not the request
+func (serv *interceptedWatchlistServer) intercept(ctx context.Context, req interface{}, method string, call func(ctx context.Context) (*WatchlistResponse, error)) (*WatchlistResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info(method), func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return call(ctx)
+	})
+	listResp, _ := resp.(*WatchlistResponse)
+
+	return listResp, err
+}
+
+func (serv *interceptedWatchlistServer) info(method string) *grpc.UnaryServerInfo {
+	return &grpc.UnaryServerInfo{
+		Server:     serv.server,
+		FullMethod: "/" + Watchlist_ServiceDesc.ServiceName + "/" + method,
+	}
+}
+
+// interceptedReviewServer is interceptedMovieServer for the Review service
+type interceptedReviewServer struct {
+	server      ReviewServer
+	interceptor grpc.UnaryServerInterceptor
+}
+
+func NewInterceptedReviewServer(server ReviewServer, interceptor grpc.UnaryServerInterceptor) ReviewServer {
+	return &interceptedReviewServer{
+		server:      server,
+		interceptor: interceptor,
+	}
+}
+
+func (serv *interceptedReviewServer) SubmitReview(ctx context.Context, req *SubmitReviewRequest) (*ReviewResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info("SubmitReview"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return serv.server.SubmitReview(ctx, req.(*SubmitReviewRequest))
+	})
+	reviewResp, _ := resp.(*ReviewResponse)
+
+	return reviewResp, err
+}
+
+func (serv *interceptedReviewServer) DeleteReview(ctx context.Context, req *DeleteReviewRequest) (*DeleteReviewResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info("DeleteReview"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return serv.server.DeleteReview(ctx, req.(*DeleteReviewRequest))
+	})
+	deleteResp, _ := resp.(*DeleteReviewResponse)
+
+	return deleteResp, err
+}
+
+func (serv *interceptedReviewServer) ListReviews(ctx context.Context, req *ListReviewsRequest) (*ListReviewsResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info("ListReviews"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return serv.server.ListReviews(ctx, req.(*ListReviewsRequest))
+	})
+	listResp, _ := resp.(*ListReviewsResponse)
+
+	return listResp, err
+}
+
+func (serv *interceptedReviewServer) ModerateReview(ctx context.Context, req *ModerateReviewRequest) (*ReviewResponse, error) {
+	resp, err := serv.interceptor(ctx, req, serv.info("ModerateReview"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return serv.server.ModerateReview(ctx, req.(*ModerateReviewRequest))
+	})
+	reviewResp, _ := resp.(*ReviewResponse)
+
+	return reviewResp, err
+}
+
+func (serv *interceptedReviewServer) info(method string) *grpc.UnaryServerInfo {
+	return &grpc.UnaryServerInfo{
+		Server:     serv.server,
+		FullMethod: "/" + Review_ServiceDesc.ServiceName + "/" + method,
+	}
+}
@@ -0,0 +1,270 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	mock "github.com/zenkobert/sbtest-2/domain/mocks"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	status "google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func passThrough(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(ctx, req)
+}
+
+func searchUsecase() *mock.MovieUsecase {
+	movieUsecaseMock := &mock.MovieUsecase{}
+	movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{
+		Search:       []model.SearchDetail{{Title: "Iron Man", ImdbID: "tt0371746"}},
+		TotalResults: "1",
+	}, nil)
+
+	return movieUsecaseMock
+}
+
+func TestNewInterceptedMovieServer(t *testing.T) {
+	t.Run("[NewInterceptedMovieServer]", func(t *testing.T) {
+		serv := &movieServer{&mock.MovieUsecase{}}
+
+		actual := NewInterceptedMovieServer(serv, passThrough)
+		assert.Equal(t, serv, actual.(*interceptedMovieServer).server)
+	})
+}
+
+func TestInterceptedMovieServer(t *testing.T) {
+	t.Run("[SearchMovie] interceptor sees the full method and the response", func(t *testing.T) {
+		var methods []string
+		var responses []interface{}
+		interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			methods = append(methods, info.FullMethod)
+			resp, err := handler(ctx, req)
+			responses = append(responses, resp)
+			return resp, err
+		}
+
+		serv := NewInterceptedMovieServer(&movieServer{searchUsecase()}, interceptor)
+		resp, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: "iron man"})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "Iron Man", resp.Results[0].Title)
+			assert.Equal(t, []string{"/movie.SearchMovie/SearchMovie"}, methods)
+			assert.Equal(t, []interface{}{resp}, responses)
+		}
+	})
+
+	t.Run("[GetMovieDetail] interceptor can reject the call", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		rejected := status.Error(codes.PermissionDenied, "denied")
+		interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			assert.Equal(t, "/movie.SearchMovie/GetMovieDetail", info.FullMethod)
+			return nil, rejected
+		}
+
+		serv := NewInterceptedMovieServer(&movieServer{movieUsecaseMock}, interceptor)
+		resp, err := serv.GetMovieDetail(todoContext, &GetMovieDetailRequest{Id: "tt0371746"})
+		assert.Nil(t, resp)
+		assert.Equal(t, rejected, err)
+		movieUsecaseMock.AssertNotCalled(t, "GetMovieDetailByID", testify.Anything, testify.Anything)
+	})
+
+	t.Run("[RegisterSearchMovieHandlerServer] REST calls run through the interceptor", func(t *testing.T) {
+		calls := 0
+		interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls++
+			return handler(ctx, req)
+		}
+
+		gwmux := runtime.NewServeMux()
+		err := RegisterSearchMovieHandlerServer(todoContext, gwmux, NewInterceptedMovieServer(&movieServer{searchUsecase()}, interceptor))
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/movies?searchword=iron", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Iron Man")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestInterceptedWatchlistServer(t *testing.T) {
+	t.Run("[AddItem] interceptor sees the full method and hands its context over", func(t *testing.T) {
+		var methods []string
+		interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			methods = append(methods, info.FullMethod)
+			return handler(model.WithCaller(ctx, "user-1"), req)
+		}
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("AddItem", testify.Anything, "list-1", "tt0371746").Return(weekendList, nil)
+
+		serv := NewInterceptedWatchlistServer(NewWatchlistServer(usecase, &mock.ImportUsecase{}), interceptor)
+		resp, err := serv.AddItem(todoContext, &AddItemRequest{WatchlistId: "list-1", ImdbId: "tt0371746"})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "list-1", resp.Id)
+			assert.Equal(t, []string{"/movie.Watchlist/AddItem"}, methods)
+
+			ctx := usecase.Calls[0].Arguments.Get(0).(context.Context)
+			caller, _ := model.CallerFrom(ctx)
+			assert.Equal(t, "user-1", caller)
+		}
+	})
+
+	t.Run("[ListWatchlists] interceptor can reject the call", func(t *testing.T) {
+		rejected := status.Error(codes.Unauthenticated, "denied")
+		interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return nil, rejected
+		}
+		usecase := &mock.WatchlistUsecase{}
+
+		serv := NewInterceptedWatchlistServer(NewWatchlistServer(usecase, &mock.ImportUsecase{}), interceptor)
+		resp, err := serv.ListWatchlists(todoContext, &ListWatchlistsRequest{})
+		assert.Nil(t, resp)
+		assert.Equal(t, rejected, err)
+		usecase.AssertNotCalled(t, "Watchlists", testify.Anything)
+	})
+
+	t.Run("[RegisterWatchlistHandlerServer] the Authorization header reaches the interceptor", func(t *testing.T) {
+		var authorization []string
+		interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			authorization = md.Get("authorization")
+			return handler(ctx, req)
+		}
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("ReorderItems", testify.Anything, "list-1", []string{"tt1228705", "tt0371746"}).Return(weekendList, nil)
+
+		gwmux := runtime.NewServeMux()
+		err := RegisterWatchlistHandlerServer(todoContext, gwmux, NewInterceptedWatchlistServer(NewWatchlistServer(usecase, &mock.ImportUsecase{}), interceptor))
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/watchlists/list-1:reorder", strings.NewReader(`{"imdbIds": ["tt1228705", "tt0371746"]}`))
+		req.Header.Set("Authorization", "Bearer token")
+		gwmux.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Weekend")
+		assert.Equal(t, []string{"Bearer token"}, authorization)
+	})
+}
+
+func TestInterceptedReviewServer(t *testing.T) {
+	t.Run("[RegisterReviewHandlerServer] reviews go through the interceptor", func(t *testing.T) {
+		var methods []string
+		interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			methods = append(methods, info.FullMethod)
+			return handler(ctx, req)
+		}
+		usecase := &mock.ReviewUsecase{}
+		usecase.On("SubmitReview", testify.Anything, "tt0371746", 8, "").Return(suitReview, nil)
+
+		gwmux := runtime.NewServeMux()
+		err := RegisterReviewHandlerServer(todoContext, gwmux, NewInterceptedReviewServer(NewReviewServer(usecase), interceptor))
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/v1/movies/tt0371746/reviews/mine", strings.NewReader(`{"rating": 8}`))
+		gwmux.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "review-1")
+		assert.Equal(t, []string{"/movie.Review/SubmitReview"}, methods)
+	})
+
+	t.Run("[ListReviews] interceptor can reject the call", func(t *testing.T) {
+		rejected := status.Error(codes.Unauthenticated, "denied")
+		interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return nil, rejected
+		}
+		usecase := &mock.ReviewUsecase{}
+
+		resp, err := NewInterceptedReviewServer(NewReviewServer(usecase), interceptor).ListReviews(todoContext, &ListReviewsRequest{ImdbId: "tt0371746"})
+		assert.Nil(t, resp)
+		assert.Equal(t, rejected, err)
+		usecase.AssertNotCalled(t, "Reviews", testify.Anything, testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+	})
+}
+
+// BenchmarkGateway compares a REST search going through the gateway over loopback TCP,
+// over an in-memory listener and calling the server directly
+func BenchmarkGateway(b *testing.B) {
+	serv := &movieServer{searchUsecase()}
+
+	serve := func(b *testing.B, listener net.Listener) {
+		grpcServer := grpc.NewServer(grpc.UnaryInterceptor(passThrough))
+		RegisterSearchMovieServer(grpcServer, serv)
+		go grpcServer.Serve(listener)
+		b.Cleanup(grpcServer.Stop)
+	}
+	// the gateway closes its connection once ctx is done
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	run := func(b *testing.B, gwmux *runtime.ServeMux) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/movies?searchword=iron", nil)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			rec := httptest.NewRecorder()
+			gwmux.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				b.Fatal(rec.Body.String())
+			}
+		}
+	}
+
+	b.Run("dial loopback", func(b *testing.B) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			b.Fatal(err)
+		}
+		serve(b, listener)
+
+		gwmux := runtime.NewServeMux()
+		err = RegisterSearchMovieHandlerFromEndpoint(ctx, gwmux, listener.Addr().String(), []grpc.DialOption{grpc.WithInsecure()})
+		if err != nil {
+			b.Fatal(err)
+		}
+		run(b, gwmux)
+	})
+
+	b.Run("dial bufconn", func(b *testing.B) {
+		listener := bufconn.Listen(1 << 20)
+		serve(b, listener)
+
+		gwmux := runtime.NewServeMux()
+		err := RegisterSearchMovieHandlerFromEndpoint(ctx, gwmux, "in-process", []grpc.DialOption{
+			grpc.WithInsecure(),
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		run(b, gwmux)
+	})
+
+	b.Run("direct", func(b *testing.B) {
+		gwmux := runtime.NewServeMux()
+		err := RegisterSearchMovieHandlerServer(ctx, gwmux, NewInterceptedMovieServer(serv, passThrough))
+		if err != nil {
+			b.Fatal(err)
+		}
+		run(b, gwmux)
+	})
+}
@@ -18,8 +18,37 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SearchMovieClient interface {
+	// Search movies by title
+	//
+	// Returns the matching movies one page at a time, NOT_FOUND when nothing matches, with a SearchSuggestions
+	// detail when the searchword looks misspelled
 	SearchMovie(ctx context.Context, in *SearchMovieRequest, opts ...grpc.CallOption) (*SearchMovieResponse, error)
+	// Get a movie detail
+	//
+	// Returns NOT_FOUND for an unknown IMDb ID and INVALID_ARGUMENT for a malformed one
 	GetMovieDetail(ctx context.Context, in *GetMovieDetailRequest, opts ...grpc.CallOption) (*GetMovieDetailResponse, error)
+	// Search movies by the words of their details
+	//
+	// Searches a local index of the details fetched so far rather than OMDb, so a query can name actors or
+	// words of the plot. Returns NOT_FOUND when nothing matches
+	FullTextSearch(ctx context.Context, in *FullTextSearchRequest, opts ...grpc.CallOption) (*FullTextSearchResponse, error)
+	// Complete a title being typed
+	//
+	// Completes from the titles of the dataset, the search log and the movies found so far, the most popular
+	// first. Returns INVALID_ARGUMENT for an empty prefix
+	Autocomplete(ctx context.Context, in *AutocompleteRequest, opts ...grpc.CallOption) (*AutocompleteResponse, error)
+	// Complete a title as it is typed
+	//
+	// Answers each request of the stream with its completions, in order, sparing a search box a call per
+	// keystroke. gRPC only
+	AutocompleteStream(ctx context.Context, opts ...grpc.CallOption) (SearchMovie_AutocompleteStreamClient, error)
+	// Get movies like a movie
+	//
+	// Ranks the movies of the local index of the details fetched so far by the genres, director, writers and
+	// actors they share with the movie, then how close their decade and rating are. Returns NOT_FOUND for an
+	// unknown IMDb ID, INVALID_ARGUMENT for a malformed one and UNAUTHENTICATED when excluding the watchlisted
+	// movies without a token
+	GetSimilarMovies(ctx context.Context, in *GetSimilarMoviesRequest, opts ...grpc.CallOption) (*GetSimilarMoviesResponse, error)
 }
 
 type searchMovieClient struct {
@@ -48,12 +77,99 @@ func (c *searchMovieClient) GetMovieDetail(ctx context.Context, in *GetMovieDeta
 	return out, nil
 }
 
+func (c *searchMovieClient) FullTextSearch(ctx context.Context, in *FullTextSearchRequest, opts ...grpc.CallOption) (*FullTextSearchResponse, error) {
+	out := new(FullTextSearchResponse)
+	err := c.cc.Invoke(ctx, "/movie.SearchMovie/FullTextSearch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchMovieClient) Autocomplete(ctx context.Context, in *AutocompleteRequest, opts ...grpc.CallOption) (*AutocompleteResponse, error) {
+	out := new(AutocompleteResponse)
+	err := c.cc.Invoke(ctx, "/movie.SearchMovie/Autocomplete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchMovieClient) AutocompleteStream(ctx context.Context, opts ...grpc.CallOption) (SearchMovie_AutocompleteStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SearchMovie_ServiceDesc.Streams[0], "/movie.SearchMovie/AutocompleteStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &searchMovieAutocompleteStreamClient{stream}
+	return x, nil
+}
+
+type SearchMovie_AutocompleteStreamClient interface {
+	Send(*AutocompleteRequest) error
+	Recv() (*AutocompleteResponse, error)
+	grpc.ClientStream
+}
+
+type searchMovieAutocompleteStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *searchMovieAutocompleteStreamClient) Send(m *AutocompleteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *searchMovieAutocompleteStreamClient) Recv() (*AutocompleteResponse, error) {
+	m := new(AutocompleteResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *searchMovieClient) GetSimilarMovies(ctx context.Context, in *GetSimilarMoviesRequest, opts ...grpc.CallOption) (*GetSimilarMoviesResponse, error) {
+	out := new(GetSimilarMoviesResponse)
+	err := c.cc.Invoke(ctx, "/movie.SearchMovie/GetSimilarMovies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SearchMovieServer is the server API for SearchMovie service.
 // All implementations should embed UnimplementedSearchMovieServer
 // for forward compatibility
 type SearchMovieServer interface {
+	// Search movies by title
+	//
+	// Returns the matching movies one page at a time, NOT_FOUND when nothing matches, with a SearchSuggestions
+	// detail when the searchword looks misspelled
 	SearchMovie(context.Context, *SearchMovieRequest) (*SearchMovieResponse, error)
+	// Get a movie detail
+	//
+	// Returns NOT_FOUND for an unknown IMDb ID and INVALID_ARGUMENT for a malformed one
 	GetMovieDetail(context.Context, *GetMovieDetailRequest) (*GetMovieDetailResponse, error)
+	// Search movies by the words of their details
+	//
+	// Searches a local index of the details fetched so far rather than OMDb, so a query can name actors or
+	// words of the plot. Returns NOT_FOUND when nothing matches
+	FullTextSearch(context.Context, *FullTextSearchRequest) (*FullTextSearchResponse, error)
+	// Complete a title being typed
+	//
+	// Completes from the titles of the dataset, the search log and the movies found so far, the most popular
+	// first. Returns INVALID_ARGUMENT for an empty prefix
+	Autocomplete(context.Context, *AutocompleteRequest) (*AutocompleteResponse, error)
+	// Complete a title as it is typed
+	//
+	// Answers each request of the stream with its completions, in order, sparing a search box a call per
+	// keystroke. gRPC only
+	AutocompleteStream(SearchMovie_AutocompleteStreamServer) error
+	// Get movies like a movie
+	//
+	// Ranks the movies of the local index of the details fetched so far by the genres, director, writers and
+	// actors they share with the movie, then how close their decade and rating are. Returns NOT_FOUND for an
+	// unknown IMDb ID, INVALID_ARGUMENT for a malformed one and UNAUTHENTICATED when excluding the watchlisted
+	// movies without a token
+	GetSimilarMovies(context.Context, *GetSimilarMoviesRequest) (*GetSimilarMoviesResponse, error)
 }
 
 // UnimplementedSearchMovieServer should be embedded to have forward compatible implementations.
@@ -66,6 +182,18 @@ func (UnimplementedSearchMovieServer) SearchMovie(context.Context, *SearchMovieR
 func (UnimplementedSearchMovieServer) GetMovieDetail(context.Context, *GetMovieDetailRequest) (*GetMovieDetailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMovieDetail not implemented")
 }
+func (UnimplementedSearchMovieServer) FullTextSearch(context.Context, *FullTextSearchRequest) (*FullTextSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FullTextSearch not implemented")
+}
+func (UnimplementedSearchMovieServer) Autocomplete(context.Context, *AutocompleteRequest) (*AutocompleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Autocomplete not implemented")
+}
+func (UnimplementedSearchMovieServer) AutocompleteStream(SearchMovie_AutocompleteStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method AutocompleteStream not implemented")
+}
+func (UnimplementedSearchMovieServer) GetSimilarMovies(context.Context, *GetSimilarMoviesRequest) (*GetSimilarMoviesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimilarMovies not implemented")
+}
 
 // UnsafeSearchMovieServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to SearchMovieServer will
@@ -114,6 +242,86 @@ func _SearchMovie_GetMovieDetail_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SearchMovie_FullTextSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FullTextSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchMovieServer).FullTextSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.SearchMovie/FullTextSearch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchMovieServer).FullTextSearch(ctx, req.(*FullTextSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchMovie_Autocomplete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AutocompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchMovieServer).Autocomplete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.SearchMovie/Autocomplete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchMovieServer).Autocomplete(ctx, req.(*AutocompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchMovie_AutocompleteStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SearchMovieServer).AutocompleteStream(&searchMovieAutocompleteStreamServer{stream})
+}
+
+type SearchMovie_AutocompleteStreamServer interface {
+	Send(*AutocompleteResponse) error
+	Recv() (*AutocompleteRequest, error)
+	grpc.ServerStream
+}
+
+type searchMovieAutocompleteStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *searchMovieAutocompleteStreamServer) Send(m *AutocompleteResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *searchMovieAutocompleteStreamServer) Recv() (*AutocompleteRequest, error) {
+	m := new(AutocompleteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _SearchMovie_GetSimilarMovies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSimilarMoviesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchMovieServer).GetSimilarMovies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.SearchMovie/GetSimilarMovies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchMovieServer).GetSimilarMovies(ctx, req.(*GetSimilarMoviesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SearchMovie_ServiceDesc is the grpc.ServiceDesc for SearchMovie service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -129,6 +337,605 @@ var SearchMovie_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMovieDetail",
 			Handler:    _SearchMovie_GetMovieDetail_Handler,
 		},
+		{
+			MethodName: "FullTextSearch",
+			Handler:    _SearchMovie_FullTextSearch_Handler,
+		},
+		{
+			MethodName: "Autocomplete",
+			Handler:    _SearchMovie_Autocomplete_Handler,
+		},
+		{
+			MethodName: "GetSimilarMovies",
+			Handler:    _SearchMovie_GetSimilarMovies_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AutocompleteStream",
+			Handler:       _SearchMovie_AutocompleteStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "delivery/grpc/movie.proto",
+}
+
+// WatchlistClient is the client API for Watchlist service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WatchlistClient interface {
+	// Create a watchlist
+	CreateWatchlist(ctx context.Context, in *CreateWatchlistRequest, opts ...grpc.CallOption) (*WatchlistResponse, error)
+	// List the watchlists of the caller
+	ListWatchlists(ctx context.Context, in *ListWatchlistsRequest, opts ...grpc.CallOption) (*ListWatchlistsResponse, error)
+	// List the items of a watchlist with the details of their movies
+	ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (*WatchlistResponse, error)
+	// Add a movie at the end of a watchlist
+	//
+	// Adding a movie the watchlist already has leaves it where it is. Returns NOT_FOUND for a movie
+	// unknown to OMDb and FAILED_PRECONDITION when the watchlist has 100 movies
+	AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*WatchlistResponse, error)
+	// Remove a movie from a watchlist
+	RemoveItem(ctx context.Context, in *RemoveItemRequest, opts ...grpc.CallOption) (*WatchlistResponse, error)
+	// Reorder the movies of a watchlist
+	//
+	// Returns INVALID_ARGUMENT unless every movie of the watchlist is listed once
+	ReorderItems(ctx context.Context, in *ReorderItemsRequest, opts ...grpc.CallOption) (*WatchlistResponse, error)
+	// Mark a movie of a watchlist as watched, or not watched
+	MarkWatched(ctx context.Context, in *MarkWatchedRequest, opts ...grpc.CallOption) (*WatchlistResponse, error)
+	// Import a watch history exported from Letterboxd or IMDb into a watchlist, and the ratings of its movies
+	//
+	// Rows are matched by IMDb ID when they have one, by title and year otherwise. Lookups are throttled so a big
+	// history doesn't use up the OMDb quota, when it is the rows left are failed. Returns INVALID_ARGUMENT for a CSV
+	// in neither format or with too many rows
+	ImportHistory(ctx context.Context, in *ImportHistoryRequest, opts ...grpc.CallOption) (*ImportHistoryResponse, error)
+}
+
+type watchlistClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWatchlistClient(cc grpc.ClientConnInterface) WatchlistClient {
+	return &watchlistClient{cc}
+}
+
+func (c *watchlistClient) CreateWatchlist(ctx context.Context, in *CreateWatchlistRequest, opts ...grpc.CallOption) (*WatchlistResponse, error) {
+	out := new(WatchlistResponse)
+	err := c.cc.Invoke(ctx, "/movie.Watchlist/CreateWatchlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistClient) ListWatchlists(ctx context.Context, in *ListWatchlistsRequest, opts ...grpc.CallOption) (*ListWatchlistsResponse, error) {
+	out := new(ListWatchlistsResponse)
+	err := c.cc.Invoke(ctx, "/movie.Watchlist/ListWatchlists", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistClient) ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (*WatchlistResponse, error) {
+	out := new(WatchlistResponse)
+	err := c.cc.Invoke(ctx, "/movie.Watchlist/ListItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistClient) AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*WatchlistResponse, error) {
+	out := new(WatchlistResponse)
+	err := c.cc.Invoke(ctx, "/movie.Watchlist/AddItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistClient) RemoveItem(ctx context.Context, in *RemoveItemRequest, opts ...grpc.CallOption) (*WatchlistResponse, error) {
+	out := new(WatchlistResponse)
+	err := c.cc.Invoke(ctx, "/movie.Watchlist/RemoveItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistClient) ReorderItems(ctx context.Context, in *ReorderItemsRequest, opts ...grpc.CallOption) (*WatchlistResponse, error) {
+	out := new(WatchlistResponse)
+	err := c.cc.Invoke(ctx, "/movie.Watchlist/ReorderItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistClient) MarkWatched(ctx context.Context, in *MarkWatchedRequest, opts ...grpc.CallOption) (*WatchlistResponse, error) {
+	out := new(WatchlistResponse)
+	err := c.cc.Invoke(ctx, "/movie.Watchlist/MarkWatched", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchlistClient) ImportHistory(ctx context.Context, in *ImportHistoryRequest, opts ...grpc.CallOption) (*ImportHistoryResponse, error) {
+	out := new(ImportHistoryResponse)
+	err := c.cc.Invoke(ctx, "/movie.Watchlist/ImportHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WatchlistServer is the server API for Watchlist service.
+// All implementations should embed UnimplementedWatchlistServer
+// for forward compatibility
+type WatchlistServer interface {
+	// Create a watchlist
+	CreateWatchlist(context.Context, *CreateWatchlistRequest) (*WatchlistResponse, error)
+	// List the watchlists of the caller
+	ListWatchlists(context.Context, *ListWatchlistsRequest) (*ListWatchlistsResponse, error)
+	// List the items of a watchlist with the details of their movies
+	ListItems(context.Context, *ListItemsRequest) (*WatchlistResponse, error)
+	// Add a movie at the end of a watchlist
+	//
+	// Adding a movie the watchlist already has leaves it where it is. Returns NOT_FOUND for a movie
+	// unknown to OMDb and FAILED_PRECONDITION when the watchlist has 100 movies
+	AddItem(context.Context, *AddItemRequest) (*WatchlistResponse, error)
+	// Remove a movie from a watchlist
+	RemoveItem(context.Context, *RemoveItemRequest) (*WatchlistResponse, error)
+	// Reorder the movies of a watchlist
+	//
+	// Returns INVALID_ARGUMENT unless every movie of the watchlist is listed once
+	ReorderItems(context.Context, *ReorderItemsRequest) (*WatchlistResponse, error)
+	// Mark a movie of a watchlist as watched, or not watched
+	MarkWatched(context.Context, *MarkWatchedRequest) (*WatchlistResponse, error)
+	// Import a watch history exported from Letterboxd or IMDb into a watchlist, and the ratings of its movies
+	//
+	// Rows are matched by IMDb ID when they have one, by title and year otherwise. Lookups are throttled so a big
+	// history doesn't use up the OMDb quota, when it is the rows left are failed. Returns INVALID_ARGUMENT for a CSV
+	// in neither format or with too many rows
+	ImportHistory(context.Context, *ImportHistoryRequest) (*ImportHistoryResponse, error)
+}
+
+// UnimplementedWatchlistServer should be embedded to have forward compatible implementations.
+type UnimplementedWatchlistServer struct {
+}
+
+func (UnimplementedWatchlistServer) CreateWatchlist(context.Context, *CreateWatchlistRequest) (*WatchlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWatchlist not implemented")
+}
+func (UnimplementedWatchlistServer) ListWatchlists(context.Context, *ListWatchlistsRequest) (*ListWatchlistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWatchlists not implemented")
+}
+func (UnimplementedWatchlistServer) ListItems(context.Context, *ListItemsRequest) (*WatchlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListItems not implemented")
+}
+func (UnimplementedWatchlistServer) AddItem(context.Context, *AddItemRequest) (*WatchlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItem not implemented")
+}
+func (UnimplementedWatchlistServer) RemoveItem(context.Context, *RemoveItemRequest) (*WatchlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveItem not implemented")
+}
+func (UnimplementedWatchlistServer) ReorderItems(context.Context, *ReorderItemsRequest) (*WatchlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderItems not implemented")
+}
+func (UnimplementedWatchlistServer) MarkWatched(context.Context, *MarkWatchedRequest) (*WatchlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkWatched not implemented")
+}
+func (UnimplementedWatchlistServer) ImportHistory(context.Context, *ImportHistoryRequest) (*ImportHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportHistory not implemented")
+}
+
+// UnsafeWatchlistServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WatchlistServer will
+// result in compilation errors.
+type UnsafeWatchlistServer interface {
+	mustEmbedUnimplementedWatchlistServer()
+}
+
+func RegisterWatchlistServer(s grpc.ServiceRegistrar, srv WatchlistServer) {
+	s.RegisterService(&Watchlist_ServiceDesc, srv)
+}
+
+func _Watchlist_CreateWatchlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWatchlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServer).CreateWatchlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Watchlist/CreateWatchlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServer).CreateWatchlist(ctx, req.(*CreateWatchlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Watchlist_ListWatchlists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWatchlistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServer).ListWatchlists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Watchlist/ListWatchlists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServer).ListWatchlists(ctx, req.(*ListWatchlistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Watchlist_ListItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServer).ListItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Watchlist/ListItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServer).ListItems(ctx, req.(*ListItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Watchlist_AddItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServer).AddItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Watchlist/AddItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServer).AddItem(ctx, req.(*AddItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Watchlist_RemoveItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServer).RemoveItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Watchlist/RemoveItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServer).RemoveItem(ctx, req.(*RemoveItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Watchlist_ReorderItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServer).ReorderItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Watchlist/ReorderItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServer).ReorderItems(ctx, req.(*ReorderItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Watchlist_MarkWatched_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkWatchedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServer).MarkWatched(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Watchlist/MarkWatched",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServer).MarkWatched(ctx, req.(*MarkWatchedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Watchlist_ImportHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchlistServer).ImportHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Watchlist/ImportHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchlistServer).ImportHistory(ctx, req.(*ImportHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Watchlist_ServiceDesc is the grpc.ServiceDesc for Watchlist service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Watchlist_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "movie.Watchlist",
+	HandlerType: (*WatchlistServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWatchlist",
+			Handler:    _Watchlist_CreateWatchlist_Handler,
+		},
+		{
+			MethodName: "ListWatchlists",
+			Handler:    _Watchlist_ListWatchlists_Handler,
+		},
+		{
+			MethodName: "ListItems",
+			Handler:    _Watchlist_ListItems_Handler,
+		},
+		{
+			MethodName: "AddItem",
+			Handler:    _Watchlist_AddItem_Handler,
+		},
+		{
+			MethodName: "RemoveItem",
+			Handler:    _Watchlist_RemoveItem_Handler,
+		},
+		{
+			MethodName: "ReorderItems",
+			Handler:    _Watchlist_ReorderItems_Handler,
+		},
+		{
+			MethodName: "MarkWatched",
+			Handler:    _Watchlist_MarkWatched_Handler,
+		},
+		{
+			MethodName: "ImportHistory",
+			Handler:    _Watchlist_ImportHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "delivery/grpc/movie.proto",
+}
+
+// ReviewClient is the client API for Review service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReviewClient interface {
+	// Submit the review of the caller for a movie
+	//
+	// A user has one review per movie, submitting again replaces its rating and text. Returns NOT_FOUND
+	// for a movie unknown to OMDb
+	SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error)
+	// Delete the review of the caller for a movie
+	DeleteReview(ctx context.Context, in *DeleteReviewRequest, opts ...grpc.CallOption) (*DeleteReviewResponse, error)
+	// List the reviews of a movie
+	//
+	// Anyone can list the published reviews, listing the hidden ones needs the bearer token of a moderator
+	ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error)
+	// Publish or hide a review, for moderators only
+	ModerateReview(ctx context.Context, in *ModerateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error)
+}
+
+type reviewClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReviewClient(cc grpc.ClientConnInterface) ReviewClient {
+	return &reviewClient{cc}
+}
+
+func (c *reviewClient) SubmitReview(ctx context.Context, in *SubmitReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error) {
+	out := new(ReviewResponse)
+	err := c.cc.Invoke(ctx, "/movie.Review/SubmitReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewClient) DeleteReview(ctx context.Context, in *DeleteReviewRequest, opts ...grpc.CallOption) (*DeleteReviewResponse, error) {
+	out := new(DeleteReviewResponse)
+	err := c.cc.Invoke(ctx, "/movie.Review/DeleteReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewClient) ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error) {
+	out := new(ListReviewsResponse)
+	err := c.cc.Invoke(ctx, "/movie.Review/ListReviews", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reviewClient) ModerateReview(ctx context.Context, in *ModerateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error) {
+	out := new(ReviewResponse)
+	err := c.cc.Invoke(ctx, "/movie.Review/ModerateReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReviewServer is the server API for Review service.
+// All implementations should embed UnimplementedReviewServer
+// for forward compatibility
+type ReviewServer interface {
+	// Submit the review of the caller for a movie
+	//
+	// A user has one review per movie, submitting again replaces its rating and text. Returns NOT_FOUND
+	// for a movie unknown to OMDb
+	SubmitReview(context.Context, *SubmitReviewRequest) (*ReviewResponse, error)
+	// Delete the review of the caller for a movie
+	DeleteReview(context.Context, *DeleteReviewRequest) (*DeleteReviewResponse, error)
+	// List the reviews of a movie
+	//
+	// Anyone can list the published reviews, listing the hidden ones needs the bearer token of a moderator
+	ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error)
+	// Publish or hide a review, for moderators only
+	ModerateReview(context.Context, *ModerateReviewRequest) (*ReviewResponse, error)
+}
+
+// UnimplementedReviewServer should be embedded to have forward compatible implementations.
+type UnimplementedReviewServer struct {
+}
+
+func (UnimplementedReviewServer) SubmitReview(context.Context, *SubmitReviewRequest) (*ReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitReview not implemented")
+}
+func (UnimplementedReviewServer) DeleteReview(context.Context, *DeleteReviewRequest) (*DeleteReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteReview not implemented")
+}
+func (UnimplementedReviewServer) ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviews not implemented")
+}
+func (UnimplementedReviewServer) ModerateReview(context.Context, *ModerateReviewRequest) (*ReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModerateReview not implemented")
+}
+
+// UnsafeReviewServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReviewServer will
+// result in compilation errors.
+type UnsafeReviewServer interface {
+	mustEmbedUnimplementedReviewServer()
+}
+
+func RegisterReviewServer(s grpc.ServiceRegistrar, srv ReviewServer) {
+	s.RegisterService(&Review_ServiceDesc, srv)
+}
+
+func _Review_SubmitReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewServer).SubmitReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Review/SubmitReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewServer).SubmitReview(ctx, req.(*SubmitReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Review_DeleteReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewServer).DeleteReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Review/DeleteReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewServer).DeleteReview(ctx, req.(*DeleteReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Review_ListReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewServer).ListReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Review/ListReviews",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewServer).ListReviews(ctx, req.(*ListReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Review_ModerateReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReviewServer).ModerateReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/movie.Review/ModerateReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReviewServer).ModerateReview(ctx, req.(*ModerateReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Review_ServiceDesc is the grpc.ServiceDesc for Review service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Review_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "movie.Review",
+	HandlerType: (*ReviewServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitReview",
+			Handler:    _Review_SubmitReview_Handler,
+		},
+		{
+			MethodName: "DeleteReview",
+			Handler:    _Review_DeleteReview_Handler,
+		},
+		{
+			MethodName: "ListReviews",
+			Handler:    _Review_ListReviews_Handler,
+		},
+		{
+			MethodName: "ModerateReview",
+			Handler:    _Review_ModerateReview_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "delivery/grpc/movie.proto",
@@ -2,18 +2,39 @@ package grpc
 
 import (
 	context "context"
-	"net/url"
+	"io"
+	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	model "github.com/zenkobert/sbtest-2/domain"
+	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
+	"google.golang.org/grpc/metadata"
+)
+
+// maxSearchPages bounds the OMDb calls of a search over all pages, each one counts against the daily quota
+const maxSearchPages = 10
+
+// defaultSimilarLimit is how many similar movies are answered when the request doesn't tell, maxSimilarLimit the most
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 50
+)
+
+// header metadata keys of the freshness of an answer
+const (
+	MetadataMaxAge       = "x-cache-max-age"
+	MetadataLastModified = "x-last-modified"
 )
 
 var (
-	incorrectImdbIDError = status.Error(codes.InvalidArgument, "incorrect IMDB ID")
-	movieNotFoundError   = status.Error(codes.NotFound, "movie not found")
+	missingSearchwordError = statusError(codes.InvalidArgument, ReasonMissingSearchword, "please specify a searchword param", 0)
+	missingQueryError      = statusError(codes.InvalidArgument, ReasonMissingQuery, "please specify a query param", 0)
+	missingPrefixError     = statusError(codes.InvalidArgument, ReasonMissingPrefix, "please specify a prefix param", 0)
+	incorrectImdbIDError   = statusError(codes.InvalidArgument, ReasonInvalidImdbID, "incorrect IMDB ID", 0)
+	movieNotFoundError     = statusError(codes.NotFound, ReasonMovieNotFound, "movie not found", 0)
 )
 
 type movieServer struct {
@@ -31,42 +52,205 @@ func (serv *movieServer) SearchMovie(ctx context.Context, req *SearchMovieReques
 		req.Pagination = 1
 	}
 
+	req.Searchword, err = model.NormalizeSearchword(req.Searchword)
+	if err != nil {
+		return resp, usecaseError(err)
+	}
 	if req.Searchword == "" {
-		return resp, status.Error(codes.InvalidArgument, "please specify a searchword param")
+		return resp, missingSearchwordError
 	}
 
-	req.Searchword = url.QueryEscape(req.Searchword)
+	if req.AllPages {
+		return serv.searchAllPages(ctx, req.Searchword)
+	}
 
-	movieSearch, err := serv.MovieUsecase.SearchMovies(req.Searchword, uint32(req.Pagination))
+	ctx, freshness := model.WithFreshness(ctx)
+	movieSearch, err := serv.MovieUsecase.SearchMovies(ctx, req.Searchword, uint32(req.Pagination), model.SearchFilter{})
 	if err != nil {
-		return resp, status.Error(codes.Internal, err.Error())
+		return resp, usecaseError(err)
 	}
 
 	if movieSearch.Error != "" {
-		return resp, movieNotFoundError
+		return resp, searchNotFoundError(movieSearch.Suggestions)
 	}
 
+	sendFreshness(ctx, freshness)
 	return serv.convertMovieSearchToRPCResponse(movieSearch), nil
 }
 
+// searchAllPages gathers the results of every page of a search, up to maxSearchPages
+func (serv *movieServer) searchAllPages(ctx context.Context, searchword string) (resp *SearchMovieResponse, err error) {
+	// the answer is as fresh as its stalest page, and of unknown freshness when one of them is
+	var oldest model.Freshness
+	known := true
+	merged := &model.MovieSearch{}
+	for page := uint32(1); page <= maxSearchPages; page++ {
+		pageCtx, freshness := model.WithFreshness(ctx)
+		movieSearch, err := serv.MovieUsecase.SearchMovies(pageCtx, searchword, page, model.SearchFilter{})
+		if err != nil {
+			return resp, usecaseError(err)
+		}
+
+		if movieSearch.Error != "" {
+			if page == 1 {
+				return resp, searchNotFoundError(movieSearch.Suggestions)
+			}
+			// OMDb answers not found past the last page
+			break
+		}
+
+		switch {
+		case freshness.FetchedAt.IsZero():
+			known = false
+		case oldest.FetchedAt.IsZero():
+			oldest = *freshness
+		default:
+			if freshness.FetchedAt.Before(oldest.FetchedAt) {
+				oldest.FetchedAt = freshness.FetchedAt
+			}
+			if freshness.MaxAge < oldest.MaxAge {
+				oldest.MaxAge = freshness.MaxAge
+			}
+		}
+
+		if page == 1 {
+			// the suggestions are the ones of the searchword, the following pages are the ones of the
+			// suggestion searched instead of it, if any
+			merged.Suggestions, merged.Corrected = movieSearch.Suggestions, movieSearch.Corrected
+			if movieSearch.Corrected != "" {
+				searchword = movieSearch.Corrected
+			}
+		}
+		merged.TotalResults = movieSearch.TotalResults
+		merged.Search = append(merged.Search, movieSearch.Search...)
+		total, _ := strconv.Atoi(movieSearch.TotalResults)
+		if len(movieSearch.Search) == 0 || len(merged.Search) >= total {
+			break
+		}
+	}
+
+	if known {
+		sendFreshness(ctx, &oldest)
+	}
+	return serv.convertMovieSearchToRPCResponse(merged), nil
+}
+
 func (serv *movieServer) GetMovieDetail(ctx context.Context, req *GetMovieDetailRequest) (resp *GetMovieDetailResponse, err error) {
 	err = validateImdbID(req.Id)
 	if err != nil {
 		return resp, err
 	}
 
-	detail, err := serv.MovieUsecase.GetMovieDetailByID(req.Id)
+	ctx, freshness := model.WithFreshness(ctx)
+	detail, err := serv.MovieUsecase.GetMovieDetailByID(ctx, req.Id)
 	if err != nil {
-		return resp, status.Error(codes.Internal, err.Error())
+		return resp, usecaseError(err)
 	}
 
 	if detail.Error != "" {
 		return resp, movieNotFoundError
 	}
 
+	sendFreshness(ctx, freshness)
 	return serv.convertMovieDetailToRPCResponse(detail), nil
 }
 
+func (serv *movieServer) FullTextSearch(ctx context.Context, req *FullTextSearchRequest) (resp *FullTextSearchResponse, err error) {
+	if req.Pagination <= 0 {
+		req.Pagination = 1
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		return resp, missingQueryError
+	}
+
+	result, err := serv.MovieUsecase.FullTextSearch(ctx, req.Query, uint32(req.Pagination), model.SearchFilter{Type: req.Type, Year: int(req.Year)})
+	if err != nil {
+		return resp, usecaseError(err)
+	}
+
+	if len(result.Hits) == 0 {
+		return resp, movieNotFoundError
+	}
+
+	return serv.convertFullTextResultToRPCResponse(result), nil
+}
+
+// Autocomplete answers with no completions rather than NOT_FOUND, nothing known starting with a prefix
+// is no error while typing
+func (serv *movieServer) Autocomplete(ctx context.Context, req *AutocompleteRequest) (resp *AutocompleteResponse, err error) {
+	if strings.TrimSpace(req.Prefix) == "" {
+		return resp, missingPrefixError
+	}
+
+	completions, err := serv.MovieUsecase.Autocomplete(ctx, req.Prefix, int(req.Limit))
+	if err != nil {
+		return resp, usecaseError(err)
+	}
+
+	return serv.convertCompletionsToRPCResponse(req.Prefix, completions), nil
+}
+
+// AutocompleteStream answers the requests of the stream one by one until the client closes it. A request
+// without prefix ends the stream with missingPrefixError, like it fails Autocomplete
+func (serv *movieServer) AutocompleteStream(stream SearchMovie_AutocompleteStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp, err := serv.Autocomplete(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// GetSimilarMovies answers with no movies rather than NOT_FOUND when none is like the movie, only an unknown
+// movie is
+func (serv *movieServer) GetSimilarMovies(ctx context.Context, req *GetSimilarMoviesRequest) (resp *GetSimilarMoviesResponse, err error) {
+	err = validateImdbID(req.Id)
+	if err != nil {
+		return resp, err
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultSimilarLimit
+	}
+	if limit > maxSimilarLimit {
+		limit = maxSimilarLimit
+	}
+
+	movies, err := serv.MovieUsecase.SimilarMovies(ctx, req.Id, limit, req.ExcludeWatchlisted)
+	if err != nil {
+		return resp, similarError(err)
+	}
+
+	return serv.convertSimilarMoviesToRPCResponse(movies), nil
+}
+
+// sendFreshness tells the client when the answer was fetched from OMDb and how long it stays fresh, when known.
+// The REST gateway turns it into caching headers
+func sendFreshness(ctx context.Context, freshness *model.Freshness) {
+	if freshness.FetchedAt.IsZero() {
+		return
+	}
+
+	// fails outside of a GRPC call, e.g. in unit tests, there is nobody to tell then
+	grpc.SetHeader(ctx, metadata.Pairs(
+		MetadataMaxAge, strconv.FormatInt(int64(freshness.MaxAge/time.Second), 10),
+		MetadataLastModified, freshness.FetchedAt.UTC().Format(http.TimeFormat),
+	))
+}
+
 func validateImdbID(id string) error {
 	prefixIdx := strings.Index(id, "tt")
 	if prefixIdx < 0 {
@@ -95,6 +279,63 @@ func (serv *movieServer) convertMovieSearchToRPCResponse(m *model.MovieSearch) (
 	}
 
 	r.Total = m.TotalResults
+	r.Suggestions = m.Suggestions
+	r.CorrectedSearchword = m.Corrected
+
+	return r
+}
+
+func (serv *movieServer) convertFullTextResultToRPCResponse(m *model.FullTextResult) (r *FullTextSearchResponse) {
+	r = &FullTextSearchResponse{Total: int32(m.Total)}
+
+	for _, hit := range m.Hits {
+		r.Hits = append(r.Hits, &FullTextHit{
+			Movie: &Search{
+				Title:  hit.Movie.Title,
+				Year:   hit.Movie.Year,
+				ImdbId: hit.Movie.ImdbID,
+				Type:   hit.Movie.Type,
+				Poster: hit.Movie.Poster,
+			},
+			Score:  hit.Score,
+			Fields: hit.Fields,
+		})
+	}
+
+	return r
+}
+
+func (serv *movieServer) convertCompletionsToRPCResponse(prefix string, completions []model.Completion) (r *AutocompleteResponse) {
+	r = &AutocompleteResponse{Prefix: prefix}
+
+	for _, completion := range completions {
+		r.Completions = append(r.Completions, &Completion{
+			Title:  completion.Title,
+			ImdbId: completion.ImdbID,
+			Year:   completion.Year,
+			Type:   completion.Type,
+		})
+	}
+
+	return r
+}
+
+func (serv *movieServer) convertSimilarMoviesToRPCResponse(movies []model.SimilarMovie) (r *GetSimilarMoviesResponse) {
+	r = &GetSimilarMoviesResponse{}
+
+	for _, movie := range movies {
+		r.Movies = append(r.Movies, &SimilarMovie{
+			Movie: &Search{
+				Title:  movie.Movie.Title,
+				Year:   movie.Movie.Year,
+				ImdbId: movie.Movie.ImdbID,
+				Type:   movie.Movie.Type,
+				Poster: movie.Movie.Poster,
+			},
+			Score:  movie.Score,
+			Shared: movie.Shared,
+		})
+	}
 
 	return r
 }
@@ -124,6 +365,10 @@ func (serv *movieServer) convertMovieDetailToRPCResponse(m *model.MovieDetail) (
 		BoxOffice:  m.BoxOffice,
 		Production: m.Production,
 		Website:    m.Production,
+		SeriesId:   m.SeriesID,
+		Season:     m.Season,
+		Episode:    m.Episode,
+		Sources:    m.Sources,
 	}
 
 	for _, rating := range m.Ratings {
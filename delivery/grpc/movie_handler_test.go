@@ -3,12 +3,17 @@ package grpc
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	testify "github.com/stretchr/testify/mock"
 	model "github.com/zenkobert/sbtest-2/domain"
 	mock "github.com/zenkobert/sbtest-2/domain/mocks"
+	grpc "google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 )
@@ -29,24 +34,38 @@ func TestNewMovieServer(t *testing.T) {
 }
 
 func TestSearchMovie(t *testing.T) {
-	t.Run("[SearchMovie] ensure page always > 0 and searchword is URL encoded", func(t *testing.T) {
+	t.Run("[SearchMovie] IF searchword is blank, too long or has control characters, RETURN InvalidArgument error", func(t *testing.T) {
+		serv := &movieServer{&mock.MovieUsecase{}}
+
+		_, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: " \t "})
+		assert.Equal(t, missingSearchwordError, err)
+
+		for _, searchword := range []string{"iron\x00man", "iron man\x1b[2J", strings.Repeat("a", model.MaxSearchwordLength+1)} {
+			_, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: searchword})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), searchword)
+			reason, _ := reasonOf(t, err)
+			assert.Equal(t, ReasonInvalidSearchword, reason, searchword)
+		}
+	})
+
+	t.Run("[SearchMovie] ensure page always > 0 and searchword is normalized", func(t *testing.T) {
 		movieUsecaseMock := &mock.MovieUsecase{}
-		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything).Return(&model.MovieSearch{}, nil)
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{}, nil)
 
 		serv := &movieServer{movieUsecaseMock}
 		req := &SearchMovieRequest{
 			Pagination: -1,
-			Searchword: "iron man",
+			Searchword: "  iron \t man ",
 		}
 
 		serv.SearchMovie(todoContext, req)
 		assert.Equal(t, int32(1), req.Pagination)
-		assert.Equal(t, "iron+man", req.Searchword)
+		assert.Equal(t, "iron man", req.Searchword)
 	})
 
 	t.Run("[SearchMovie] IF searchword is null, RETURN InvalidArgument error", func(t *testing.T) {
 		movieUsecaseMock := &mock.MovieUsecase{}
-		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything).Return(&model.MovieSearch{}, nil)
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{}, nil)
 
 		serv := &movieServer{movieUsecaseMock}
 		req := &SearchMovieRequest{
@@ -66,7 +85,7 @@ func TestSearchMovie(t *testing.T) {
 		errMsg := "Oops, something happened"
 
 		movieUsecaseMock := &mock.MovieUsecase{}
-		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything).Return(&model.MovieSearch{}, errors.New(errMsg))
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{}, errors.New(errMsg))
 
 		serv := &movieServer{movieUsecaseMock}
 		req := &SearchMovieRequest{Searchword: "ironman"}
@@ -80,7 +99,7 @@ func TestSearchMovie(t *testing.T) {
 
 	t.Run("[SearchMovie] movieSearch has an error", func(t *testing.T) {
 		movieUsecaseMock := &mock.MovieUsecase{}
-		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything).Return(&model.MovieSearch{Error: "error"}, nil)
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{Error: "error"}, nil)
 
 		serv := &movieServer{movieUsecaseMock}
 		req := &SearchMovieRequest{Searchword: "ironman"}
@@ -91,6 +110,37 @@ func TestSearchMovie(t *testing.T) {
 		}
 	})
 
+	t.Run("[SearchMovie] movieSearch has an error and suggestions", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{Error: "error", Suggestions: []string{"iron man", "iron men"}}, nil)
+
+		serv := &movieServer{movieUsecaseMock}
+
+		_, actualErr := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: "iron mann"})
+		st, _ := status.FromError(actualErr)
+		assert.Equal(t, codes.NotFound, st.Code())
+		if details := st.Details(); assert.Len(t, details, 2) {
+			assert.Equal(t, []string{"iron man", "iron men"}, details[1].(*SearchSuggestions).Suggestions)
+		}
+	})
+
+	t.Run("[SearchMovie] suggestion searched instead", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{
+			Search:      []model.SearchDetail{{Title: "Iron Man", Year: "2008", ImdbID: "id1", Type: "movie", Poster: "poster1"}},
+			Suggestions: []string{"iron man"},
+			Corrected:   "iron man",
+		}, nil)
+
+		serv := &movieServer{movieUsecaseMock}
+
+		resp, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: "iron mann"})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "iron man", resp.CorrectedSearchword)
+			assert.Equal(t, []string{"iron man"}, resp.Suggestions)
+		}
+	})
+
 	t.Run("[SearchMovie] no error, movieSearch has result", func(t *testing.T) {
 		movieUsecaseMock := &mock.MovieUsecase{}
 		movieSearchResult := &model.MovieSearch{
@@ -99,7 +149,7 @@ func TestSearchMovie(t *testing.T) {
 				{"Captain America", "2011", "id2", "movie", "poster2"},
 			},
 		}
-		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything).Return(movieSearchResult, nil)
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(movieSearchResult, nil)
 
 		serv := &movieServer{movieUsecaseMock}
 
@@ -108,6 +158,288 @@ func TestSearchMovie(t *testing.T) {
 	})
 }
 
+// pagedUsecase answers searches with pages of 10 movies out of total, not found past the last page
+func pagedUsecase(total int) *mock.MovieUsecase {
+	movieUsecaseMock := &mock.MovieUsecase{}
+	for page := 1; page <= (total+9)/10; page++ {
+		movieSearch := &model.MovieSearch{TotalResults: strconv.Itoa(total)}
+		for i := (page - 1) * 10; i < page*10 && i < total; i++ {
+			movieSearch.Search = append(movieSearch.Search, model.SearchDetail{ImdbID: fmt.Sprintf("tt%07d", i)})
+		}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, "iron man", uint32(page), testify.Anything).Return(movieSearch, nil)
+	}
+	movieUsecaseMock.On("SearchMovies", testify.Anything, "iron man", testify.Anything, testify.Anything).Return(&model.MovieSearch{Error: "Movie not found!"}, nil)
+
+	return movieUsecaseMock
+}
+
+func TestSearchAllPages(t *testing.T) {
+	t.Run("[SearchMovie] results of every page, in order", func(t *testing.T) {
+		movieUsecaseMock := pagedUsecase(25)
+		serv := &movieServer{movieUsecaseMock}
+
+		resp, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: "iron man", Pagination: 2, AllPages: true})
+		if assert.Nil(t, err) && assert.Len(t, resp.Results, 25) {
+			assert.Equal(t, "tt0000000", resp.Results[0].ImdbId)
+			assert.Equal(t, "tt0000024", resp.Results[24].ImdbId)
+			assert.Equal(t, "25", resp.Total)
+		}
+		// no request for a page past the total
+		movieUsecaseMock.AssertNumberOfCalls(t, "SearchMovies", 3)
+	})
+
+	t.Run("[SearchMovie] up to 10 pages", func(t *testing.T) {
+		movieUsecaseMock := pagedUsecase(250)
+		serv := &movieServer{movieUsecaseMock}
+
+		resp, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: "iron man", AllPages: true})
+		if assert.Nil(t, err) {
+			assert.Len(t, resp.Results, 100)
+			assert.Equal(t, "250", resp.Total)
+		}
+		movieUsecaseMock.AssertNumberOfCalls(t, "SearchMovies", maxSearchPages)
+	})
+
+	t.Run("[SearchMovie] stops at the page OMDb doesn't have", func(t *testing.T) {
+		// OMDb counting more movies than it returns
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, uint32(1), testify.Anything).Return(&model.MovieSearch{
+			Search:       make([]model.SearchDetail, 10),
+			TotalResults: "50",
+		}, nil)
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, uint32(2), testify.Anything).Return(&model.MovieSearch{
+			Search:       make([]model.SearchDetail, 1),
+			TotalResults: "50",
+		}, nil)
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, uint32(3), testify.Anything).Return(&model.MovieSearch{Error: "Movie not found!"}, nil)
+		serv := &movieServer{movieUsecaseMock}
+
+		resp, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: "iron man", AllPages: true})
+		if assert.Nil(t, err) {
+			assert.Len(t, resp.Results, 11)
+		}
+		movieUsecaseMock.AssertNumberOfCalls(t, "SearchMovies", 3)
+	})
+
+	t.Run("[SearchMovie] the pages after a corrected first one are the ones of the correction", func(t *testing.T) {
+		movieUsecaseMock := pagedUsecase(15)
+		first, _ := movieUsecaseMock.SearchMovies(todoContext, "iron man", 1, model.SearchFilter{})
+		corrected := *first
+		corrected.Suggestions, corrected.Corrected = []string{"iron man"}, "iron man"
+		movieUsecaseMock.On("SearchMovies", testify.Anything, "iron mann", uint32(1), testify.Anything).Return(&corrected, nil)
+		serv := &movieServer{movieUsecaseMock}
+
+		resp, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: "iron mann", AllPages: true})
+		if assert.Nil(t, err) {
+			assert.Len(t, resp.Results, 15)
+			assert.Equal(t, "iron man", resp.CorrectedSearchword)
+			assert.Equal(t, []string{"iron man"}, resp.Suggestions)
+		}
+		movieUsecaseMock.AssertCalled(t, "SearchMovies", testify.Anything, "iron man", uint32(2), testify.Anything)
+		movieUsecaseMock.AssertNotCalled(t, "SearchMovies", testify.Anything, "iron mann", uint32(2), testify.Anything)
+	})
+
+	t.Run("[SearchMovie] not found", func(t *testing.T) {
+		serv := &movieServer{pagedUsecase(0)}
+
+		_, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: "iron man", AllPages: true})
+		assert.Equal(t, movieNotFoundError, err)
+	})
+
+	t.Run("[SearchMovie] an error on any page fails the search", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, uint32(1), testify.Anything).Return(&model.MovieSearch{
+			Search:       []model.SearchDetail{{ImdbID: "tt1"}},
+			TotalResults: "20",
+		}, nil)
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, uint32(2), testify.Anything).Return(nil, model.ErrQuotaExceeded)
+		serv := &movieServer{movieUsecaseMock}
+
+		resp, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: "iron man", AllPages: true})
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+}
+
+func TestFullTextSearch(t *testing.T) {
+	t.Run("[FullTextSearch] IF query is blank, RETURN InvalidArgument error", func(t *testing.T) {
+		serv := &movieServer{&mock.MovieUsecase{}}
+
+		_, err := serv.FullTextSearch(todoContext, &FullTextSearchRequest{Query: "  "})
+		if assert.Error(t, err) {
+			assert.Equal(t, missingQueryError, err)
+			st, _ := status.FromError(err)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+		}
+	})
+
+	t.Run("[FullTextSearch] nothing matches", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("FullTextSearch", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.FullTextResult{}, nil)
+
+		serv := &movieServer{movieUsecaseMock}
+
+		_, err := serv.FullTextSearch(todoContext, &FullTextSearchRequest{Query: "heist"})
+		assert.Equal(t, movieNotFoundError, err)
+	})
+
+	t.Run("[FullTextSearch] page defaults to 1, filters passed on", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		result := &model.FullTextResult{
+			Hits:  []model.FullTextHit{{Movie: model.SearchDetail{Title: "Heat", Year: "1995", ImdbID: "tt0113277", Type: "movie", Poster: "N/A"}, Score: 4.2, Fields: []string{"actors", "plot"}}},
+			Total: 1,
+		}
+		movieUsecaseMock.On("FullTextSearch", testify.Anything, "heist al pacino", uint32(1), model.SearchFilter{Type: "movie", Year: 1995}).Return(result, nil)
+
+		serv := &movieServer{movieUsecaseMock}
+
+		resp, err := serv.FullTextSearch(todoContext, &FullTextSearchRequest{Query: "heist al pacino", Type: "movie", Year: 1995})
+		if assert.Nil(t, err) {
+			assert.Equal(t, int32(1), resp.Total)
+			assert.Equal(t, &Search{Title: "Heat", Year: "1995", ImdbId: "tt0113277", Type: "movie", Poster: "N/A"}, resp.Hits[0].Movie)
+			assert.Equal(t, 4.2, resp.Hits[0].Score)
+			assert.Equal(t, []string{"actors", "plot"}, resp.Hits[0].Fields)
+		}
+	})
+}
+
+// autocompleteStream is a stream of requests, answered into resps
+type autocompleteStream struct {
+	grpc.ServerStream
+	reqs  []*AutocompleteRequest
+	resps []*AutocompleteResponse
+}
+
+func (stream *autocompleteStream) Context() context.Context {
+	return todoContext
+}
+
+func (stream *autocompleteStream) Recv() (*AutocompleteRequest, error) {
+	if len(stream.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := stream.reqs[0]
+	stream.reqs = stream.reqs[1:]
+	return req, nil
+}
+
+func (stream *autocompleteStream) Send(resp *AutocompleteResponse) error {
+	stream.resps = append(stream.resps, resp)
+	return nil
+}
+
+func TestAutocomplete(t *testing.T) {
+	ironMan := []model.Completion{
+		{Title: "Iron Man", ImdbID: "tt0371746", Year: "2008", Type: "movie", Popularity: 4.8},
+		{Title: "iron man 4", Popularity: 1.2},
+	}
+	completing := func() *mock.MovieUsecase {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("Autocomplete", testify.Anything, "iron m", 5).Return(ironMan, nil)
+		movieUsecaseMock.On("Autocomplete", testify.Anything, testify.Anything, testify.Anything).Return([]model.Completion{}, nil)
+		return movieUsecaseMock
+	}
+
+	t.Run("[Autocomplete] IF prefix is blank, RETURN InvalidArgument error", func(t *testing.T) {
+		serv := &movieServer{&mock.MovieUsecase{}}
+
+		_, err := serv.Autocomplete(todoContext, &AutocompleteRequest{Prefix: " "})
+		if assert.Error(t, err) {
+			assert.Equal(t, missingPrefixError, err)
+			st, _ := status.FromError(err)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+		}
+	})
+
+	t.Run("[Autocomplete] completions, most popular first", func(t *testing.T) {
+		serv := &movieServer{completing()}
+
+		resp, err := serv.Autocomplete(todoContext, &AutocompleteRequest{Prefix: "iron m", Limit: 5})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "iron m", resp.Prefix)
+			assert.Equal(t, []*Completion{
+				{Title: "Iron Man", ImdbId: "tt0371746", Year: "2008", Type: "movie"},
+				{Title: "iron man 4"},
+			}, resp.Completions)
+		}
+	})
+
+	t.Run("[Autocomplete] nothing to complete is no error", func(t *testing.T) {
+		serv := &movieServer{completing()}
+
+		resp, err := serv.Autocomplete(todoContext, &AutocompleteRequest{Prefix: "zzz"})
+		if assert.Nil(t, err) {
+			assert.Empty(t, resp.Completions)
+		}
+	})
+
+	t.Run("[AutocompleteStream] every request answered in order until the client is done", func(t *testing.T) {
+		stream := &autocompleteStream{reqs: []*AutocompleteRequest{{Prefix: "iron m", Limit: 5}, {Prefix: "zzz"}}}
+
+		err := (&movieServer{completing()}).AutocompleteStream(stream)
+		if assert.Nil(t, err) && assert.Len(t, stream.resps, 2) {
+			assert.Equal(t, "iron m", stream.resps[0].Prefix)
+			assert.Len(t, stream.resps[0].Completions, 2)
+			assert.Equal(t, "zzz", stream.resps[1].Prefix)
+			assert.Empty(t, stream.resps[1].Completions)
+		}
+	})
+
+	t.Run("[AutocompleteStream] a blank prefix ends the stream", func(t *testing.T) {
+		stream := &autocompleteStream{reqs: []*AutocompleteRequest{{Prefix: "iron m", Limit: 5}, {Prefix: ""}, {Prefix: "zzz"}}}
+
+		err := (&movieServer{completing()}).AutocompleteStream(stream)
+		assert.Equal(t, missingPrefixError, err)
+		assert.Len(t, stream.resps, 1)
+	})
+}
+
+func TestGetSimilarMovies(t *testing.T) {
+	similar := []model.SimilarMovie{
+		{Movie: model.SearchDetail{Title: "Batman Begins", Year: "2005", ImdbID: "tt0372784", Type: "movie", Poster: "N/A"}, Score: 8.3, Shared: []string{"genres", "director"}},
+	}
+
+	t.Run("[GetSimilarMovies] IF id is malformed, RETURN InvalidArgument error", func(t *testing.T) {
+		serv := &movieServer{&mock.MovieUsecase{}}
+
+		_, err := serv.GetSimilarMovies(todoContext, &GetSimilarMoviesRequest{Id: "0468569"})
+		assert.Equal(t, incorrectImdbIDError, err)
+	})
+
+	t.Run("[GetSimilarMovies] similar movies, the limit defaulted and capped", func(t *testing.T) {
+		for limit, expected := range map[int32]int{0: defaultSimilarLimit, 5: 5, 500: maxSimilarLimit} {
+			movieUsecaseMock := &mock.MovieUsecase{}
+			movieUsecaseMock.On("SimilarMovies", testify.Anything, "tt0468569", expected, true).Return(similar, nil)
+			serv := &movieServer{movieUsecaseMock}
+
+			resp, err := serv.GetSimilarMovies(todoContext, &GetSimilarMoviesRequest{Id: "tt0468569", Limit: limit, ExcludeWatchlisted: true})
+			if assert.Nil(t, err) {
+				assert.Equal(t, []*SimilarMovie{{
+					Movie:  &Search{Title: "Batman Begins", Year: "2005", ImdbId: "tt0372784", Type: "movie", Poster: "N/A"},
+					Score:  8.3,
+					Shared: []string{"genres", "director"},
+				}}, resp.Movies)
+			}
+		}
+	})
+
+	t.Run("[GetSimilarMovies] errors of the usecase", func(t *testing.T) {
+		for usecaseErr, code := range map[error]codes.Code{
+			model.ErrMovieNotFound:   codes.NotFound,
+			model.ErrUnauthenticated: codes.Unauthenticated,
+			model.ErrUpstream:        codes.Unavailable,
+		} {
+			movieUsecaseMock := &mock.MovieUsecase{}
+			movieUsecaseMock.On("SimilarMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(nil, usecaseErr)
+			serv := &movieServer{movieUsecaseMock}
+
+			_, err := serv.GetSimilarMovies(todoContext, &GetSimilarMoviesRequest{Id: "tt0468569"})
+			st, _ := status.FromError(err)
+			assert.Equal(t, code, st.Code(), usecaseErr.Error())
+		}
+	})
+}
+
 func TestGetMovieDetail(t *testing.T) {
 	t.Run("[GetMovieDetail] malformed imdb id", func(t *testing.T) {
 		testCases := []string{
@@ -131,7 +463,7 @@ func TestGetMovieDetail(t *testing.T) {
 		errMsg := "Oops, something happened"
 
 		movieUsecaseMock := &mock.MovieUsecase{}
-		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything).Return(&model.MovieDetail{}, errors.New(errMsg))
+		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(&model.MovieDetail{}, errors.New(errMsg))
 
 		serv := &movieServer{movieUsecaseMock}
 		req := &GetMovieDetailRequest{Id: "tt1234567"}
@@ -145,7 +477,7 @@ func TestGetMovieDetail(t *testing.T) {
 
 	t.Run("[GetMovieDetail] detail has an error", func(t *testing.T) {
 		movieUsecaseMock := &mock.MovieUsecase{}
-		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything).Return(&model.MovieDetail{Error: "error"}, nil)
+		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(&model.MovieDetail{Error: "error"}, nil)
 
 		serv := &movieServer{movieUsecaseMock}
 		req := &GetMovieDetailRequest{Id: "tt1234567"}
@@ -161,10 +493,10 @@ func TestGetMovieDetail(t *testing.T) {
 		movieDetailResult := &model.MovieDetail{
 			"title", "year", "rated", "released", "runtime", "genre", "director", "writer", "actors",
 			"plot", "language", "country", "awards", "poster", []model.MovieRating{{"source", "value"}}, "metascore",
-			"imdbrating", "imdbvotes", "imdbid", "type", "dvd", "boxoffice", "production", "website", "true", "",
+			"imdbrating", "imdbvotes", "imdbid", "type", "dvd", "boxoffice", "production", "website", "seriesid", "season", "episode", "true", "", nil,
 		}
 
-		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything).Return(movieDetailResult, nil)
+		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(movieDetailResult, nil)
 
 		serv := &movieServer{movieUsecaseMock}
 		req := &GetMovieDetailRequest{Id: "tt1234567"}
@@ -173,3 +505,29 @@ func TestGetMovieDetail(t *testing.T) {
 		assert.Equal(t, serv.convertMovieDetailToRPCResponse(movieDetailResult), actualResult)
 	})
 }
+
+func TestAPIKeyRedaction(t *testing.T) {
+	upstreamErr := errors.New(`Get "http://www.omdbapi.com/?apikey=s3cr3tKey&s=ironman": dial tcp: i/o timeout`)
+
+	t.Run("[SearchMovie] api key is not in the status message", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(nil, upstreamErr)
+
+		serv := &movieServer{movieUsecaseMock}
+		_, err := serv.SearchMovie(todoContext, &SearchMovieRequest{Searchword: "ironman"})
+		if assert.Error(t, err) {
+			assert.NotContains(t, err.Error(), "s3cr3tKey")
+		}
+	})
+
+	t.Run("[GetMovieDetail] api key is not in the status message", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(nil, upstreamErr)
+
+		serv := &movieServer{movieUsecaseMock}
+		_, err := serv.GetMovieDetail(todoContext, &GetMovieDetailRequest{Id: "tt0371746"})
+		if assert.Error(t, err) {
+			assert.NotContains(t, err.Error(), "s3cr3tKey")
+		}
+	})
+}
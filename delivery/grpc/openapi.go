@@ -0,0 +1,9 @@
+package grpc
+
+import _ "embed"
+
+// OpenAPISpec is the OpenAPI v2 document of the REST gateway, generated from movie.proto
+// by protoc-gen-openapiv2 (see generatepb.sh)
+//
+//go:embed movie.swagger.json
+var OpenAPISpec []byte
@@ -0,0 +1,96 @@
+package grpc
+
+import (
+	context "context"
+	"time"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+type reviewServer struct {
+	ReviewUsecase model.ReviewUsecase
+}
+
+func NewReviewServer(usecase model.ReviewUsecase) ReviewServer {
+	return &reviewServer{
+		ReviewUsecase: usecase,
+	}
+}
+
+func (serv *reviewServer) SubmitReview(ctx context.Context, req *SubmitReviewRequest) (resp *ReviewResponse, err error) {
+	err = validateImdbID(req.ImdbId)
+	if err != nil {
+		return resp, err
+	}
+
+	review, err := serv.ReviewUsecase.SubmitReview(ctx, req.ImdbId, int(req.Rating), req.Text)
+	if err != nil {
+		return resp, reviewError(err)
+	}
+
+	return convertReviewToRPCResponse(review), nil
+}
+
+func (serv *reviewServer) DeleteReview(ctx context.Context, req *DeleteReviewRequest) (resp *DeleteReviewResponse, err error) {
+	err = serv.ReviewUsecase.DeleteReview(ctx, req.ImdbId)
+	if err != nil {
+		return resp, reviewError(err)
+	}
+
+	return &DeleteReviewResponse{}, nil
+}
+
+func (serv *reviewServer) ListReviews(ctx context.Context, req *ListReviewsRequest) (resp *ListReviewsResponse, err error) {
+	err = validateImdbID(req.ImdbId)
+	if err != nil {
+		return resp, err
+	}
+
+	page, err := serv.ReviewUsecase.Reviews(ctx, req.ImdbId, int(req.Page), int(req.PageSize), req.IncludeHidden)
+	if err != nil {
+		return resp, reviewError(err)
+	}
+
+	resp = &ListReviewsResponse{
+		Total: int32(page.Total),
+		Votes: int32(page.Votes),
+	}
+	if page.Votes > 0 {
+		rating := model.CommunityRating(page.Average)
+		resp.CommunityRating = &Rating{Source: rating.Source, Value: rating.Value}
+	}
+	for _, review := range page.Reviews {
+		resp.Reviews = append(resp.Reviews, convertReviewToRPCResponse(review))
+	}
+
+	return resp, nil
+}
+
+func (serv *reviewServer) ModerateReview(ctx context.Context, req *ModerateReviewRequest) (resp *ReviewResponse, err error) {
+	review, err := serv.ReviewUsecase.ModerateReview(ctx, req.ReviewId, req.State, req.Note)
+	if err != nil {
+		return resp, reviewError(err)
+	}
+
+	return convertReviewToRPCResponse(review), nil
+}
+
+func convertReviewToRPCResponse(review *model.Review) (r *ReviewResponse) {
+	r = &ReviewResponse{
+		Id:             review.ID,
+		ImdbId:         review.ImdbID,
+		Author:         review.Author,
+		Rating:         int32(review.Rating),
+		Text:           review.Text,
+		CreatedAt:      review.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:      review.UpdatedAt.UTC().Format(time.RFC3339),
+		State:          review.State,
+		ModeratedBy:    review.ModeratedBy,
+		ModerationNote: review.ModerationNote,
+	}
+	if !review.ModeratedAt.IsZero() {
+		r.ModeratedAt = review.ModeratedAt.UTC().Format(time.RFC3339)
+	}
+
+	return r
+}
@@ -0,0 +1,145 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	mock "github.com/zenkobert/sbtest-2/domain/mocks"
+	"google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+var suitReview = &model.Review{
+	ID:        "review-1",
+	ImdbID:    "tt0371746",
+	Author:    "user-1",
+	Rating:    8,
+	Text:      "The suit steals the show",
+	CreatedAt: time.Date(2021, 9, 3, 16, 20, 0, 0, time.UTC),
+	UpdatedAt: time.Date(2021, 9, 3, 23, 21, 42, 0, time.FixedZone("WIB", 7*3600)),
+	State:     model.ReviewPublished,
+}
+
+func TestNewReviewServer(t *testing.T) {
+	t.Run("[NewReviewServer]", func(t *testing.T) {
+		usecase := &mock.ReviewUsecase{}
+
+		actual := NewReviewServer(usecase)
+		assert.Equal(t, usecase, actual.(*reviewServer).ReviewUsecase)
+	})
+}
+
+func TestConvertReviewToRPCResponse(t *testing.T) {
+	t.Run("[convertReviewToRPCResponse] times in UTC, moderation once moderated", func(t *testing.T) {
+		resp := convertReviewToRPCResponse(suitReview)
+		assert.Equal(t, "review-1", resp.Id)
+		assert.Equal(t, "tt0371746", resp.ImdbId)
+		assert.Equal(t, "user-1", resp.Author)
+		assert.Equal(t, int32(8), resp.Rating)
+		assert.Equal(t, "The suit steals the show", resp.Text)
+		assert.Equal(t, "2021-09-03T16:20:00Z", resp.CreatedAt)
+		assert.Equal(t, "2021-09-03T16:21:42Z", resp.UpdatedAt)
+		assert.Equal(t, "published", resp.State)
+		assert.Empty(t, resp.ModeratedAt)
+
+		moderated := *suitReview
+		moderated.State = model.ReviewHidden
+		moderated.ModeratedBy = "moderator"
+		moderated.ModeratedAt = time.Date(2021, 9, 4, 8, 0, 0, 0, time.UTC)
+		moderated.ModerationNote = "spoilers"
+		resp = convertReviewToRPCResponse(&moderated)
+		assert.Equal(t, "hidden", resp.State)
+		assert.Equal(t, "moderator", resp.ModeratedBy)
+		assert.Equal(t, "2021-09-04T08:00:00Z", resp.ModeratedAt)
+		assert.Equal(t, "spoilers", resp.ModerationNote)
+	})
+}
+
+func TestReviewServer(t *testing.T) {
+	t.Run("[SubmitReview]", func(t *testing.T) {
+		usecase := &mock.ReviewUsecase{}
+		usecase.On("SubmitReview", testify.Anything, "tt0371746", 8, "The suit steals the show").Return(suitReview, nil)
+
+		resp, err := NewReviewServer(usecase).SubmitReview(todoContext, &SubmitReviewRequest{ImdbId: "tt0371746", Rating: 8, Text: "The suit steals the show"})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "review-1", resp.Id)
+		}
+	})
+
+	t.Run("[SubmitReview] malformed IMDb ID is not reviewed", func(t *testing.T) {
+		usecase := &mock.ReviewUsecase{}
+
+		_, err := NewReviewServer(usecase).SubmitReview(todoContext, &SubmitReviewRequest{ImdbId: "iron man", Rating: 8})
+		reason, _ := reasonOf(t, err)
+		assert.Equal(t, ReasonInvalidImdbID, reason)
+		usecase.AssertNotCalled(t, "SubmitReview", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("[SubmitReview] invalid rating", func(t *testing.T) {
+		usecase := &mock.ReviewUsecase{}
+		usecase.On("SubmitReview", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(nil, model.ErrInvalidRating)
+
+		_, err := NewReviewServer(usecase).SubmitReview(todoContext, &SubmitReviewRequest{ImdbId: "tt0371746", Rating: 11})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("[DeleteReview] review not found", func(t *testing.T) {
+		usecase := &mock.ReviewUsecase{}
+		usecase.On("DeleteReview", testify.Anything, "tt0371746").Return(model.ErrReviewNotFound)
+
+		_, err := NewReviewServer(usecase).DeleteReview(todoContext, &DeleteReviewRequest{ImdbId: "tt0371746"})
+		reason, _ := reasonOf(t, err)
+		assert.Equal(t, ReasonReviewNotFound, reason)
+	})
+
+	t.Run("[ListReviews] page with the community rating", func(t *testing.T) {
+		usecase := &mock.ReviewUsecase{}
+		usecase.On("Reviews", testify.Anything, "tt0371746", 2, 1, false).Return(&model.ReviewPage{
+			Reviews: []*model.Review{suitReview},
+			Total:   3,
+			Votes:   2,
+			Average: 7.25,
+		}, nil)
+
+		resp, err := NewReviewServer(usecase).ListReviews(todoContext, &ListReviewsRequest{ImdbId: "tt0371746", Page: 2, PageSize: 1})
+		if assert.Nil(t, err) && assert.Len(t, resp.Reviews, 1) {
+			assert.Equal(t, "review-1", resp.Reviews[0].Id)
+			assert.Equal(t, int32(3), resp.Total)
+			assert.Equal(t, int32(2), resp.Votes)
+			assert.Equal(t, "Community", resp.CommunityRating.Source)
+			assert.Equal(t, "7.2/10", resp.CommunityRating.Value)
+		}
+	})
+
+	t.Run("[ListReviews] no community rating without votes", func(t *testing.T) {
+		usecase := &mock.ReviewUsecase{}
+		usecase.On("Reviews", testify.Anything, "tt0371746", 0, 0, false).Return(&model.ReviewPage{Reviews: []*model.Review{}}, nil)
+
+		resp, err := NewReviewServer(usecase).ListReviews(todoContext, &ListReviewsRequest{ImdbId: "tt0371746"})
+		if assert.Nil(t, err) {
+			assert.Empty(t, resp.Reviews)
+			assert.Nil(t, resp.CommunityRating)
+		}
+	})
+
+	t.Run("[ListReviews] hidden reviews for moderators only", func(t *testing.T) {
+		usecase := &mock.ReviewUsecase{}
+		usecase.On("Reviews", testify.Anything, "tt0371746", 0, 0, true).Return(nil, model.ErrPermissionDenied)
+
+		_, err := NewReviewServer(usecase).ListReviews(todoContext, &ListReviewsRequest{ImdbId: "tt0371746", IncludeHidden: true})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("[ModerateReview]", func(t *testing.T) {
+		usecase := &mock.ReviewUsecase{}
+		usecase.On("ModerateReview", testify.Anything, "review-1", "hidden", "spoilers").Return(suitReview, nil)
+
+		resp, err := NewReviewServer(usecase).ModerateReview(todoContext, &ModerateReviewRequest{ReviewId: "review-1", State: "hidden", Note: "spoilers"})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "review-1", resp.Id)
+		}
+	})
+}
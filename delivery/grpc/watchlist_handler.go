@@ -0,0 +1,178 @@
+package grpc
+
+import (
+	context "context"
+	"time"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+type watchlistServer struct {
+	WatchlistUsecase model.WatchlistUsecase
+	ImportUsecase    model.ImportUsecase
+
+	now func() time.Time
+}
+
+func NewWatchlistServer(usecase model.WatchlistUsecase, importUsecase model.ImportUsecase) WatchlistServer {
+	return &watchlistServer{
+		WatchlistUsecase: usecase,
+		ImportUsecase:    importUsecase,
+		now:              time.Now,
+	}
+}
+
+func (serv *watchlistServer) CreateWatchlist(ctx context.Context, req *CreateWatchlistRequest) (resp *WatchlistResponse, err error) {
+	list, err := serv.WatchlistUsecase.CreateWatchlist(ctx, req.Name)
+	if err != nil {
+		return resp, watchlistError(err)
+	}
+
+	return convertWatchlistToRPCResponse(list), nil
+}
+
+func (serv *watchlistServer) ListWatchlists(ctx context.Context, req *ListWatchlistsRequest) (resp *ListWatchlistsResponse, err error) {
+	lists, err := serv.WatchlistUsecase.Watchlists(ctx)
+	if err != nil {
+		return resp, watchlistError(err)
+	}
+
+	resp = &ListWatchlistsResponse{}
+	for _, list := range lists {
+		resp.Watchlists = append(resp.Watchlists, convertWatchlistToRPCResponse(list))
+	}
+
+	return resp, nil
+}
+
+func (serv *watchlistServer) ListItems(ctx context.Context, req *ListItemsRequest) (resp *WatchlistResponse, err error) {
+	list, err := serv.WatchlistUsecase.Items(ctx, req.WatchlistId)
+	if err != nil {
+		return resp, watchlistError(err)
+	}
+
+	return convertWatchlistToRPCResponse(list), nil
+}
+
+func (serv *watchlistServer) AddItem(ctx context.Context, req *AddItemRequest) (resp *WatchlistResponse, err error) {
+	err = validateImdbID(req.ImdbId)
+	if err != nil {
+		return resp, err
+	}
+
+	list, err := serv.WatchlistUsecase.AddItem(ctx, req.WatchlistId, req.ImdbId)
+	if err != nil {
+		return resp, watchlistError(err)
+	}
+
+	return convertWatchlistToRPCResponse(list), nil
+}
+
+func (serv *watchlistServer) RemoveItem(ctx context.Context, req *RemoveItemRequest) (resp *WatchlistResponse, err error) {
+	list, err := serv.WatchlistUsecase.RemoveItem(ctx, req.WatchlistId, req.ImdbId)
+	if err != nil {
+		return resp, watchlistError(err)
+	}
+
+	return convertWatchlistToRPCResponse(list), nil
+}
+
+func (serv *watchlistServer) ReorderItems(ctx context.Context, req *ReorderItemsRequest) (resp *WatchlistResponse, err error) {
+	list, err := serv.WatchlistUsecase.ReorderItems(ctx, req.WatchlistId, req.ImdbIds)
+	if err != nil {
+		return resp, watchlistError(err)
+	}
+
+	return convertWatchlistToRPCResponse(list), nil
+}
+
+func (serv *watchlistServer) MarkWatched(ctx context.Context, req *MarkWatchedRequest) (resp *WatchlistResponse, err error) {
+	watchedOn := req.WatchedOn
+	switch {
+	case req.Unwatched:
+		watchedOn = ""
+	case watchedOn == "":
+		watchedOn = serv.now().UTC().Format(model.WatchedOnLayout)
+	}
+
+	list, err := serv.WatchlistUsecase.MarkWatched(ctx, req.WatchlistId, req.ImdbId, watchedOn)
+	if err != nil {
+		return resp, watchlistError(err)
+	}
+
+	return convertWatchlistToRPCResponse(list), nil
+}
+
+func (serv *watchlistServer) ImportHistory(ctx context.Context, req *ImportHistoryRequest) (resp *ImportHistoryResponse, err error) {
+	result, err := serv.ImportUsecase.ImportHistory(ctx, model.ImportRequest{
+		Format:        req.Format,
+		Content:       req.Content,
+		Unwatched:     req.Unwatched,
+		WatchlistID:   req.WatchlistId,
+		WatchlistName: req.WatchlistName,
+	})
+	if err != nil {
+		return resp, watchlistError(err)
+	}
+
+	return convertImportResultToRPCResponse(result), nil
+}
+
+func convertWatchlistToRPCResponse(list *model.Watchlist) (r *WatchlistResponse) {
+	r = &WatchlistResponse{
+		Id:        list.ID,
+		Name:      list.Name,
+		CreatedAt: list.CreatedAt.UTC().Format(time.RFC3339),
+	}
+
+	for _, item := range list.Items {
+		rpcItem := &WatchlistItem{
+			ImdbId:    item.ImdbID,
+			AddedAt:   item.AddedAt.UTC().Format(time.RFC3339),
+			WatchedOn: item.WatchedOn,
+		}
+		if item.Movie != nil {
+			rpcItem.Movie = (&movieServer{}).convertMovieDetailToRPCResponse(item.Movie)
+		}
+		r.Items = append(r.Items, rpcItem)
+	}
+
+	return r
+}
+
+func convertImportResultToRPCResponse(result *model.ImportResult) *ImportHistoryResponse {
+	return &ImportHistoryResponse{
+		WatchlistId: result.WatchlistID,
+		Rows:        int32(result.Rows),
+		Added:       int32(result.Added),
+		Watched:     int32(result.Watched),
+		Rated:       int32(result.Rated),
+		Unmatched:   convertImportIssues(result.Unmatched),
+		Ambiguous:   convertImportIssues(result.Ambiguous),
+		Failed:      convertImportIssues(result.Failed),
+	}
+}
+
+func convertImportIssues(issues []model.ImportIssue) (r []*ImportIssue) {
+	for _, issue := range issues {
+		rpcIssue := &ImportIssue{
+			Row:    int32(issue.Row.Number),
+			Title:  issue.Row.Title,
+			Year:   int32(issue.Row.Year),
+			ImdbId: issue.Row.ImdbID,
+			Reason: issue.Reason,
+		}
+		for _, candidate := range issue.Candidates {
+			rpcIssue.Candidates = append(rpcIssue.Candidates, &Search{
+				Title:  candidate.Title,
+				Year:   candidate.Year,
+				ImdbId: candidate.ImdbID,
+				Type:   candidate.Type,
+				Poster: candidate.Poster,
+			})
+		}
+		r = append(r, rpcIssue)
+	}
+
+	return r
+}
@@ -0,0 +1,216 @@
+package grpc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	mock "github.com/zenkobert/sbtest-2/domain/mocks"
+	"google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+var weekendList = &model.Watchlist{
+	ID:        "list-1",
+	Owner:     "user-1",
+	Name:      "Weekend",
+	CreatedAt: time.Date(2021, 9, 3, 16, 20, 0, 0, time.UTC),
+	Items: []model.WatchlistItem{
+		{
+			ImdbID:    "tt0371746",
+			AddedAt:   time.Date(2021, 9, 3, 16, 21, 42, 0, time.FixedZone("WIB", 7*3600)),
+			WatchedOn: "2021-09-04",
+			Movie:     &model.MovieDetail{Title: "Iron Man", ImdbID: "tt0371746", Ratings: []model.MovieRating{{Source: "Internet Movie Database", Value: "7.9/10"}}},
+		},
+		{ImdbID: "tt1228705", AddedAt: time.Date(2021, 9, 3, 16, 22, 0, 0, time.UTC)},
+	},
+}
+
+func newTestWatchlistServer(usecase model.WatchlistUsecase) *watchlistServer {
+	serv := NewWatchlistServer(usecase, &mock.ImportUsecase{}).(*watchlistServer)
+	serv.now = func() time.Time { return time.Date(2021, 9, 4, 23, 30, 0, 0, time.FixedZone("WIB", 7*3600)) }
+
+	return serv
+}
+
+func TestNewWatchlistServer(t *testing.T) {
+	t.Run("[NewWatchlistServer]", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+		importUsecase := &mock.ImportUsecase{}
+
+		actual := NewWatchlistServer(usecase, importUsecase)
+		assert.Equal(t, usecase, actual.(*watchlistServer).WatchlistUsecase)
+		assert.Equal(t, importUsecase, actual.(*watchlistServer).ImportUsecase)
+	})
+}
+
+func TestConvertWatchlistToRPCResponse(t *testing.T) {
+	t.Run("[convertWatchlistToRPCResponse] times in UTC, movie details when known", func(t *testing.T) {
+		resp := convertWatchlistToRPCResponse(weekendList)
+		assert.Equal(t, "list-1", resp.Id)
+		assert.Equal(t, "Weekend", resp.Name)
+		assert.Equal(t, "2021-09-03T16:20:00Z", resp.CreatedAt)
+		if assert.Len(t, resp.Items, 2) {
+			assert.Equal(t, "tt0371746", resp.Items[0].ImdbId)
+			assert.Equal(t, "2021-09-03T09:21:42Z", resp.Items[0].AddedAt)
+			assert.Equal(t, "2021-09-04", resp.Items[0].WatchedOn)
+			assert.Equal(t, "Iron Man", resp.Items[0].Movie.Title)
+			assert.Equal(t, "7.9/10", resp.Items[0].Movie.Ratings[0].Value)
+			assert.Nil(t, resp.Items[1].Movie)
+		}
+	})
+}
+
+func TestWatchlistServer(t *testing.T) {
+	t.Run("[CreateWatchlist]", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("CreateWatchlist", testify.Anything, "Weekend").Return(weekendList, nil)
+
+		resp, err := newTestWatchlistServer(usecase).CreateWatchlist(todoContext, &CreateWatchlistRequest{Name: "Weekend"})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "list-1", resp.Id)
+		}
+	})
+
+	t.Run("[ListWatchlists] unauthenticated", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("Watchlists", testify.Anything).Return(nil, model.ErrUnauthenticated)
+
+		_, err := newTestWatchlistServer(usecase).ListWatchlists(todoContext, &ListWatchlistsRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("[ListWatchlists]", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("Watchlists", testify.Anything).Return([]*model.Watchlist{weekendList, {ID: "list-2"}}, nil)
+
+		resp, err := newTestWatchlistServer(usecase).ListWatchlists(todoContext, &ListWatchlistsRequest{})
+		if assert.Nil(t, err) && assert.Len(t, resp.Watchlists, 2) {
+			assert.Equal(t, "list-1", resp.Watchlists[0].Id)
+			assert.Equal(t, "list-2", resp.Watchlists[1].Id)
+		}
+	})
+
+	t.Run("[ListItems] watchlist not found", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("Items", testify.Anything, "list-3").Return(nil, model.ErrWatchlistNotFound)
+
+		_, err := newTestWatchlistServer(usecase).ListItems(todoContext, &ListItemsRequest{WatchlistId: "list-3"})
+		reason, _ := reasonOf(t, err)
+		assert.Equal(t, ReasonWatchlistNotFound, reason)
+	})
+
+	t.Run("[AddItem] malformed IMDb ID is not looked up", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+
+		_, err := newTestWatchlistServer(usecase).AddItem(todoContext, &AddItemRequest{WatchlistId: "list-1", ImdbId: "iron man"})
+		reason, _ := reasonOf(t, err)
+		assert.Equal(t, ReasonInvalidImdbID, reason)
+		usecase.AssertNotCalled(t, "AddItem", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("[AddItem]", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("AddItem", testify.Anything, "list-1", "tt1228705").Return(weekendList, nil)
+
+		resp, err := newTestWatchlistServer(usecase).AddItem(todoContext, &AddItemRequest{WatchlistId: "list-1", ImdbId: "tt1228705"})
+		if assert.Nil(t, err) {
+			assert.Len(t, resp.Items, 2)
+		}
+	})
+
+	t.Run("[RemoveItem] movie not in the watchlist", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("RemoveItem", testify.Anything, "list-1", "tt4154756").Return(nil, model.ErrItemNotFound)
+
+		_, err := newTestWatchlistServer(usecase).RemoveItem(todoContext, &RemoveItemRequest{WatchlistId: "list-1", ImdbId: "tt4154756"})
+		reason, _ := reasonOf(t, err)
+		assert.Equal(t, ReasonItemNotFound, reason)
+	})
+
+	t.Run("[ReorderItems]", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("ReorderItems", testify.Anything, "list-1", []string{"tt1228705", "tt0371746"}).Return(weekendList, nil)
+
+		_, err := newTestWatchlistServer(usecase).ReorderItems(todoContext, &ReorderItemsRequest{WatchlistId: "list-1", ImdbIds: []string{"tt1228705", "tt0371746"}})
+		assert.Nil(t, err)
+	})
+
+	t.Run("[MarkWatched] today in UTC unless a day is given", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("MarkWatched", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(weekendList, nil)
+		serv := newTestWatchlistServer(usecase)
+
+		serv.MarkWatched(todoContext, &MarkWatchedRequest{WatchlistId: "list-1", ImdbId: "tt0371746"})
+		usecase.AssertCalled(t, "MarkWatched", testify.Anything, "list-1", "tt0371746", "2021-09-04")
+
+		serv.MarkWatched(todoContext, &MarkWatchedRequest{WatchlistId: "list-1", ImdbId: "tt0371746", WatchedOn: "2021-08-31"})
+		usecase.AssertCalled(t, "MarkWatched", testify.Anything, "list-1", "tt0371746", "2021-08-31")
+	})
+
+	t.Run("[MarkWatched] unwatched clears the day", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("MarkWatched", testify.Anything, "list-1", "tt0371746", "").Return(weekendList, nil)
+
+		_, err := newTestWatchlistServer(usecase).MarkWatched(todoContext, &MarkWatchedRequest{WatchlistId: "list-1", ImdbId: "tt0371746", WatchedOn: "2021-08-31", Unwatched: true})
+		assert.Nil(t, err)
+	})
+
+	t.Run("[MarkWatched] invalid date", func(t *testing.T) {
+		usecase := &mock.WatchlistUsecase{}
+		usecase.On("MarkWatched", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(nil, model.ErrInvalidDate)
+
+		_, err := newTestWatchlistServer(usecase).MarkWatched(todoContext, &MarkWatchedRequest{WatchlistId: "list-1", ImdbId: "tt0371746", WatchedOn: "yesterday"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestImportHistory(t *testing.T) {
+	t.Run("[ImportHistory] issues with their rows and candidates", func(t *testing.T) {
+		importUsecase := &mock.ImportUsecase{}
+		importUsecase.On("ImportHistory", testify.Anything, model.ImportRequest{Format: model.ImportIMDb, Content: []byte("Const\n"), WatchlistName: "Mine"}).Return(&model.ImportResult{
+			WatchlistID: "list-2",
+			Rows:        3,
+			Added:       1,
+			Rated:       1,
+			Ambiguous: []model.ImportIssue{{
+				Row:        model.ImportRow{Number: 2, Title: "Crash"},
+				Reason:     "several movies match the title",
+				Candidates: []model.SearchDetail{{Title: "Crash", Year: "2004", ImdbID: "tt0375679", Type: "movie"}},
+			}},
+			Failed: []model.ImportIssue{{Row: model.ImportRow{Number: 3, Title: "Lost", Year: 2010, ImdbID: "tt0000000"}, Reason: "unknown IMDb ID"}},
+		}, nil)
+		serv := NewWatchlistServer(&mock.WatchlistUsecase{}, importUsecase)
+
+		resp, err := serv.ImportHistory(todoContext, &ImportHistoryRequest{Format: model.ImportIMDb, Content: []byte("Const\n"), WatchlistName: "Mine"})
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Equal(t, "list-2", resp.WatchlistId)
+		assert.Equal(t, int32(3), resp.Rows)
+		assert.Equal(t, int32(1), resp.Added)
+		assert.Empty(t, resp.Unmatched)
+		if assert.Len(t, resp.Ambiguous, 1) && assert.Len(t, resp.Ambiguous[0].Candidates, 1) {
+			assert.Equal(t, int32(2), resp.Ambiguous[0].Row)
+			assert.Equal(t, "2004", resp.Ambiguous[0].Candidates[0].Year)
+			assert.Equal(t, "tt0375679", resp.Ambiguous[0].Candidates[0].ImdbId)
+		}
+		if assert.Len(t, resp.Failed, 1) {
+			assert.Equal(t, int32(2010), resp.Failed[0].Year)
+			assert.Equal(t, "unknown IMDb ID", resp.Failed[0].Reason)
+		}
+	})
+
+	t.Run("[ImportHistory] invalid CSV", func(t *testing.T) {
+		importUsecase := &mock.ImportUsecase{}
+		importUsecase.On("ImportHistory", testify.Anything, testify.Anything).Return(nil, fmt.Errorf("%w: no Letterboxd or IMDb columns", model.ErrInvalidImport))
+
+		_, err := NewWatchlistServer(&mock.WatchlistUsecase{}, importUsecase).ImportHistory(todoContext, &ImportHistoryRequest{})
+		reason, _ := reasonOf(t, err)
+		assert.Equal(t, ReasonInvalidImport, reason)
+		assert.Contains(t, status.Convert(err).Message(), "no Letterboxd or IMDb columns")
+	})
+}
@@ -0,0 +1,247 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/zenkobert/sbtest-2/common"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	serving    = "SERVING"
+	notServing = "NOT_SERVING"
+	unknown    = "UNKNOWN"
+)
+
+// Checker periodically runs the dependency checks and publishes the outcome
+// through the standard grpc.health.v1.Health service and the HTTP probes.
+// A service is SERVING only while every dependency it was registered with is healthy,
+// the overall "" service and readiness require all dependencies to be healthy but the informational ones
+type Checker struct {
+	Server *grpchealth.Server
+
+	interval time.Duration
+	timeout  time.Duration
+
+	mutex        *sync.RWMutex
+	dependencies map[string]common.HealthChecker
+	// informational dependencies are reported without gating readiness
+	informational map[string]bool
+	services      map[string][]string
+	results       map[string]error
+	shuttingDown  bool
+}
+
+type readinessResponse struct {
+	Status       string            `json:"status"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+func NewChecker(interval, timeout time.Duration) *Checker {
+	server := grpchealth.NewServer()
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return &Checker{
+		Server:        server,
+		interval:      interval,
+		timeout:       timeout,
+		mutex:         &sync.RWMutex{},
+		dependencies:  map[string]common.HealthChecker{},
+		informational: map[string]bool{},
+		services:      map[string][]string{},
+		results:       map[string]error{},
+	}
+}
+
+func (c *Checker) AddDependency(name string, dependency common.HealthChecker) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.dependencies[name] = dependency
+	c.services[name] = []string{name}
+	c.Server.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// AddInformational checks dependency and reports it like the others, but the instance stays ready while
+// it is down, e.g. one of several movie providers when the others still answer
+func (c *Checker) AddInformational(name string, dependency common.HealthChecker) {
+	c.AddDependency(name, dependency)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.informational[name] = true
+}
+
+func (c *Checker) AddService(name string, dependencies ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.services[name] = dependencies
+	c.Server.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Run checks the dependencies right away, then every interval until ctx is done
+func (c *Checker) Run(ctx context.Context) {
+	c.CheckAll(ctx)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.CheckAll(ctx)
+		}
+	}
+}
+
+func (c *Checker) CheckAll(ctx context.Context) {
+	c.mutex.RLock()
+	dependencies := make(map[string]common.HealthChecker, len(c.dependencies))
+	for name, dependency := range c.dependencies {
+		dependencies[name] = dependency
+	}
+	c.mutex.RUnlock()
+
+	results := make(map[string]error, len(dependencies))
+	resultsMutex := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	for name, dependency := range dependencies {
+		wg.Add(1)
+		go func(name string, dependency common.HealthChecker) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			err := dependency.Check(checkCtx)
+			if err != nil {
+				log.Printf("health check %s failed: %v\n", name, err)
+			}
+
+			resultsMutex.Lock()
+			results[name] = err
+			resultsMutex.Unlock()
+		}(name, dependency)
+	}
+	wg.Wait()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.results = results
+	if c.shuttingDown {
+		return
+	}
+
+	for service, serviceDependencies := range c.services {
+		c.Server.SetServingStatus(service, toServingStatus(c.healthyLocked(serviceDependencies)))
+	}
+	c.Server.SetServingStatus("", toServingStatus(c.allHealthyLocked()))
+}
+
+// Shutdown marks every service NOT_SERVING for good so load balancers drain the instance
+func (c *Checker) Shutdown() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.shuttingDown = true
+	c.Server.Shutdown()
+}
+
+func (c *Checker) Ready() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return !c.shuttingDown && c.allHealthyLocked()
+}
+
+// LivenessHandler answers /healthz, the process is alive as long as it can serve HTTP
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+}
+
+// ReadinessHandler answers /readyz with the state of each dependency
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ready := c.Ready()
+
+		resp := readinessResponse{
+			Status:       serving,
+			Dependencies: c.dependencyStatuses(),
+		}
+
+		code := http.StatusOK
+		if !ready {
+			resp.Status = notServing
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(resp)
+	})
+}
+
+func (c *Checker) dependencyStatuses() map[string]string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	statuses := make(map[string]string, len(c.dependencies))
+	for name := range c.dependencies {
+		err, checked := c.results[name]
+		switch {
+		case !checked:
+			statuses[name] = unknown
+		case err != nil:
+			statuses[name] = notServing
+		default:
+			statuses[name] = serving
+		}
+	}
+
+	return statuses
+}
+
+func (c *Checker) healthyLocked(dependencies []string) bool {
+	for _, name := range dependencies {
+		err, checked := c.results[name]
+		if !checked || err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Checker) allHealthyLocked() bool {
+	names := make([]string, 0, len(c.dependencies))
+	for name := range c.dependencies {
+		if !c.informational[name] {
+			names = append(names, name)
+		}
+	}
+
+	return c.healthyLocked(names)
+}
+
+func toServingStatus(healthy bool) healthpb.HealthCheckResponse_ServingStatus {
+	if healthy {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
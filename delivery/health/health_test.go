@@ -0,0 +1,157 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type checkFunc func(ctx context.Context) error
+
+func (f checkFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+var (
+	healthy   = checkFunc(func(context.Context) error { return nil })
+	unhealthy = checkFunc(func(context.Context) error { return errors.New("unreachable") })
+)
+
+func servingStatus(t *testing.T, c *Checker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := c.Server.Check(context.TODO(), &healthpb.HealthCheckRequest{Service: service})
+	if !assert.Nil(t, err) {
+		return healthpb.HealthCheckResponse_UNKNOWN
+	}
+
+	return resp.Status
+}
+
+func TestCheckAll(t *testing.T) {
+	t.Run("[CheckAll] not serving before the first check", func(t *testing.T) {
+		c := NewChecker(time.Minute, time.Second)
+		c.AddDependency("omdb", healthy)
+		c.AddService("movie.SearchMovie", "omdb")
+
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, c, ""))
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, c, "movie.SearchMovie"))
+		assert.False(t, c.Ready())
+	})
+
+	t.Run("[CheckAll] every dependency healthy", func(t *testing.T) {
+		c := NewChecker(time.Minute, time.Second)
+		c.AddDependency("omdb", healthy)
+		c.AddDependency("search-log", healthy)
+		c.AddService("movie.SearchMovie", "omdb", "search-log")
+		c.CheckAll(context.TODO())
+
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, c, ""))
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, c, "movie.SearchMovie"))
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, c, "omdb"))
+		assert.True(t, c.Ready())
+	})
+
+	t.Run("[CheckAll] one dependency down only affects the services using it", func(t *testing.T) {
+		c := NewChecker(time.Minute, time.Second)
+		c.AddDependency("omdb", unhealthy)
+		c.AddDependency("search-log", healthy)
+		c.AddService("movie.SearchMovie", "omdb", "search-log")
+		c.AddService("logging", "search-log")
+		c.CheckAll(context.TODO())
+
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, c, ""))
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, c, "movie.SearchMovie"))
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, c, "logging"))
+		assert.False(t, c.Ready())
+	})
+
+	t.Run("[CheckAll] informational dependency down keeps the instance ready", func(t *testing.T) {
+		c := NewChecker(time.Minute, time.Second)
+		c.AddInformational("omdb", unhealthy)
+		c.AddInformational("tmdb", healthy)
+		c.AddDependency("movies", healthy)
+		c.AddService("movie.SearchMovie", "movies")
+		c.CheckAll(context.TODO())
+
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, c, ""))
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, c, "movie.SearchMovie"))
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, c, "omdb"))
+		assert.True(t, c.Ready())
+		assert.Equal(t, map[string]string{"omdb": notServing, "tmdb": serving, "movies": serving}, c.dependencyStatuses())
+	})
+
+	t.Run("[CheckAll] slow dependency is cut by the timeout", func(t *testing.T) {
+		slow := checkFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		c := NewChecker(time.Minute, 10*time.Millisecond)
+		c.AddDependency("omdb", slow)
+		c.CheckAll(context.TODO())
+
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, c, "omdb"))
+	})
+}
+
+func TestShutdown(t *testing.T) {
+	t.Run("[Shutdown] stays not serving even if checks pass", func(t *testing.T) {
+		c := NewChecker(time.Minute, time.Second)
+		c.AddDependency("omdb", healthy)
+		c.CheckAll(context.TODO())
+		c.Shutdown()
+		c.CheckAll(context.TODO())
+
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, c, ""))
+		assert.False(t, c.Ready())
+	})
+}
+
+func TestHandlers(t *testing.T) {
+	t.Run("[LivenessHandler] always ok", func(t *testing.T) {
+		c := NewChecker(time.Minute, time.Second)
+		c.AddDependency("omdb", unhealthy)
+		c.CheckAll(context.TODO())
+
+		rec := httptest.NewRecorder()
+		c.LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("[ReadinessHandler] ready", func(t *testing.T) {
+		c := NewChecker(time.Minute, time.Second)
+		c.AddDependency("omdb", healthy)
+		c.CheckAll(context.TODO())
+
+		rec := httptest.NewRecorder()
+		c.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		resp := readinessResponse{}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, readinessResponse{Status: serving, Dependencies: map[string]string{"omdb": serving}}, resp)
+	})
+
+	t.Run("[ReadinessHandler] not ready", func(t *testing.T) {
+		c := NewChecker(time.Minute, time.Second)
+		c.AddDependency("omdb", unhealthy)
+		c.AddDependency("search-log", healthy)
+		c.CheckAll(context.TODO())
+
+		rec := httptest.NewRecorder()
+		c.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		resp := readinessResponse{}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, notServing, resp.Status)
+		assert.Equal(t, notServing, resp.Dependencies["omdb"])
+		assert.Equal(t, serving, resp.Dependencies["search-log"])
+	})
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zenkobert/sbtest-2/common/auth"
+	server "github.com/zenkobert/sbtest-2/delivery/grpc"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// authorizationKey is the metadata key of the bearer token, the REST gateway forwards the Authorization header to it
+const authorizationKey = "authorization"
+
+type authenticator struct {
+	verifier *auth.Verifier
+	prefixes []string
+}
+
+// NewAuthenticator requires a valid bearer token for the methods starting with one of prefixes, such as
+// "/movie.Watchlist/", and tells the handlers who the caller is, see model.CallerFrom.
+// Other methods are called anonymously unless they come with a valid token too
+func NewAuthenticator(verifier *auth.Verifier, prefixes ...string) authenticator {
+	return authenticator{
+		verifier: verifier,
+		prefixes: prefixes,
+	}
+}
+
+func (a *authenticator) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	token, ok := bearerToken(ctx)
+	if !a.protects(info.FullMethod) {
+		// a token that doesn't verify doesn't fail calls that don't need one
+		if caller, err := a.verifier.Verify(token); ok && err == nil {
+			ctx = model.WithCaller(ctx, caller)
+		}
+		return handler(ctx, req)
+	}
+
+	if !ok {
+		return nil, server.UnauthenticatedError(server.ReasonUnauthenticated, "a bearer token is required")
+	}
+
+	caller, err := a.verifier.Verify(token)
+	switch {
+	case err == auth.ErrExpiredToken:
+		return nil, server.UnauthenticatedError(server.ReasonTokenExpired, "the bearer token expired")
+	case err != nil:
+		return nil, server.UnauthenticatedError(server.ReasonUnauthenticated, "the bearer token is invalid")
+	}
+
+	return handler(model.WithCaller(ctx, caller), req)
+}
+
+func (a *authenticator) protects(method string) bool {
+	for _, prefix := range a.prefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(authorizationKey) {
+		parts := strings.SplitN(value, " ", 2)
+		if len(parts) == 2 && strings.EqualFold(parts[0], "Bearer") && strings.TrimSpace(parts[1]) != "" {
+			return strings.TrimSpace(parts[1]), true
+		}
+	}
+
+	return "", false
+}
+
+// Chain runs interceptors around handler in order, the first one is the outermost
+func Chain(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+
+		return next(ctx, req)
+	}
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenkobert/sbtest-2/common/auth"
+	server "github.com/zenkobert/sbtest-2/delivery/grpc"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const jwtSecret = "0123456789abcdef0123456789abcdef"
+
+var watchlistInfo = &grpc.UnaryServerInfo{FullMethod: "/movie.Watchlist/ListWatchlists"}
+
+func withAuthorization(value string) context.Context {
+	return metadata.NewIncomingContext(context.TODO(), metadata.Pairs("authorization", value))
+}
+
+// callerHandler answers with the caller of its context
+func callerHandler(ctx context.Context, req interface{}) (interface{}, error) {
+	caller, _ := model.CallerFrom(ctx)
+	return caller, nil
+}
+
+func reasonOf(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+
+	return ""
+}
+
+func TestAuthenticator(t *testing.T) {
+	authenticator := NewAuthenticator(auth.NewVerifier(jwtSecret), "/movie.Watchlist/")
+
+	t.Run("[Unary] caller from a valid bearer token", func(t *testing.T) {
+		token, _ := auth.Sign(jwtSecret, "user-1", time.Now().Add(time.Hour))
+		for _, value := range []string{"Bearer " + token, "bearer  " + token} {
+			caller, err := authenticator.Unary(withAuthorization(value), nil, watchlistInfo, callerHandler)
+			assert.Nil(t, err)
+			assert.Equal(t, "user-1", caller)
+		}
+	})
+
+	t.Run("[Unary] missing or not a bearer token", func(t *testing.T) {
+		for _, ctx := range []context.Context{context.TODO(), withAuthorization("Basic dXNlcjpwYXNz"), withAuthorization("Bearer ")} {
+			_, err := authenticator.Unary(ctx, nil, watchlistInfo, callerHandler)
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			assert.Equal(t, server.ReasonUnauthenticated, reasonOf(err))
+		}
+	})
+
+	t.Run("[Unary] invalid token", func(t *testing.T) {
+		token, _ := auth.Sign("another secret of at least 32 bytes", "user-1", time.Time{})
+		_, err := authenticator.Unary(withAuthorization("Bearer "+token), nil, watchlistInfo, callerHandler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, server.ReasonUnauthenticated, reasonOf(err))
+	})
+
+	t.Run("[Unary] expired token", func(t *testing.T) {
+		token, _ := auth.Sign(jwtSecret, "user-1", time.Now().Add(-time.Hour))
+		_, err := authenticator.Unary(withAuthorization("Bearer "+token), nil, watchlistInfo, callerHandler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Equal(t, server.ReasonTokenExpired, reasonOf(err))
+	})
+
+	t.Run("[Unary] other methods don't need a token", func(t *testing.T) {
+		caller, err := authenticator.Unary(context.TODO(), nil, &grpc.UnaryServerInfo{FullMethod: "/movie.SearchMovie/SearchMovie"}, callerHandler)
+		assert.Nil(t, err)
+		assert.Equal(t, "", caller)
+	})
+
+	t.Run("[Unary] other methods know the caller of a valid token", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/movie.Review/ListReviews"}
+		token, _ := auth.Sign(jwtSecret, "user-1", time.Now().Add(time.Hour))
+		caller, err := authenticator.Unary(withAuthorization("Bearer "+token), nil, info, callerHandler)
+		assert.Nil(t, err)
+		assert.Equal(t, "user-1", caller)
+
+		expired, _ := auth.Sign(jwtSecret, "user-1", time.Now().Add(-time.Hour))
+		caller, err = authenticator.Unary(withAuthorization("Bearer "+expired), nil, info, callerHandler)
+		assert.Nil(t, err)
+		assert.Equal(t, "", caller)
+	})
+}
+
+func TestChain(t *testing.T) {
+	t.Run("[Chain] first interceptor is the outermost", func(t *testing.T) {
+		var calls []string
+		interceptor := func(name string) grpc.UnaryServerInterceptor {
+			return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				calls = append(calls, name+" "+info.FullMethod)
+				resp, err := handler(ctx, req)
+				calls = append(calls, name+" done")
+				return resp, err
+			}
+		}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls = append(calls, "handler")
+			return "abc", nil
+		}
+
+		resp, err := Chain(interceptor("first"), interceptor("second"))(context.TODO(), nil, watchlistInfo, handler)
+		assert.Nil(t, err)
+		assert.Equal(t, "abc", resp)
+		assert.Equal(t, []string{
+			"first /movie.Watchlist/ListWatchlists",
+			"second /movie.Watchlist/ListWatchlists",
+			"handler",
+			"second done",
+			"first done",
+		}, calls)
+	})
+}
@@ -4,29 +4,91 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
+	"sync"
 
+	"github.com/zenkobert/sbtest-2/common/redact"
+	"github.com/zenkobert/sbtest-2/common/tracing"
 	model "github.com/zenkobert/sbtest-2/domain"
 	"google.golang.org/grpc"
 )
 
+// health probes hit the server every few seconds, they're not searches worth logging
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+// searchServicePrefix is the service whose requests are logged whole, searchwords are learnt from the
+// search log. The requests of the other services carry watchlists, reviews and imported watch histories,
+// only their method is logged
+const searchServicePrefix = "/movie.SearchMovie/"
+
 type interceptor struct {
 	MovieUsecase model.MovieUsecase
+	pending      *sync.WaitGroup
 }
 
 func NewInterceptor(usecase model.MovieUsecase) interceptor {
 	return interceptor{
 		MovieUsecase: usecase,
+		pending:      &sync.WaitGroup{},
 	}
 }
 
 func (in *interceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	record := fmt.Sprintf("%s/ %s", info.FullMethod, req)
+	ctx, span := tracing.StartServerSpan(ctx, info.FullMethod)
+	defer span.End()
+
+	if !strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+		record := fmt.Sprintf("%s/", info.FullMethod)
+		if strings.HasPrefix(info.FullMethod, searchServicePrefix) {
+			record = fmt.Sprintf("%s/ %s", info.FullMethod, req)
+		}
+		in.Record(record)
+	}
+
+	resp, err := handler(ctx, req)
+	// last line of defence, whatever the handler returns must not leak a secret to the client
+	err = redact.Status(err)
+	tracing.RecordStatus(span, err)
+
+	return resp, err
+}
+
+// Stream is Unary for streaming calls, logged once when they start rather than for every message
+func (in *interceptor) Stream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := tracing.StartServerSpan(stream.Context(), info.FullMethod)
+	defer span.End()
+
+	if !strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+		in.Record(fmt.Sprintf("%s/ stream", info.FullMethod))
+	}
+
+	err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	err = redact.Status(err)
+	tracing.RecordStatus(span, err)
 
+	return err
+}
+
+// contextStream is a ServerStream whose handler gets the context of the interceptor, carrying its span
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *contextStream) Context() context.Context {
+	return stream.ctx
+}
+
+// Record logs a call to the search log, for the servers not behind the interceptor such as GraphQL.
+// Flush waits for it as well
+func (in *interceptor) Record(record string) {
 	// don't need to wait until logging finish
 	// client need to be served asap
-	go in.logToDB(record)
-
-	return handler(ctx, req)
+	in.pending.Add(1)
+	go func() {
+		defer in.pending.Done()
+		in.logToDB(record)
+	}()
 }
 
 func (in *interceptor) logToDB(record string) error {
@@ -37,3 +99,20 @@ func (in *interceptor) logToDB(record string) error {
 
 	return err
 }
+
+// Flush waits for the search records still being written, until ctx is done.
+// Call it once the server stopped accepting requests
+func (in *interceptor) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		in.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
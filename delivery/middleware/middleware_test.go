@@ -3,17 +3,22 @@ package middleware
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	testify "github.com/stretchr/testify/mock"
+	"github.com/zenkobert/sbtest-2/common/redact"
 	"github.com/zenkobert/sbtest-2/domain/mocks"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestNewInterceptor(t *testing.T) {
 	t.Run("[NewInterceptor]", func(t *testing.T) {
-		expected := interceptor{&mocks.MovieUsecase{}}
+		expected := interceptor{&mocks.MovieUsecase{}, &sync.WaitGroup{}}
 		actual := NewInterceptor(&mocks.MovieUsecase{})
 
 		assert.Equal(t, expected, actual)
@@ -36,6 +41,26 @@ func TestUnary(t *testing.T) {
 	})
 }
 
+func TestUnaryRecord(t *testing.T) {
+	t.Run("[Unary] requests of the other services are logged without their content", func(t *testing.T) {
+		for method, expected := range map[string]string{
+			"/movie.SearchMovie/SearchMovie": "/movie.SearchMovie/SearchMovie/ searchword:\"iron man\"",
+			"/movie.Watchlist/ImportHistory": "/movie.Watchlist/ImportHistory/",
+			"/movie.Review/SubmitReview":     "/movie.Review/SubmitReview/",
+		} {
+			movieUsecase := mocks.MovieUsecase{}
+			movieUsecase.On("LogToDB", testify.Anything).Return(nil)
+			in := NewInterceptor(&movieUsecase)
+
+			in.Unary(context.TODO(), `searchword:"iron man"`, &grpc.UnaryServerInfo{FullMethod: method}, func(context.Context, interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			in.Flush(context.TODO())
+			movieUsecase.AssertCalled(t, "LogToDB", expected)
+		}
+	})
+}
+
 func TestLogToDB(t *testing.T) {
 	t.Run("[logToDB] movieUsecase return error", func(t *testing.T) {
 		movieUsecase := mocks.MovieUsecase{}
@@ -55,3 +80,136 @@ func TestLogToDB(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestUnaryHealthCheck(t *testing.T) {
+	t.Run("[Unary] health checks are not logged", func(t *testing.T) {
+		movieUsecase := mocks.MovieUsecase{}
+		in := NewInterceptor(&movieUsecase)
+
+		var handler = func(context.Context, interface{}) (interface{}, error) {
+			return "SERVING", nil
+		}
+
+		info := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+		result, err := in.Unary(context.TODO(), "interface{}", info, handler)
+		assert.Nil(t, err)
+		assert.Equal(t, "SERVING", result)
+		movieUsecase.AssertNotCalled(t, "LogToDB", testify.Anything)
+	})
+}
+
+func TestFlush(t *testing.T) {
+	t.Run("[Flush] waits for pending records", func(t *testing.T) {
+		written := make(chan struct{})
+		movieUsecase := mocks.MovieUsecase{}
+		movieUsecase.On("LogToDB", testify.Anything).Run(func(testify.Arguments) {
+			time.Sleep(10 * time.Millisecond)
+			close(written)
+		}).Return(nil)
+		in := NewInterceptor(&movieUsecase)
+
+		var handler = func(context.Context, interface{}) (interface{}, error) {
+			return "abc", nil
+		}
+		in.Unary(context.TODO(), "interface{}", &grpc.UnaryServerInfo{}, handler)
+
+		err := in.Flush(context.TODO())
+		assert.Nil(t, err)
+		select {
+		case <-written:
+		default:
+			t.Error("Flush returned before the record was written")
+		}
+	})
+
+	t.Run("[Flush] gives up when ctx is done", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		movieUsecase := mocks.MovieUsecase{}
+		movieUsecase.On("LogToDB", testify.Anything).Run(func(testify.Arguments) {
+			<-release
+		}).Return(nil)
+		in := NewInterceptor(&movieUsecase)
+
+		var handler = func(context.Context, interface{}) (interface{}, error) {
+			return "abc", nil
+		}
+		in.Unary(context.TODO(), "interface{}", &grpc.UnaryServerInfo{}, handler)
+
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+		defer cancel()
+
+		err := in.Flush(ctx)
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+}
+
+func TestUnaryRedaction(t *testing.T) {
+	t.Run("[Unary] registered secret is removed from the returned status", func(t *testing.T) {
+		redact.Register("s3cr3tKey")
+
+		movieUsecase := mocks.MovieUsecase{}
+		movieUsecase.On("LogToDB", testify.Anything).Return(nil)
+		in := NewInterceptor(&movieUsecase)
+
+		var handler = func(context.Context, interface{}) (interface{}, error) {
+			return nil, status.Error(codes.Internal, "upstream rejected key s3cr3tKey")
+		}
+
+		_, err := in.Unary(context.TODO(), "interface{}", &grpc.UnaryServerInfo{}, handler)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.NotContains(t, st.Message(), "s3cr3tKey")
+	})
+}
+
+// serverStream is a stream of no messages with a context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *serverStream) Context() context.Context {
+	return stream.ctx
+}
+
+func TestStream(t *testing.T) {
+	t.Run("[Stream] logged once, the handler gets the context of the stream", func(t *testing.T) {
+		redact.Register("s3cr3tKey")
+
+		movieUsecase := mocks.MovieUsecase{}
+		movieUsecase.On("LogToDB", testify.Anything).Return(nil)
+		in := NewInterceptor(&movieUsecase)
+
+		type key struct{}
+		ctx := context.WithValue(context.TODO(), key{}, "value")
+		var handler = func(srv interface{}, stream grpc.ServerStream) error {
+			assert.Equal(t, "value", stream.Context().Value(key{}))
+			return status.Error(codes.Internal, "upstream rejected key s3cr3tKey")
+		}
+
+		err := in.Stream(nil, &serverStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/movie.SearchMovie/AutocompleteStream"}, handler)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.NotContains(t, st.Message(), "s3cr3tKey")
+
+		in.Flush(context.TODO())
+		movieUsecase.AssertCalled(t, "LogToDB", "/movie.SearchMovie/AutocompleteStream/ stream")
+		movieUsecase.AssertNumberOfCalls(t, "LogToDB", 1)
+	})
+
+	t.Run("[Stream] health watches are not logged", func(t *testing.T) {
+		movieUsecase := mocks.MovieUsecase{}
+		in := NewInterceptor(&movieUsecase)
+
+		var handler = func(srv interface{}, stream grpc.ServerStream) error {
+			return nil
+		}
+
+		err := in.Stream(nil, &serverStream{ctx: context.TODO()}, &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch"}, handler)
+		assert.Nil(t, err)
+		in.Flush(context.TODO())
+		movieUsecase.AssertNotCalled(t, "LogToDB", testify.Anything)
+	})
+}
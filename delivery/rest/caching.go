@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	server "github.com/zenkobert/sbtest-2/delivery/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// CacheHeaders sets Cache-Control and Last-Modified from the freshness the GRPC service sends along with
+// its answer, meant for runtime.WithForwardResponseOption. Answers of unknown freshness must be revalidated
+func CacheHeaders(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+	md, _ := runtime.ServerMetadataFromContext(ctx)
+
+	maxAge := md.HeaderMD.Get(server.MetadataMaxAge)
+	if len(maxAge) == 0 {
+		w.Header().Set("Cache-Control", "no-cache")
+		return nil
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age="+maxAge[0])
+	if lastModified := md.HeaderMD.Get(server.MetadataLastModified); len(lastModified) > 0 {
+		w.Header().Set("Last-Modified", lastModified[0])
+	}
+
+	return nil
+}
+
+// outgoingHeader forwards header metadata the way the gateway does by default,
+// except the freshness already turned into caching headers by CacheHeaders
+func outgoingHeader(key string) (string, bool) {
+	switch key {
+	case server.MetadataMaxAge, server.MetadataLastModified:
+		return "", false
+	}
+
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// bufferedWriter holds the response back until its ETag is known
+type bufferedWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+
+	return w.body.Write(b)
+}
+
+// Conditional gives successful GET responses a strong ETag computed from their body
+// and answers 304 Not Modified to If-None-Match, or If-Modified-Since without it. The responses to a request
+// with an Authorization header may depend on the caller, whatever CacheHeaders set they're private
+// and revalidated
+func Conditional(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		buffered := &bufferedWriter{ResponseWriter: w}
+		next.ServeHTTP(buffered, r)
+		if buffered.status == 0 {
+			buffered.status = http.StatusOK
+		}
+		if r.Header.Get("Authorization") != "" {
+			w.Header().Set("Cache-Control", "private, no-cache")
+			w.Header().Del("Last-Modified")
+		}
+
+		if buffered.status == http.StatusOK {
+			etag := ETag(buffered.body.Bytes())
+			w.Header().Set("ETag", etag)
+
+			if notModified(r, etag, w.Header().Get("Last-Modified")) {
+				for _, header := range []string{"Content-Type", "Content-Length", "Transfer-Encoding"} {
+					w.Header().Del(header)
+				}
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		w.WriteHeader(buffered.status)
+		w.Write(buffered.body.Bytes())
+	})
+}
+
+// ETag is the strong entity tag of body
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+
+	return fmt.Sprintf(`"%s"`, base64.RawURLEncoding.EncodeToString(sum[:18]))
+}
+
+// notModified evaluates the conditional headers of r as RFC 7232 section 6 does for GET
+func notModified(r *http.Request, etag, lastModified string) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			// If-None-Match uses the weak comparison
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+
+	return !modified.Truncate(time.Second).After(ifModifiedSince)
+}
@@ -0,0 +1,151 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	mock "github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+var fetchedAt = time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+
+// cachedDetail answers like a usecase on top of the OMDb cache, with freshness when cached is set
+func cachedDetail(cached bool) *mock.MovieUsecase {
+	movieUsecaseMock := &mock.MovieUsecase{}
+	movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Run(func(args testify.Arguments) {
+		if cached {
+			model.RecordFreshness(args.Get(0).(context.Context), fetchedAt, 90*time.Second)
+		}
+	}).Return(&model.MovieDetail{Title: "Iron Man", ImdbID: "tt0371746"}, nil)
+
+	return movieUsecaseMock
+}
+
+func conditionalGet(handler http.Handler, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/movies/tt0371746", nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	Conditional(handler).ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestCacheHeaders(t *testing.T) {
+	t.Run("[CacheHeaders] freshness of cached answers", func(t *testing.T) {
+		rec := conditionalGet(gateway(t, cachedDetail(true)), nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "public, max-age=90", rec.Header().Get("Cache-Control"))
+		assert.Equal(t, "Wed, 01 Sep 2021 10:00:00 GMT", rec.Header().Get("Last-Modified"))
+		assert.Equal(t, ETag(rec.Body.Bytes()), rec.Header().Get("ETag"))
+		// the metadata is not forwarded as is
+		assert.Empty(t, rec.Header().Get("Grpc-Metadata-X-Cache-Max-Age"))
+		assert.Empty(t, rec.Header().Get("Grpc-Metadata-X-Last-Modified"))
+	})
+
+	t.Run("[CacheHeaders] answers of unknown freshness must be revalidated", func(t *testing.T) {
+		rec := conditionalGet(gateway(t, cachedDetail(false)), nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+		assert.Empty(t, rec.Header().Get("Last-Modified"))
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+	})
+
+	t.Run("[CacheHeaders] all pages are as fresh as the stalest one", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		page := func(fetched time.Time, maxAge time.Duration) func(testify.Arguments) {
+			return func(args testify.Arguments) {
+				model.RecordFreshness(args.Get(0).(context.Context), fetched, maxAge)
+			}
+		}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, uint32(1), testify.Anything).Run(page(fetchedAt, 90*time.Second)).
+			Return(&model.MovieSearch{Search: make([]model.SearchDetail, 10), TotalResults: "11"}, nil)
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, uint32(2), testify.Anything).Run(page(fetchedAt.Add(-time.Minute), 30*time.Second)).
+			Return(&model.MovieSearch{Search: make([]model.SearchDetail, 1), TotalResults: "11"}, nil)
+
+		rec := httptest.NewRecorder()
+		gateway(t, movieUsecaseMock).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/movies?searchword=iron&allPages=true", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "public, max-age=30", rec.Header().Get("Cache-Control"))
+		assert.Equal(t, "Wed, 01 Sep 2021 09:59:00 GMT", rec.Header().Get("Last-Modified"))
+	})
+}
+
+func TestConditional(t *testing.T) {
+	handler := gateway(t, cachedDetail(true))
+	etag := conditionalGet(handler, nil).Header().Get("ETag")
+
+	t.Run("[Conditional] the ETag is stable and strong", func(t *testing.T) {
+		assert.Equal(t, etag, conditionalGet(handler, nil).Header().Get("ETag"))
+		assert.Regexp(t, `^"[A-Za-z0-9_-]+"$`, etag)
+		assert.NotEqual(t, etag, ETag([]byte(`{}`)))
+	})
+
+	t.Run("[Conditional] If-None-Match", func(t *testing.T) {
+		testCases := map[string]int{
+			etag:               http.StatusNotModified,
+			`"other", ` + etag: http.StatusNotModified,
+			"W/" + etag:        http.StatusNotModified,
+			"*":                http.StatusNotModified,
+			`"other"`:          http.StatusOK,
+			`"x` + etag[1:]:    http.StatusOK,
+		}
+
+		for ifNoneMatch, code := range testCases {
+			rec := conditionalGet(handler, map[string]string{"If-None-Match": ifNoneMatch})
+			assert.Equal(t, code, rec.Code, "If-None-Match: %s", ifNoneMatch)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			if code == http.StatusNotModified {
+				assert.Empty(t, rec.Body.Bytes())
+				assert.Empty(t, rec.Header().Get("Content-Type"))
+				assert.Equal(t, "public, max-age=90", rec.Header().Get("Cache-Control"))
+			}
+		}
+	})
+
+	t.Run("[Conditional] If-Modified-Since", func(t *testing.T) {
+		testCases := map[time.Time]int{
+			fetchedAt:                 http.StatusNotModified,
+			fetchedAt.Add(time.Hour):  http.StatusNotModified,
+			fetchedAt.Add(-time.Hour): http.StatusOK,
+		}
+
+		for ifModifiedSince, code := range testCases {
+			rec := conditionalGet(handler, map[string]string{"If-Modified-Since": ifModifiedSince.Format(http.TimeFormat)})
+			assert.Equal(t, code, rec.Code, "If-Modified-Since: %s", ifModifiedSince)
+		}
+
+		// If-None-Match takes precedence
+		rec := conditionalGet(handler, map[string]string{
+			"If-Modified-Since": fetchedAt.Format(http.TimeFormat),
+			"If-None-Match":     `"other"`,
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("[Conditional] answers to a token stay out of shared caches", func(t *testing.T) {
+		rec := conditionalGet(gateway(t, cachedDetail(true)), map[string]string{"Authorization": "Bearer token"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "private, no-cache", rec.Header().Get("Cache-Control"))
+		assert.Empty(t, rec.Header().Get("Last-Modified"))
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+	})
+
+	t.Run("[Conditional] errors are passed through", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/movies?searchword=iron", nil)
+		req.Header.Set("If-None-Match", "*")
+		rec := httptest.NewRecorder()
+		Conditional(gateway(t, failingSearch(model.ErrUpstream))).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		assert.Empty(t, rec.Header().Get("ETag"))
+		assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+	})
+}
@@ -0,0 +1,198 @@
+package rest
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+// content codings the REST server compresses responses with
+const (
+	EncodingGzip = "gzip"
+	EncodingZstd = "zstd"
+)
+
+// encoders pool the compressors by content coding, they are reset for every response
+var encoders = map[string]*sync.Pool{
+	EncodingGzip: {New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return w
+	}},
+	EncodingZstd: {New: func() interface{} {
+		w, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedDefault))
+		return w
+	}},
+}
+
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// Compress compresses the responses of next with the encodings the client accepts, the first listed one
+// wins among those it prefers equally. Bodies shorter than minSize are sent as is, compressing them
+// costs more than it saves
+func Compress(next http.Handler, encodings []string, minSize int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := acceptedEncoding(r.Header.Get("Accept-Encoding"), encodings)
+		if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Range") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: minSize}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// acceptedEncoding picks the encoding to answer with from an Accept-Encoding header, empty for identity
+func acceptedEncoding(acceptEncoding string, encodings []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	accepted := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		q := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				value, err := strconv.ParseFloat(param[2:], 64)
+				if err == nil {
+					q = value
+				}
+			}
+		}
+		accepted[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range encodings {
+		q, ok := accepted[encoding]
+		if !ok {
+			q, ok = accepted["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+
+	return best
+}
+
+// compressWriter holds the body back until minSize bytes were written, then decides whether to compress
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	status  int
+	buf     []byte
+	started bool
+	encoder encoder
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.started || w.status != 0 {
+		return
+	}
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		// no body to compress
+		w.started = true
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	w.status = status
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.started {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) < w.minSize {
+			return len(b), nil
+		}
+
+		err := w.start(true)
+		return len(b), err
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// start sends the headers and the buffered body, compressed when compress is set and the response allows it
+func (w *compressWriter) start(compress bool) error {
+	w.started = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	header := w.Header()
+	if compress && header.Get("Content-Encoding") == "" && header.Get("Content-Range") == "" {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		if header.Get("Content-Type") == "" {
+			header.Set("Content-Type", http.DetectContentType(w.buf))
+		}
+
+		w.encoder = encoders[w.encoding].Get().(encoder)
+		w.encoder.Reset(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buf) == 0 {
+		return nil
+	}
+	if w.encoder != nil {
+		_, err := w.encoder.Write(w.buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(w.buf)
+	return err
+}
+
+// Flush sends what was written so far, compressed when it reached minSize, for streamed responses
+func (w *compressWriter) Flush() {
+	if !w.started {
+		w.start(len(w.buf) >= w.minSize)
+	}
+	if w.encoder != nil {
+		w.encoder.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close sends short bodies as is and terminates the compressed stream of long ones
+func (w *compressWriter) Close() error {
+	if !w.started {
+		if w.status == 0 && len(w.buf) == 0 {
+			// nothing was written, let net/http answer 200 with an empty body
+			return nil
+		}
+		return w.start(false)
+	}
+	if w.encoder == nil {
+		return nil
+	}
+
+	err := w.encoder.Close()
+	encoders[w.encoding].Put(w.encoder)
+	w.encoder = nil
+	return err
+}
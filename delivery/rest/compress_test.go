@@ -0,0 +1,226 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	mock "github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+var encodings = []string{EncodingZstd, EncodingGzip}
+
+func decompress(t testing.TB, encoding string, body []byte) []byte {
+	var r io.Reader
+	var err error
+	switch encoding {
+	case EncodingGzip:
+		r, err = gzip.NewReader(bytes.NewReader(body))
+	case EncodingZstd:
+		r, err = zstd.NewReader(bytes.NewReader(body))
+	default:
+		return body
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decompressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return decompressed
+}
+
+func compressRequest(handler http.Handler, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/movies?searchword=iron", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	Compress(handler, encodings, 64).ServeHTTP(rec, req)
+
+	return rec
+}
+
+func writeBody(body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", "999")
+		// written in pieces like the gateway marshalers do
+		for _, piece := range strings.SplitAfter(body, ",") {
+			w.Write([]byte(piece))
+		}
+	})
+}
+
+func TestAcceptedEncoding(t *testing.T) {
+	testCases := map[string]string{
+		"":                            "",
+		"identity":                    "",
+		"gzip":                        EncodingGzip,
+		"gzip, deflate, br, zstd":     EncodingZstd,
+		"GZIP;q=0.8, zstd;q=0.5":      EncodingGzip,
+		"zstd;q=0, gzip":              EncodingGzip,
+		"*":                           EncodingZstd,
+		"*;q=0.2, gzip;q=0.5":         EncodingGzip,
+		"gzip;q=0, zstd;q=0":          "",
+		"br":                          "",
+		"zstd;q=invalid":              EncodingZstd,
+		"deflate ; q=1.0, gzip ;q=.9": EncodingGzip,
+	}
+
+	for acceptEncoding, expected := range testCases {
+		t.Run("[acceptedEncoding] "+acceptEncoding, func(t *testing.T) {
+			assert.Equal(t, expected, acceptedEncoding(acceptEncoding, encodings))
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	long := `{"results":[` + strings.Repeat(`{"title":"Iron Man","year":"2008"},`, 20) + `{}]}`
+
+	for _, encoding := range encodings {
+		t.Run("[Compress] long bodies with "+encoding, func(t *testing.T) {
+			rec := compressRequest(writeBody(long), encoding)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, encoding, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			assert.Empty(t, rec.Header().Get("Content-Length"))
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+			assert.Less(t, rec.Body.Len(), len(long))
+			assert.Equal(t, long, string(decompress(t, encoding, rec.Body.Bytes())))
+		})
+	}
+
+	t.Run("[Compress] short bodies are sent as is", func(t *testing.T) {
+		rec := compressRequest(writeBody(`{"total":"1"}`), "gzip")
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, `{"total":"1"}`, rec.Body.String())
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+	})
+
+	t.Run("[Compress] clients not accepting an encoding", func(t *testing.T) {
+		rec := compressRequest(writeBody(long), "")
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, long, rec.Body.String())
+	})
+
+	t.Run("[Compress] status and already encoded bodies are kept", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "br")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(long))
+		})
+
+		rec := compressRequest(handler, "gzip")
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, long, rec.Body.String())
+	})
+
+	t.Run("[Compress] bodiless responses", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotModified)
+		})
+
+		rec := compressRequest(handler, "gzip")
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Empty(t, rec.Body.Bytes())
+	})
+
+	t.Run("[Compress] flushed streams are compressed as they go", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for i := 0; i < 3; i++ {
+				w.Write([]byte(long + "\n"))
+				w.(http.Flusher).Flush()
+			}
+		})
+
+		rec := compressRequest(handler, "gzip")
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, strings.Repeat(long+"\n", 3), string(decompress(t, EncodingGzip, rec.Body.Bytes())))
+	})
+
+	t.Run("[Compress] each encoding gets its own ETag", func(t *testing.T) {
+		handler := Conditional(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Compress(writeBody(long), encodings, 64).ServeHTTP(w, r)
+		}))
+
+		etags := map[string]bool{}
+		for _, acceptEncoding := range []string{"", "gzip", "zstd"} {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			etags[rec.Header().Get("ETag")] = true
+
+			req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+			rec = httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusNotModified, rec.Code, acceptEncoding)
+		}
+		assert.Len(t, etags, 3)
+	})
+}
+
+// BenchmarkCompress reports the size of the recorded OMDb responses served by the REST gateway with each encoding
+func BenchmarkCompress(b *testing.B) {
+	read := func(name string, v interface{}) {
+		content, err := ioutil.ReadFile("../grpc/testdata/" + name)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := json.Unmarshal(content, v); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	detail, search := &model.MovieDetail{}, &model.MovieSearch{}
+	read("omdb_detail.json", detail)
+	read("omdb_search.json", search)
+
+	movieUsecaseMock := &mock.MovieUsecase{}
+	movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(detail, nil)
+	movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(search, nil)
+	handler := Compress(gateway(b, movieUsecaseMock), encodings, 1024)
+
+	for name, path := range map[string]string{"detail": "/v1/movies/tt0371746", "search": "/v1/movies?searchword=iron+man"} {
+		identity := httptest.NewRecorder()
+		handler.ServeHTTP(identity, httptest.NewRequest(http.MethodGet, path, nil))
+
+		for _, encoding := range encodings {
+			b.Run(name+"/"+encoding, func(b *testing.B) {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				req.Header.Set("Accept-Encoding", encoding)
+
+				var rec *httptest.ResponseRecorder
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					rec = httptest.NewRecorder()
+					handler.ServeHTTP(rec, req)
+				}
+
+				if rec.Header().Get("Content-Encoding") != encoding {
+					b.Fatalf("%s response not compressed, %d bytes", name, rec.Body.Len())
+				}
+				b.ReportMetric(float64(identity.Body.Len()), "raw-bytes")
+				b.ReportMetric(float64(rec.Body.Len()), "wire-bytes")
+				b.ReportMetric(100*(1-float64(rec.Body.Len())/float64(identity.Body.Len())), "%saved")
+			})
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package rest
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions tell which cross-origin requests are allowed, see config.CORSConfig
+type CORSOptions struct {
+	// AllowedOrigins are origins like https://app.example.com, * for any origin
+	// or https://*.example.com for any subdomain
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// wildcardLabels is what the * of an origin pattern may stand for: one or more DNS labels
+const wildcardLabels = `[a-z0-9-]+(\.[a-z0-9-]+)*`
+
+type cors struct {
+	next      http.Handler
+	options   CORSOptions
+	anyOrigin bool
+	anyHeader bool
+	origins   []*regexp.Regexp
+	methods   map[string]bool
+	headers   map[string]bool
+}
+
+// CORS answers preflight requests and adds the CORS headers to the responses of next for allowed origins.
+// Requests from other origins are served without them, so browsers don't expose the responses
+func CORS(next http.Handler, options CORSOptions) http.Handler {
+	c := &cors{
+		next:    next,
+		options: options,
+		methods: map[string]bool{},
+		headers: map[string]bool{},
+	}
+
+	for _, origin := range options.AllowedOrigins {
+		if origin == "*" {
+			c.anyOrigin = true
+			continue
+		}
+
+		parts := strings.SplitN(strings.ToLower(origin), "*", 2)
+		pattern := regexp.QuoteMeta(parts[0])
+		if len(parts) == 2 {
+			pattern += wildcardLabels + regexp.QuoteMeta(parts[1])
+		}
+		c.origins = append(c.origins, regexp.MustCompile("^"+pattern+"$"))
+	}
+	for _, method := range options.AllowedMethods {
+		c.methods[strings.ToUpper(method)] = true
+	}
+	for _, header := range options.AllowedHeaders {
+		if header == "*" {
+			c.anyHeader = true
+		}
+		c.headers[http.CanonicalHeaderKey(header)] = true
+	}
+
+	return c
+}
+
+func (c *cors) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+	w.Header().Add("Vary", "Origin")
+	if preflight {
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+	}
+
+	if origin == "" {
+		c.next.ServeHTTP(w, r)
+		return
+	}
+	if !c.allowedOrigin(origin) {
+		if preflight {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		c.next.ServeHTTP(w, r)
+		return
+	}
+
+	if preflight {
+		c.preflight(w, r, origin)
+		return
+	}
+
+	c.allowOrigin(w, origin)
+	if len(c.options.ExposedHeaders) > 0 {
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(c.options.ExposedHeaders, ", "))
+	}
+	c.next.ServeHTTP(w, r)
+}
+
+func (c *cors) preflight(w http.ResponseWriter, r *http.Request, origin string) {
+	if !c.methods[strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))] {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var requested []string
+	for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+		if header = strings.TrimSpace(header); header == "" {
+			continue
+		}
+		if !c.anyHeader && !c.headers[http.CanonicalHeaderKey(header)] {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		requested = append(requested, header)
+	}
+
+	c.allowOrigin(w, origin)
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(c.options.AllowedMethods, ", "))
+	if len(requested) > 0 {
+		// echoed rather than listed, browsers don't honor * along with credentials
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(requested, ", "))
+	}
+	if c.options.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(c.options.MaxAge/time.Second)))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *cors) allowOrigin(w http.ResponseWriter, origin string) {
+	if c.anyOrigin && !c.options.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if c.options.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (c *cors) allowedOrigin(origin string) bool {
+	if c.anyOrigin {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+	for _, pattern := range c.origins {
+		if pattern.MatchString(origin) {
+			return true
+		}
+	}
+
+	return false
+}
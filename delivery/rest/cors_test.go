@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var corsOptions = CORSOptions{
+	AllowedOrigins: []string{"https://app.example.com", "http://localhost:3000", "https://*.example.org"},
+	AllowedMethods: []string{"GET", "POST"},
+	AllowedHeaders: []string{"Accept-Language", "X-Request-Id"},
+	ExposedHeaders: []string{"ETag", "X-Request-Id"},
+	MaxAge:         10 * time.Minute,
+}
+
+func corsRequest(options CORSOptions, method, origin string, headers map[string]string) (rec *httptest.ResponseRecorder, served bool) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = true
+		w.Write([]byte("ok"))
+	})
+
+	req := httptest.NewRequest(method, "/v1/movies?searchword=iron", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec = httptest.NewRecorder()
+	CORS(next, options).ServeHTTP(rec, req)
+
+	return rec, served
+}
+
+func TestCORSPreflight(t *testing.T) {
+	preflight := func(options CORSOptions, origin, method, headers string) (*httptest.ResponseRecorder, bool) {
+		return corsRequest(options, http.MethodOptions, origin, map[string]string{
+			"Access-Control-Request-Method":  method,
+			"Access-Control-Request-Headers": headers,
+		})
+	}
+
+	t.Run("[CORS] allowed preflight is answered without reaching the API", func(t *testing.T) {
+		rec, served := preflight(corsOptions, "https://app.example.com", "GET", "x-request-id, accept-language")
+		assert.False(t, served)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "x-request-id, accept-language", rec.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, rec.Header().Values("Vary"))
+	})
+
+	t.Run("[CORS] wildcard subdomains", func(t *testing.T) {
+		testCases := map[string]int{
+			"https://api.example.org":        http.StatusNoContent,
+			"https://a.b.example.org":        http.StatusNoContent,
+			"https://API.Example.org":        http.StatusNoContent,
+			"https://example.org":            http.StatusForbidden,
+			"http://api.example.org":         http.StatusForbidden,
+			"https://evil.com/.example.org":  http.StatusForbidden,
+			"https://api.example.org.evil.c": http.StatusForbidden,
+			"http://localhost:3000":          http.StatusNoContent,
+			"http://localhost:3001":          http.StatusForbidden,
+		}
+
+		for origin, code := range testCases {
+			rec, served := preflight(corsOptions, origin, "GET", "")
+			assert.False(t, served, origin)
+			assert.Equal(t, code, rec.Code, origin)
+			if code == http.StatusForbidden {
+				assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"), origin)
+			}
+		}
+	})
+
+	t.Run("[CORS] methods and headers not allowed", func(t *testing.T) {
+		rec, _ := preflight(corsOptions, "https://app.example.com", "DELETE", "")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+
+		rec, _ = preflight(corsOptions, "https://app.example.com", "GET", "X-Request-Id, Authorization")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+
+		options := corsOptions
+		options.AllowedHeaders = []string{"*"}
+		rec, _ = preflight(options, "https://app.example.com", "GET", "Authorization")
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "Authorization", rec.Header().Get("Access-Control-Allow-Headers"))
+	})
+
+	t.Run("[CORS] OPTIONS without a requested method is not a preflight", func(t *testing.T) {
+		rec, served := corsRequest(corsOptions, http.MethodOptions, "https://app.example.com", nil)
+		assert.True(t, served)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestCORSSimpleRequests(t *testing.T) {
+	t.Run("[CORS] allowed origin", func(t *testing.T) {
+		rec, served := corsRequest(corsOptions, http.MethodGet, "https://app.example.com", nil)
+		assert.True(t, served)
+		assert.Equal(t, "ok", rec.Body.String())
+		assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "ETag, X-Request-Id", rec.Header().Get("Access-Control-Expose-Headers"))
+		assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+	})
+
+	t.Run("[CORS] other origins and same origin requests get no CORS headers", func(t *testing.T) {
+		for _, origin := range []string{"https://evil.com", ""} {
+			rec, served := corsRequest(corsOptions, http.MethodGet, origin, nil)
+			assert.True(t, served)
+			assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+			assert.Empty(t, rec.Header().Get("Access-Control-Expose-Headers"))
+			assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+		}
+	})
+
+	t.Run("[CORS] any origin", func(t *testing.T) {
+		options := corsOptions
+		options.AllowedOrigins = []string{"*"}
+		rec, _ := corsRequest(options, http.MethodGet, "https://anything.test", nil)
+		assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("[CORS] credentials echo the origin", func(t *testing.T) {
+		options := corsOptions
+		options.AllowCredentials = true
+		rec, _ := corsRequest(options, http.MethodGet, "https://api.example.org", nil)
+		assert.Equal(t, "https://api.example.org", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	})
+}
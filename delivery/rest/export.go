@@ -0,0 +1,263 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// media types of the export formats
+const (
+	MIMEJSON   = "application/json"
+	MIMECSV    = "text/csv"
+	MIMENDJSON = "application/x-ndjson"
+)
+
+// formats maps the values of the format query param to the media types they stand for
+var formats = map[string]string{
+	"json":   MIMEJSON,
+	"csv":    MIMECSV,
+	"ndjson": MIMENDJSON,
+}
+
+// formulaPrefixes start the cells spreadsheets would evaluate as formulas
+const formulaPrefixes = "=+-@\t\r"
+
+var errExportOnly = errors.New("export formats can't be decoded")
+
+// CSVMarshaler writes responses as CSV with a header row, for spreadsheets. When the first field of a response
+// is a repeated message, like the results of a search, its elements are the rows, otherwise the response
+// is a single row. Columns follow the order of the proto fields and are named like the JSON ones
+type CSVMarshaler struct{}
+
+// NDJSONMarshaler writes responses as newline delimited JSON, one line per row as CSVMarshaler picks them
+type NDJSONMarshaler struct{}
+
+func (CSVMarshaler) ContentType(_ interface{}) string {
+	return MIMECSV + "; charset=utf-8; header=present"
+}
+
+func (m CSVMarshaler) Marshal(v interface{}) ([]byte, error) {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("csv: %T is not a proto message", v)
+	}
+
+	descriptor, rows := rowsOf(message.ProtoReflect())
+	fields := descriptor.Fields()
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.UseCRLF = true
+
+	header := make([]string, fields.Len())
+	for i := range header {
+		header[i] = fields.Get(i).JSONName()
+	}
+	w.Write(header)
+
+	for _, row := range rows {
+		record := make([]string, fields.Len())
+		for i := range record {
+			record[i] = neutralize(formatField(row, fields.Get(i)))
+		}
+		w.Write(record)
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func (NDJSONMarshaler) ContentType(_ interface{}) string {
+	return MIMENDJSON
+}
+
+func (m NDJSONMarshaler) Marshal(v interface{}) ([]byte, error) {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("ndjson: %T is not a proto message", v)
+	}
+
+	options := protojson.MarshalOptions{EmitUnpopulated: true}
+	_, rows := rowsOf(message.ProtoReflect())
+
+	var buf bytes.Buffer
+	for _, row := range rows {
+		line, err := options.Marshal(row.Interface())
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (CSVMarshaler) Unmarshal(_ []byte, _ interface{}) error {
+	return errExportOnly
+}
+
+func (NDJSONMarshaler) Unmarshal(_ []byte, _ interface{}) error {
+	return errExportOnly
+}
+
+func (CSVMarshaler) NewDecoder(_ io.Reader) runtime.Decoder {
+	return runtime.DecoderFunc(func(_ interface{}) error { return errExportOnly })
+}
+
+func (NDJSONMarshaler) NewDecoder(_ io.Reader) runtime.Decoder {
+	return runtime.DecoderFunc(func(_ interface{}) error { return errExportOnly })
+}
+
+func (m CSVMarshaler) NewEncoder(w io.Writer) runtime.Encoder {
+	return encoderOf(m, w)
+}
+
+func (m NDJSONMarshaler) NewEncoder(w io.Writer) runtime.Encoder {
+	return encoderOf(m, w)
+}
+
+func encoderOf(m runtime.Marshaler, w io.Writer) runtime.Encoder {
+	return runtime.EncoderFunc(func(v interface{}) error {
+		content, err := m.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(content)
+		return err
+	})
+}
+
+// rowsOf returns the elements of the first field of message when it is a repeated message, or message itself
+func rowsOf(message protoreflect.Message) (protoreflect.MessageDescriptor, []protoreflect.Message) {
+	fields := message.Descriptor().Fields()
+	if fields.Len() == 0 || !fields.Get(0).IsList() || fields.Get(0).Message() == nil {
+		return message.Descriptor(), []protoreflect.Message{message}
+	}
+
+	field := fields.Get(0)
+	list := message.Get(field).List()
+	rows := make([]protoreflect.Message, list.Len())
+	for i := range rows {
+		rows[i] = list.Get(i).Message()
+	}
+	return field.Message(), rows
+}
+
+// formatField renders a field as one cell, list elements and map entries are separated by "; "
+// and the fields of messages, like the source and value of a rating, or a key and its value, by ": "
+func formatField(message protoreflect.Message, field protoreflect.FieldDescriptor) string {
+	value := message.Get(field)
+	if field.IsMap() {
+		entries := make([]string, 0, value.Map().Len())
+		value.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+			entries = append(entries, key.String()+": "+formatValue(field.MapValue(), value))
+			return true
+		})
+		sort.Strings(entries)
+		return strings.Join(entries, "; ")
+	}
+	if !field.IsList() {
+		return formatValue(field, value)
+	}
+
+	list := value.List()
+	items := make([]string, list.Len())
+	for i := range items {
+		items[i] = formatValue(field, list.Get(i))
+	}
+	return strings.Join(items, "; ")
+}
+
+func formatValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	if field.Message() == nil {
+		return value.String()
+	}
+
+	message := value.Message()
+	fields := field.Message().Fields()
+	parts := make([]string, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		parts = append(parts, formatField(message, fields.Get(i)))
+	}
+	return strings.Join(parts, ": ")
+}
+
+// neutralize keeps spreadsheets from running cells as formulas, OWASP CSV injection advice
+func neutralize(cell string) string {
+	if cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+		return "'" + cell
+	}
+
+	return cell
+}
+
+// Negotiate picks the format of the response from the format query param, or from the Accept header
+// with its quality values, and hands it to the gateway as an Accept header it matches exactly.
+// Clients asking for none of them get JSON
+func Negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		query := r.URL.Query()
+		if format := query.Get("format"); format != "" {
+			mediaType, ok := formats[strings.ToLower(format)]
+			if !ok {
+				err := status.Errorf(codes.InvalidArgument, "format must be json, csv or ndjson, got %q", format)
+				ErrorHandler(r.Context(), nil, nil, w, r, err)
+				return
+			}
+
+			// the gateway doesn't know the param, it's consumed here
+			query.Del("format")
+			r.URL.RawQuery = query.Encode()
+			r.Header.Set("Accept", mediaType)
+		} else if mediaType := acceptedFormat(r.Header.Values("Accept")); mediaType != "" {
+			r.Header.Set("Accept", mediaType)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// acceptedFormat returns the media type of the preferred format in Accept headers, empty when none matches
+func acceptedFormat(accept []string) string {
+	best, bestQ := "", 0.0
+	for _, header := range accept {
+		for _, part := range strings.Split(header, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+
+			q := 1.0
+			if value, ok := params["q"]; ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+			for _, known := range []string{MIMEJSON, MIMECSV, MIMENDJSON} {
+				if mediaType == known && q > bestQ {
+					best, bestQ = known, q
+				}
+			}
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	server "github.com/zenkobert/sbtest-2/delivery/grpc"
+	model "github.com/zenkobert/sbtest-2/domain"
+	mock "github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+func exportUsecase() *mock.MovieUsecase {
+	movieUsecaseMock := &mock.MovieUsecase{}
+	movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{
+		Search: []model.SearchDetail{
+			{Title: "Iron Man", Type: "movie", ImdbID: "tt0371746", Poster: "N/A"},
+			{Title: `Iron Man, "The Armored" Adventures`, Type: "series", ImdbID: "tt0837143", Poster: "N/A"},
+			{Title: "=HYPERLINK(\"http://evil\")", Type: "movie", ImdbID: "tt1", Poster: "N/A"},
+		},
+		TotalResults: "3",
+	}, nil)
+	movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(&model.MovieDetail{
+		Title:  "Iron Man",
+		ImdbID: "tt0371746",
+		Ratings: []model.MovieRating{
+			{Source: "Internet Movie Database", Value: "7.9/10"},
+			{Source: "Rotten Tomatoes", Value: "94%"},
+		},
+		Sources: map[string]string{"title": "omdb", "imdbId": "omdb", "ratings": "omdb, tmdb"},
+	}, nil)
+
+	return movieUsecaseMock
+}
+
+func export(t *testing.T, target string, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	Negotiate(gateway(t, exportUsecase())).ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestCSVMarshaler(t *testing.T) {
+	t.Run("[CSVMarshaler] search results are rows", func(t *testing.T) {
+		content, err := CSVMarshaler{}.Marshal(&server.SearchMovieResponse{
+			Results: []*server.Search{
+				{Title: "Iron Man", Year: "2008", ImdbId: "tt0371746", Type: "movie", Poster: "N/A"},
+				{Title: "Iron Man, \"The Armored\" Adventures", Year: "2009\u20132012", ImdbId: "tt0837143", Type: "series"},
+				{Title: "line\nbreak", ImdbId: "tt2"},
+				{Title: `=HYPERLINK("http://evil")`, Year: "-1", ImdbId: "tt1"},
+			},
+			Total: "4",
+		})
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.True(t, strings.HasPrefix(string(content), "title,year,imdbId,type,poster\r\n"))
+
+		records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+		if assert.Nil(t, err) {
+			assert.Equal(t, [][]string{
+				{"title", "year", "imdbId", "type", "poster"},
+				{"Iron Man", "2008", "tt0371746", "movie", "N/A"},
+				{`Iron Man, "The Armored" Adventures`, "2009\u20132012", "tt0837143", "series", ""},
+				{"line\nbreak", "", "tt2", "", ""},
+				{`'=HYPERLINK("http://evil")`, "'-1", "tt1", "", ""},
+			}, records)
+		}
+	})
+
+	t.Run("[CSVMarshaler] through the gateway", func(t *testing.T) {
+		rec := export(t, "/v1/movies?searchword=iron", MIMECSV)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8; header=present", rec.Header().Get("Content-Type"))
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		if assert.Nil(t, err) && assert.Len(t, records, 4) {
+			assert.Equal(t, []string{"title", "year", "imdbId", "type", "poster"}, records[0])
+			assert.Equal(t, "Iron Man", records[1][0])
+			assert.Equal(t, "tt0837143", records[2][2])
+		}
+	})
+
+	t.Run("[CSVMarshaler] a detail is one row", func(t *testing.T) {
+		rec := export(t, "/v1/movies/tt0371746", MIMECSV)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		if assert.Nil(t, err) && assert.Len(t, records, 2) {
+			row := map[string]string{}
+			for i, column := range records[0] {
+				row[column] = records[1][i]
+			}
+			assert.Equal(t, "title", records[0][0])
+			assert.Equal(t, "Iron Man", row["title"])
+			assert.Equal(t, "tt0371746", row["imdbId"])
+			assert.Equal(t, "Internet Movie Database: 7.9/10; Rotten Tomatoes: 94%", row["ratings"])
+			assert.Equal(t, "imdbId: omdb; ratings: omdb, tmdb; title: omdb", row["sources"])
+		}
+	})
+
+	t.Run("[CSVMarshaler] no results is a header only", func(t *testing.T) {
+		content, err := CSVMarshaler{}.Marshal(&server.SearchMovieResponse{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "title,year,imdbId,type,poster\r\n", string(content))
+		}
+	})
+
+	t.Run("[CSVMarshaler] export only", func(t *testing.T) {
+		_, err := CSVMarshaler{}.Marshal("not a message")
+		assert.Error(t, err)
+		assert.Error(t, CSVMarshaler{}.Unmarshal([]byte("a,b"), &server.SearchMovieResponse{}))
+	})
+}
+
+func TestNDJSONMarshaler(t *testing.T) {
+	t.Run("[NDJSONMarshaler] one result per line", func(t *testing.T) {
+		rec := export(t, "/v1/movies?searchword=iron", MIMENDJSON)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, MIMENDJSON, rec.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+		if assert.Len(t, lines, 3) {
+			var first map[string]string
+			assert.Nil(t, json.Unmarshal([]byte(lines[0]), &first))
+			assert.Equal(t, "Iron Man", first["title"])
+			assert.Equal(t, "tt0371746", first["imdbId"])
+			// unpopulated fields are there like in JSON responses
+			assert.Contains(t, first, "type")
+		}
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		target      string
+		accept      string
+		contentType string
+	}{
+		{"default", "/v1/movies?searchword=iron", "", MIMEJSON},
+		{"browsers get JSON", "/v1/movies?searchword=iron", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", MIMEJSON},
+		{"accept csv", "/v1/movies?searchword=iron", "text/csv", MIMECSV},
+		{"quality values", "/v1/movies?searchword=iron", "text/csv;q=0.5, application/x-ndjson", MIMENDJSON},
+		{"format wins over accept", "/v1/movies?searchword=iron&format=csv", "application/json", MIMECSV},
+		{"format is case insensitive", "/v1/movies?format=NDJSON&searchword=iron", "", MIMENDJSON},
+		{"format json", "/v1/movies?searchword=iron&format=json", "text/csv", MIMEJSON},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("[Negotiate] "+testCase.name, func(t *testing.T) {
+			rec := export(t, testCase.target, testCase.accept)
+			assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), testCase.contentType), rec.Header().Get("Content-Type"))
+			assert.Contains(t, rec.Header().Values("Vary"), "Accept")
+		})
+	}
+
+	t.Run("[Negotiate] unknown format", func(t *testing.T) {
+		rec := export(t, "/v1/movies?searchword=iron&format=xlsx", "")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `format must be json, csv or ndjson`)
+	})
+
+	t.Run("[Negotiate] errors stay problem+json", func(t *testing.T) {
+		rec := export(t, "/v1/movies?format=csv", "")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+	})
+}
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"net/http"
+
+	server "github.com/zenkobert/sbtest-2/delivery/grpc"
+	"golang.org/x/text/language"
+)
+
+// languages are the translations of the problem details, the first one is the fallback
+var languages = []language.Tag{language.English, language.Indonesian}
+
+var matcher = language.NewMatcher(languages)
+
+// messages holds the problem details by language then reason, the empty reason is for unexpected errors
+var messages = map[language.Tag]map[string]string{
+	language.English: {
+		server.ReasonMissingSearchword: "Please specify a searchword parameter.",
+		server.ReasonMissingQuery:      "Please specify a query parameter.",
+		server.ReasonMissingPrefix:     "Please specify a prefix parameter.",
+		server.ReasonInvalidSearchword: "The searchword must have at most 200 characters and no control characters.",
+		server.ReasonInvalidImdbID:     "The IMDb ID is malformed, it looks like tt0371746.",
+		server.ReasonMovieNotFound:     "No movie matches the request.",
+		server.ReasonQuotaExceeded:     "The OMDb request limit is reached, retry later.",
+		server.ReasonUpstreamError:     "OMDb could not answer the request.",
+		server.ReasonUpstreamTimeout:   "OMDb took too long to answer.",
+		server.ReasonUnauthenticated:   "A valid bearer token is required.",
+		server.ReasonTokenExpired:      "The bearer token expired, sign in again.",
+		server.ReasonWatchlistNotFound: "No watchlist of yours has this ID.",
+		server.ReasonItemNotFound:      "The movie is not in the watchlist.",
+		server.ReasonWatchlistFull:     "The watchlist has as many movies as allowed, remove one first.",
+		server.ReasonInvalidWatchlist:  "The name of a watchlist has 1 to 100 characters.",
+		server.ReasonInvalidOrder:      "The new order must list every movie of the watchlist once.",
+		server.ReasonInvalidDate:       "The date is malformed, it looks like 2021-09-04.",
+		server.ReasonPermissionDenied:  "Only moderators are allowed to do this.",
+		server.ReasonReviewNotFound:    "No review matches the request.",
+		server.ReasonInvalidRating:     "The rating must be a whole number from 1 to 10.",
+		server.ReasonInvalidReview:     "A review or moderation note has up to 1000 characters.",
+		server.ReasonInvalidState:      "The state of a review is published or hidden.",
+		server.ReasonInvalidImport:     "The file is not a CSV exported from Letterboxd or IMDb.",
+		server.ReasonImportTooLarge:    "The file has more rows than an import allows.",
+		"":                             "An unexpected error occurred.",
+	},
+	language.Indonesian: {
+		server.ReasonMissingSearchword: "Harap isi parameter searchword.",
+		server.ReasonMissingQuery:      "Harap isi parameter query.",
+		server.ReasonMissingPrefix:     "Harap isi parameter prefix.",
+		server.ReasonInvalidSearchword: "Searchword harus paling banyak 200 karakter dan tanpa karakter kontrol.",
+		server.ReasonInvalidImdbID:     "Format IMDb ID tidak valid, contohnya tt0371746.",
+		server.ReasonMovieNotFound:     "Tidak ada film yang sesuai dengan permintaan.",
+		server.ReasonQuotaExceeded:     "Batas permintaan OMDb telah tercapai, coba lagi nanti.",
+		server.ReasonUpstreamError:     "OMDb tidak dapat menjawab permintaan.",
+		server.ReasonUpstreamTimeout:   "OMDb terlalu lama merespons.",
+		server.ReasonUnauthenticated:   "Diperlukan bearer token yang valid.",
+		server.ReasonTokenExpired:      "Bearer token sudah kedaluwarsa, silakan masuk kembali.",
+		server.ReasonWatchlistNotFound: "Tidak ada watchlist Anda dengan ID ini.",
+		server.ReasonItemNotFound:      "Film tidak ada di dalam watchlist.",
+		server.ReasonWatchlistFull:     "Watchlist sudah mencapai jumlah film maksimum, hapus salah satu terlebih dahulu.",
+		server.ReasonInvalidWatchlist:  "Nama watchlist terdiri dari 1 sampai 100 karakter.",
+		server.ReasonInvalidOrder:      "Urutan baru harus mencantumkan setiap film di watchlist tepat satu kali.",
+		server.ReasonInvalidDate:       "Format tanggal tidak valid, contohnya 2021-09-04.",
+		server.ReasonPermissionDenied:  "Hanya moderator yang diizinkan melakukan ini.",
+		server.ReasonReviewNotFound:    "Tidak ada ulasan yang sesuai dengan permintaan.",
+		server.ReasonInvalidRating:     "Rating harus berupa bilangan bulat dari 1 sampai 10.",
+		server.ReasonInvalidReview:     "Ulasan atau catatan moderasi terdiri dari paling banyak 1000 karakter.",
+		server.ReasonInvalidState:      "Status ulasan adalah published atau hidden.",
+		server.ReasonInvalidImport:     "Berkas bukan CSV yang diekspor dari Letterboxd atau IMDb.",
+		server.ReasonImportTooLarge:    "Berkas memiliki lebih banyak baris daripada yang diizinkan untuk impor.",
+		"":                             "Terjadi kesalahan yang tidak terduga.",
+	},
+}
+
+// acceptedLanguage picks the translation matching the Accept-Language header of r
+func acceptedLanguage(r *http.Request) language.Tag {
+	tags, _, _ := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	_, index, _ := matcher.Match(tags...)
+
+	return languages[index]
+}
+
+func message(tag language.Tag, reason string) string {
+	return messages[tag][reason]
+}
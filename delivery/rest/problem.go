@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	server "github.com/zenkobert/sbtest-2/delivery/grpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase prefixes the type URI of every problem, the URIs are stable identifiers clients can switch on
+const problemTypeBase = "/problems/"
+
+// Problem is an RFC 7807 problem details body, extended with the request ID, the GRPC status, a retry hint
+// and the searchwords a search finding nothing may have meant
+type Problem struct {
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	Status     int    `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	Instance   string `json:"instance,omitempty"`
+	RequestID  string `json:"request_id,omitempty"`
+	Code       string `json:"code"`
+	Reason     string `json:"reason,omitempty"`
+	RetryAfter int64  `json:"retry_after,omitempty"`
+
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+type problemType struct {
+	slug   string
+	title  string
+	status int
+}
+
+// problemTypes maps the reasons the GRPC service attaches to its errors to problem types
+var problemTypes = map[string]problemType{
+	server.ReasonMissingSearchword: {"missing-searchword", "Missing searchword", http.StatusBadRequest},
+	server.ReasonMissingQuery:      {"missing-query", "Missing query", http.StatusBadRequest},
+	server.ReasonMissingPrefix:     {"missing-prefix", "Missing prefix", http.StatusBadRequest},
+	server.ReasonInvalidSearchword: {"invalid-searchword", "Invalid searchword", http.StatusBadRequest},
+	server.ReasonInvalidImdbID:     {"invalid-imdb-id", "Invalid IMDb ID", http.StatusBadRequest},
+	server.ReasonMovieNotFound:     {"movie-not-found", "Movie not found", http.StatusNotFound},
+	server.ReasonQuotaExceeded:     {"quota-exceeded", "OMDb quota exceeded", http.StatusTooManyRequests},
+	server.ReasonUpstreamError:     {"upstream-error", "OMDb error", http.StatusBadGateway},
+	server.ReasonUpstreamTimeout:   {"upstream-timeout", "OMDb timeout", http.StatusGatewayTimeout},
+	server.ReasonUnauthenticated:   {"unauthenticated", "Unauthenticated", http.StatusUnauthorized},
+	server.ReasonTokenExpired:      {"token-expired", "Token expired", http.StatusUnauthorized},
+	server.ReasonWatchlistNotFound: {"watchlist-not-found", "Watchlist not found", http.StatusNotFound},
+	server.ReasonItemNotFound:      {"item-not-found", "Movie not in the watchlist", http.StatusNotFound},
+	server.ReasonWatchlistFull:     {"watchlist-full", "Watchlist full", http.StatusConflict},
+	server.ReasonInvalidWatchlist:  {"invalid-watchlist", "Invalid watchlist name", http.StatusBadRequest},
+	server.ReasonInvalidOrder:      {"invalid-order", "Invalid order", http.StatusBadRequest},
+	server.ReasonInvalidDate:       {"invalid-date", "Invalid date", http.StatusBadRequest},
+	server.ReasonPermissionDenied:  {"permission-denied", "Permission denied", http.StatusForbidden},
+	server.ReasonReviewNotFound:    {"review-not-found", "Review not found", http.StatusNotFound},
+	server.ReasonInvalidRating:     {"invalid-rating", "Invalid rating", http.StatusBadRequest},
+	server.ReasonInvalidReview:     {"invalid-review", "Invalid review", http.StatusBadRequest},
+	server.ReasonInvalidState:      {"invalid-review-state", "Invalid review state", http.StatusBadRequest},
+	server.ReasonInvalidImport:     {"invalid-import", "Invalid import", http.StatusBadRequest},
+	server.ReasonImportTooLarge:    {"import-too-large", "Import too large", http.StatusBadRequest},
+}
+
+// ErrorHandler writes errors of the gateway as problem+json, meant for runtime.WithErrorHandler.
+// Errors without a reason the service knows get the about:blank type and a status derived from their GRPC code
+func ErrorHandler(ctx context.Context, mux *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	problem, retryAfter := NewProblem(r, err)
+
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for key, values := range md.HeaderMD {
+			for _, value := range values {
+				w.Header().Add(fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), value)
+			}
+		}
+	}
+
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("Content-Language", acceptedLanguage(r).String())
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+	}
+	if problem.Status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+
+	w.WriteHeader(problem.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		log.Println(err)
+	}
+}
+
+// NewProblem describes err in the language the client of r accepts, along with the seconds it should wait
+// before retrying or 0 when there is no hint
+func NewProblem(r *http.Request, err error) (problem *Problem, retryAfter int64) {
+	httpStatus := 0
+	var statusErr *runtime.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		// routing errors, 404 and 405 from the gateway
+		httpStatus, err = statusErr.HTTPStatus, statusErr.Err
+	}
+
+	st := status.Convert(err)
+	problem = &Problem{
+		Type:      "about:blank",
+		Instance:  r.URL.RequestURI(),
+		RequestID: RequestIDFromContext(r.Context()),
+		Code:      st.Code().String(),
+	}
+
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if detail.Domain == server.ErrorDomain {
+				problem.Reason = detail.Reason
+			}
+		case *errdetails.RetryInfo:
+			retryAfter = int64(math.Ceil(detail.RetryDelay.AsDuration().Seconds()))
+		case *server.SearchSuggestions:
+			problem.Suggestions = detail.Suggestions
+		}
+	}
+
+	if typ, ok := problemTypes[problem.Reason]; ok {
+		problem.Type = problemTypeBase + typ.slug
+		problem.Title = typ.title
+		problem.Status = typ.status
+		problem.Detail = message(acceptedLanguage(r), problem.Reason)
+	} else {
+		if httpStatus == 0 {
+			httpStatus = runtime.HTTPStatusFromCode(st.Code())
+		}
+		problem.Status = httpStatus
+		problem.Title = http.StatusText(httpStatus)
+		problem.Detail = st.Message()
+		if httpStatus >= http.StatusInternalServerError || st.Code() == codes.Unknown {
+			// the message of an unexpected error is meant for our logs, not for clients
+			problem.Detail = message(acceptedLanguage(r), "")
+		}
+	}
+	problem.RetryAfter = retryAfter
+
+	return problem, retryAfter
+}
@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	server "github.com/zenkobert/sbtest-2/delivery/grpc"
+	model "github.com/zenkobert/sbtest-2/domain"
+	mock "github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+// gateway serves the REST API the way main does, on top of a server using usecase
+func gateway(t testing.TB, usecase model.MovieUsecase) http.Handler {
+	gwmux := runtime.NewServeMux(ServeMuxOptions()...)
+	err := server.RegisterSearchMovieHandlerServer(context.Background(), gwmux, server.NewMovieServer(usecase))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return RequestID(gwmux)
+}
+
+func failingSearch(err error) *mock.MovieUsecase {
+	movieUsecaseMock := &mock.MovieUsecase{}
+	movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(nil, err)
+
+	return movieUsecaseMock
+}
+
+func problemOf(t *testing.T, handler http.Handler, req *http.Request) (*httptest.ResponseRecorder, Problem) {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var problem Problem
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+
+	return rec, problem
+}
+
+func TestErrorHandler(t *testing.T) {
+	t.Run("[ErrorHandler] validation error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/movies", nil)
+		req.Header.Set(RequestIDHeader, "req-1")
+
+		rec, problem := problemOf(t, gateway(t, &mock.MovieUsecase{}), req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, Problem{
+			Type:      "/problems/missing-searchword",
+			Title:     "Missing searchword",
+			Status:    http.StatusBadRequest,
+			Detail:    "Please specify a searchword parameter.",
+			Instance:  "/v1/movies",
+			RequestID: "req-1",
+			Code:      "InvalidArgument",
+			Reason:    server.ReasonMissingSearchword,
+		}, problem)
+		assert.Equal(t, "req-1", rec.Header().Get(RequestIDHeader))
+		assert.Equal(t, "en", rec.Header().Get("Content-Language"))
+	})
+
+	t.Run("[ErrorHandler] movie not found", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(&model.MovieDetail{Error: "Incorrect IMDb ID."}, nil)
+
+		rec, problem := problemOf(t, gateway(t, movieUsecaseMock), httptest.NewRequest(http.MethodGet, "/v1/movies/tt0000001", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "/problems/movie-not-found", problem.Type)
+	})
+
+	t.Run("[ErrorHandler] searches finding nothing suggest searchwords", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{Error: "Movie not found!", Suggestions: []string{"iron man"}}, nil)
+
+		rec, problem := problemOf(t, gateway(t, movieUsecaseMock), httptest.NewRequest(http.MethodGet, "/v1/movies?searchword=iron+mann", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "/problems/movie-not-found", problem.Type)
+		assert.Equal(t, []string{"iron man"}, problem.Suggestions)
+	})
+
+	t.Run("[ErrorHandler] unauthenticated calls are challenged", func(t *testing.T) {
+		watchlistUsecaseMock := &mock.WatchlistUsecase{}
+		watchlistUsecaseMock.On("Watchlists", testify.Anything).Return(nil, model.ErrUnauthenticated)
+		gwmux := runtime.NewServeMux(ServeMuxOptions()...)
+		server.RegisterWatchlistHandlerServer(context.Background(), gwmux, server.NewWatchlistServer(watchlistUsecaseMock, &mock.ImportUsecase{}))
+
+		rec, problem := problemOf(t, gwmux, httptest.NewRequest(http.MethodGet, "/v1/watchlists", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+		assert.Equal(t, "/problems/unauthenticated", problem.Type)
+	})
+
+	t.Run("[ErrorHandler] quota errors carry a retry hint", func(t *testing.T) {
+		rec, problem := problemOf(t, gateway(t, failingSearch(model.ErrQuotaExceeded)), httptest.NewRequest(http.MethodGet, "/v1/movies?searchword=iron", nil))
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "/problems/quota-exceeded", problem.Type)
+		assert.Equal(t, "ResourceExhausted", problem.Code)
+		assert.Equal(t, int64(3600), problem.RetryAfter)
+		assert.Equal(t, "3600", rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("[ErrorHandler] OMDb errors are bad gateways", func(t *testing.T) {
+		rec, problem := problemOf(t, gateway(t, failingSearch(model.ErrUpstream)), httptest.NewRequest(http.MethodGet, "/v1/movies?searchword=iron", nil))
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		assert.Equal(t, "/problems/upstream-error", problem.Type)
+		assert.Empty(t, rec.Header().Get("Retry-After"))
+
+		rec, problem = problemOf(t, gateway(t, failingSearch(context.DeadlineExceeded)), httptest.NewRequest(http.MethodGet, "/v1/movies?searchword=iron", nil))
+		assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+		assert.Equal(t, "/problems/upstream-timeout", problem.Type)
+	})
+
+	t.Run("[ErrorHandler] unexpected errors don't expose their message", func(t *testing.T) {
+		rec, problem := problemOf(t, gateway(t, failingSearch(errors.New("dial tcp 10.0.0.1:443: refused"))), httptest.NewRequest(http.MethodGet, "/v1/movies?searchword=iron", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "about:blank", problem.Type)
+		assert.Equal(t, "Internal Server Error", problem.Title)
+		assert.Equal(t, "An unexpected error occurred.", problem.Detail)
+		assert.Empty(t, problem.Reason)
+		assert.NotEmpty(t, problem.RequestID)
+	})
+
+	t.Run("[ErrorHandler] routing errors", func(t *testing.T) {
+		rec, problem := problemOf(t, gateway(t, &mock.MovieUsecase{}), httptest.NewRequest(http.MethodGet, "/v1/series", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "about:blank", problem.Type)
+		assert.Equal(t, "Not Found", problem.Title)
+		assert.Equal(t, "NotFound", problem.Code)
+	})
+
+	t.Run("[ErrorHandler] details follow Accept-Language", func(t *testing.T) {
+		testCases := map[string]string{
+			"id-ID,id;q=0.9,en;q=0.8": "Harap isi parameter searchword.",
+			"fr-FR, en;q=0.5":         "Please specify a searchword parameter.",
+			"de":                      "Please specify a searchword parameter.",
+			"":                        "Please specify a searchword parameter.",
+		}
+
+		for header, detail := range testCases {
+			req := httptest.NewRequest(http.MethodGet, "/v1/movies", nil)
+			req.Header.Set("Accept-Language", header)
+
+			_, problem := problemOf(t, gateway(t, &mock.MovieUsecase{}), req)
+			assert.Equal(t, detail, problem.Detail, "Accept-Language: %s", header)
+			// titles are stable identifiers, they're not translated
+			assert.Equal(t, "Missing searchword", problem.Title)
+		}
+	})
+}
+
+func TestMessages(t *testing.T) {
+	t.Run("[messages] every reason is translated in every language", func(t *testing.T) {
+		for _, tag := range languages {
+			for reason := range problemTypes {
+				assert.NotEmpty(t, message(tag, reason), "%s %s", tag, reason)
+			}
+			assert.NotEmpty(t, message(tag, ""), "%s unexpected error", tag)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	mock "github.com/zenkobert/sbtest-2/domain/mocks"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestID(t *testing.T) {
+	serve := func(header string) (seen string, rec *httptest.ResponseRecorder) {
+		handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = RequestIDFromContext(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set(RequestIDHeader, header)
+		}
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return seen, rec
+	}
+
+	t.Run("[RequestID] reuses the client ID", func(t *testing.T) {
+		seen, rec := serve("3f2a-b1.c_9")
+		assert.Equal(t, "3f2a-b1.c_9", seen)
+		assert.Equal(t, "3f2a-b1.c_9", rec.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("[RequestID] generates one when missing or invalid", func(t *testing.T) {
+		for _, header := range []string{"", "has space", "new\nline", strings.Repeat("a", 65)} {
+			seen, rec := serve(header)
+			assert.Len(t, seen, 32)
+			assert.NotEqual(t, header, seen)
+			assert.Equal(t, seen, rec.Header().Get(RequestIDHeader))
+		}
+
+		first, _ := serve("")
+		second, _ := serve("")
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("[RequestIDFromContext] empty without the middleware", func(t *testing.T) {
+		assert.Empty(t, RequestIDFromContext(context.Background()))
+	})
+
+	t.Run("[ServeMuxOptions] the ID is forwarded to the GRPC service", func(t *testing.T) {
+		movieUsecaseMock := &mock.MovieUsecase{}
+		movieUsecaseMock.On("SearchMovies", testify.MatchedBy(func(ctx context.Context) bool {
+			md, _ := metadata.FromIncomingContext(ctx)
+			return len(md.Get(requestIDMetadata)) == 1 && md.Get(requestIDMetadata)[0] == "req-42"
+		}), testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/movies?searchword=iron", nil)
+		req.Header.Set(RequestIDHeader, "req-42")
+		rec := httptest.NewRecorder()
+		gateway(t, movieUsecaseMock).ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		movieUsecaseMock.AssertExpectations(t)
+	})
+}
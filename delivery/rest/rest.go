@@ -0,0 +1,31 @@
+// Package rest holds what the REST gateway adds on top of the GRPC service: problem+json errors, request IDs,
+// HTTP caching, compression and export formats
+package rest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+// ServeMuxOptions configure the gateway to answer errors with problem+json, export CSV and NDJSON, forward
+// the request ID to the GRPC service and turn the freshness of its answers into caching headers
+func ServeMuxOptions() []runtime.ServeMuxOption {
+	return []runtime.ServeMuxOption{
+		runtime.WithErrorHandler(ErrorHandler),
+		runtime.WithMarshalerOption(MIMECSV, CSVMarshaler{}),
+		runtime.WithMarshalerOption(MIMENDJSON, NDJSONMarshaler{}),
+		runtime.WithForwardResponseOption(CacheHeaders),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+		runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+			id := RequestIDFromContext(r.Context())
+			if id == "" {
+				return nil
+			}
+
+			return metadata.Pairs(requestIDMetadata, id)
+		}),
+	}
+}
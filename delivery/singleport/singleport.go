@@ -0,0 +1,169 @@
+package singleport
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/soheilhy/cmux"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+)
+
+// Server serves GRPC and REST on the same listener. A connection goes to GRPC when it speaks
+// HTTP/2 with an application/grpc content type, everything else (HTTP/1.1 and plain HTTP/2) goes to REST
+type Server struct {
+	GRPC *grpc.Server
+	REST *http.Server
+	// TLSConfig terminates TLS in front of both servers, h2 and http/1.1 are negotiated with ALPN.
+	// Connections are plaintext (h2c for GRPC) when nil
+	TLSConfig *tls.Config
+}
+
+func NewServer(grpcServer *grpc.Server, restServer *http.Server, tlsConfig *tls.Config) *Server {
+	return &Server{
+		GRPC:      grpcServer,
+		REST:      restServer,
+		TLSConfig: tlsConfig,
+	}
+}
+
+// Serve blocks until the listener is closed, which happens on REST.Shutdown or GRPC.GracefulStop,
+// whichever comes first. Errors caused by that close are not reported
+func (s *Server) Serve(listener net.Listener) error {
+	if s.TLSConfig != nil {
+		listener = tls.NewListener(listener, s.TLSConfig)
+	}
+
+	// every matched listener closes the root one, REST alone closes it twice on Shutdown
+	mux := cmux.New(&onceCloseListener{Listener: listener, once: &sync.Once{}})
+	// grpc-go clients wait for the server SETTINGS frame before sending headers
+	grpcListener := mux.MatchWithWriters(cmux.HTTP2MatchHeaderFieldPrefixSendSettings("content-type", "application/grpc"))
+	h2RestListener := &settingsAckFilterListener{mux.Match(cmux.HTTP2())}
+	restListener := mux.Match(cmux.Any())
+
+	// TLS is already terminated, h2 REST clients arrive as prior knowledge HTTP/2
+	s.REST.Handler = h2c.NewHandler(s.REST.Handler, &http2.Server{})
+
+	g := &errgroup.Group{}
+	g.Go(func() error {
+		return ignoreClosed(s.GRPC.Serve(grpcListener))
+	})
+	g.Go(func() error {
+		return ignoreClosed(s.REST.Serve(restListener))
+	})
+	g.Go(func() error {
+		return ignoreClosed(s.REST.Serve(h2RestListener))
+	})
+	g.Go(func() error {
+		return ignoreClosed(mux.Serve())
+	})
+
+	return g.Wait()
+}
+
+// frameHeaderLen is the size of an HTTP/2 frame header, RFC 7540 section 4.1
+const frameHeaderLen = 9
+
+func ignoreClosed(err error) error {
+	switch {
+	case err == nil,
+		err == http.ErrServerClosed,
+		err == cmux.ErrServerClosed,
+		errors.Is(err, cmux.ErrListenerClosed),
+		errors.Is(err, net.ErrClosed):
+		return nil
+	}
+
+	return err
+}
+
+type onceCloseListener struct {
+	net.Listener
+	once *sync.Once
+	err  error
+}
+
+func (l *onceCloseListener) Close() error {
+	l.once.Do(func() {
+		l.err = l.Listener.Close()
+	})
+
+	return l.err
+}
+
+// settingsAckFilterListener hands out HTTP/2 connections that turned out not to be GRPC.
+// While sniffing them for GRPC, cmux answered the client SETTINGS with a SETTINGS frame of its own,
+// the REST server never sent that frame and closes the connection with a PROTOCOL_ERROR
+// when the client acknowledges it, so that acknowledgement is dropped on the way in
+type settingsAckFilterListener struct {
+	net.Listener
+}
+
+func (l *settingsAckFilterListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return &settingsAckFilterConn{Conn: conn, acks: 1}, nil
+}
+
+type settingsAckFilterConn struct {
+	net.Conn
+	// acks left to drop, clients send a single SETTINGS frame before their first request
+	acks    int
+	preface bool
+	pending []byte
+}
+
+func (c *settingsAckFilterConn) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 && c.acks > 0 {
+		err := c.nextFrame()
+		if err != nil {
+			return 0, err
+		}
+	}
+	if len(c.pending) > 0 {
+		n := copy(p, c.pending)
+		c.pending = c.pending[n:]
+		return n, nil
+	}
+
+	return c.Conn.Read(p)
+}
+
+// nextFrame reads the client preface or the next frame into pending, unless it is the SETTINGS ACK to drop
+func (c *settingsAckFilterConn) nextFrame() error {
+	if !c.preface {
+		c.pending = make([]byte, len(http2.ClientPreface))
+		_, err := io.ReadFull(c.Conn, c.pending)
+		c.preface = true
+		return err
+	}
+
+	header := make([]byte, frameHeaderLen)
+	_, err := io.ReadFull(c.Conn, header)
+	if err != nil {
+		return err
+	}
+
+	length := int(header[0])<<16 | int(header[1])<<8 | int(header[2])
+	frameType, flags := http2.FrameType(header[3]), http2.Flags(header[4])
+	if frameType == http2.FrameSettings && flags.Has(http2.FlagSettingsAck) && length == 0 {
+		c.acks--
+		return nil
+	}
+
+	frame := make([]byte, frameHeaderLen+length)
+	copy(frame, header)
+	_, err = io.ReadFull(c.Conn, frame[frameHeaderLen:])
+	c.pending = frame
+
+	return err
+}
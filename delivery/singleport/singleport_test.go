@@ -0,0 +1,188 @@
+package singleport
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// selfSigned returns a server certificate for 127.0.0.1 and a pool trusting it
+func selfSigned(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, pool
+}
+
+// start serves a GRPC health service and a REST handler answering its protocol on one port
+func start(t *testing.T, tlsConfig *tls.Config) (s *Server, addr string, served chan error) {
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	restServer := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("rest " + r.Proto))
+		}),
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s = NewServer(grpcServer, restServer, tlsConfig)
+	served = make(chan error, 1)
+	go func() {
+		served <- s.Serve(listener)
+	}()
+
+	return s, listener.Addr().String(), served
+}
+
+func get(t *testing.T, client *http.Client, url string) string {
+	resp, err := client.Get(url)
+	if !assert.Nil(t, err) {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+
+	return string(body)
+}
+
+func checkHealth(t *testing.T, addr string, opts ...grpc.DialOption) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, opts...)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if assert.Nil(t, err) {
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	}
+}
+
+func TestServe(t *testing.T) {
+	t.Run("[Serve] plaintext GRPC, HTTP/1.1 and h2c REST on one port", func(t *testing.T) {
+		s, addr, _ := start(t, nil)
+		defer s.GRPC.Stop()
+		defer s.REST.Close()
+
+		checkHealth(t, addr, grpc.WithInsecure())
+		assert.Equal(t, "rest HTTP/1.1", get(t, &http.Client{}, "http://"+addr+"/"))
+
+		h2c := &http.Client{Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		}}
+		assert.Equal(t, "rest HTTP/2.0", get(t, h2c, "http://"+addr+"/"))
+	})
+
+	t.Run("[Serve] REST over HTTP/2 keeps its connection", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		log.SetOutput(out)
+		defer log.SetOutput(os.Stderr)
+
+		s, addr, _ := start(t, nil)
+		defer s.GRPC.Stop()
+		defer s.REST.Close()
+
+		dials := 0
+		h2c := &http.Client{Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				dials++
+				return net.Dial(network, addr)
+			},
+		}}
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, "rest HTTP/2.0", get(t, h2c, "http://"+addr+"/"))
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		assert.Equal(t, 1, dials)
+		assert.NotContains(t, out.String(), "PROTOCOL_ERROR")
+	})
+
+	t.Run("[Serve] TLS with ALPN", func(t *testing.T) {
+		cert, pool := selfSigned(t)
+		s, addr, _ := start(t, &tls.Config{Certificates: []tls.Certificate{cert}, NextProtos: []string{"h2", "http/1.1"}})
+		defer s.GRPC.Stop()
+		defer s.REST.Close()
+
+		checkHealth(t, addr, grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(pool, "")))
+
+		http1 := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+		assert.Equal(t, "rest HTTP/1.1", get(t, http1, "https://"+addr+"/"))
+
+		h2 := &http.Client{Transport: &http2.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+		assert.Equal(t, "rest HTTP/2.0", get(t, h2, "https://"+addr+"/"))
+	})
+
+	t.Run("[Serve] returns without error on shutdown", func(t *testing.T) {
+		s, addr, served := start(t, nil)
+		checkHealth(t, addr, grpc.WithInsecure())
+
+		assert.Nil(t, s.REST.Shutdown(context.Background()))
+		s.GRPC.GracefulStop()
+
+		select {
+		case err := <-served:
+			assert.Nil(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Serve did not return")
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"context"
+	"math"
+)
+
+// Completion is a title completing what is being typed in a search box
+type Completion struct {
+	Title string
+	// ImdbID, Year and Type are empty for searchwords of the search log that are no title known
+	ImdbID string
+	Year   string
+	Type   string
+	// Popularity ranks the completions of a prefix, see TitlePopularity
+	Popularity float64
+}
+
+// TitlePopularity is how popular a movie with votes IMDb votes rated rating out of 10 is, more votes count
+// less and less
+func TitlePopularity(votes int, rating float64) float64 {
+	return math.Log10(1+float64(votes)) * rating / 10
+}
+
+// TitleCompleter completes the beginning of titles, ignoring case, accents and punctuation
+type TitleCompleter interface {
+	// Add learns completions, a title known already gets the popularity of the most popular movie having it
+	// plus the one of the searchwords, the completions without IMDb ID
+	Add(ctx context.Context, completions ...Completion)
+	// Complete returns up to max titles starting with prefix, the most popular first. max is capped by
+	// the completer, which also uses its cap when max is 0
+	Complete(ctx context.Context, prefix string, max int) []Completion
+}
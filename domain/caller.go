@@ -0,0 +1,17 @@
+package model
+
+import "context"
+
+type callerKey struct{}
+
+// WithCaller returns a context telling who made the call, caller is the user ID the call was authenticated with
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFrom returns who made the call, false when it wasn't authenticated
+func CallerFrom(ctx context.Context) (string, bool) {
+	caller, ok := ctx.Value(callerKey{}).(string)
+
+	return caller, ok && caller != ""
+}
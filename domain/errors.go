@@ -0,0 +1,60 @@
+package model
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrQuotaExceeded is returned once the OMDb api key used up its daily request limit
+	ErrQuotaExceeded = errors.New("OMDb request limit reached")
+	// ErrUpstream is returned when OMDb answers with an error status
+	ErrUpstream = errors.New("oops, something happened")
+)
+
+// IsTimeout tells whether err comes from a request to OMDb that didn't finish in time
+func IsTimeout(err error) bool {
+	var timeout interface{ Timeout() bool }
+
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &timeout) && timeout.Timeout()
+}
+
+var (
+	// ErrUnauthenticated is returned when the caller of a call needing one is unknown
+	ErrUnauthenticated = errors.New("the caller is not authenticated")
+	// ErrWatchlistNotFound is returned for watchlists that don't exist or belong to another user
+	ErrWatchlistNotFound = errors.New("watchlist not found")
+	// ErrWatchlistFull is returned when adding to a watchlist that has as many items as allowed
+	ErrWatchlistFull = errors.New("watchlist is full")
+	// ErrItemNotFound is returned for movies the watchlist doesn't have
+	ErrItemNotFound = errors.New("movie is not in the watchlist")
+	// ErrMovieNotFound is returned when adding a movie OMDb doesn't know
+	ErrMovieNotFound = errors.New("movie not found")
+	// ErrInvalidWatchlist is returned for a watchlist name that is empty or too long
+	ErrInvalidWatchlist = errors.New("invalid watchlist name")
+	// ErrInvalidOrder is returned when a new order doesn't list every item of the watchlist once
+	ErrInvalidOrder = errors.New("the order must list every item of the watchlist once")
+	// ErrInvalidDate is returned for a watched day that isn't formatted as WatchedOnLayout
+	ErrInvalidDate = errors.New("invalid date")
+)
+
+var (
+	// ErrReviewNotFound is returned for reviews that don't exist, or are hidden from the caller
+	ErrReviewNotFound = errors.New("review not found")
+	// ErrInvalidRating is returned for a rating outside of 1 to 10
+	ErrInvalidRating = errors.New("invalid rating")
+	// ErrInvalidReview is returned for a review text that is too long
+	ErrInvalidReview = errors.New("invalid review text")
+	// ErrInvalidReviewState is returned when moderating a review to a state that doesn't exist
+	ErrInvalidReviewState = errors.New("invalid review state")
+	// ErrPermissionDenied is returned when the caller is known but not allowed to make the call
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+var (
+	// ErrInvalidImport is returned for an import in an unknown format or that isn't a valid CSV, it is wrapped
+	// with what is wrong
+	ErrInvalidImport = errors.New("invalid import")
+	// ErrImportTooLarge is returned for an import with more rows than allowed
+	ErrImportTooLarge = errors.New("import has too many rows")
+)
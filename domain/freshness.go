@@ -0,0 +1,30 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Freshness tells when the answer of a repository was fetched from OMDb and how long it stays fresh
+type Freshness struct {
+	FetchedAt time.Time
+	MaxAge    time.Duration
+}
+
+type freshnessKey struct{}
+
+// WithFreshness returns a context repositories record the freshness of their answer into,
+// the returned Freshness stays zero when none of them knows it
+func WithFreshness(ctx context.Context) (context.Context, *Freshness) {
+	freshness := &Freshness{}
+
+	return context.WithValue(ctx, freshnessKey{}, freshness), freshness
+}
+
+// RecordFreshness stores the freshness of the answer served for ctx, it does nothing unless the caller asked for it
+func RecordFreshness(ctx context.Context, fetchedAt time.Time, maxAge time.Duration) {
+	if freshness, ok := ctx.Value(freshnessKey{}).(*Freshness); ok {
+		freshness.FetchedAt = fetchedAt
+		freshness.MaxAge = maxAge
+	}
+}
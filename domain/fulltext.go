@@ -0,0 +1,35 @@
+package model
+
+import "context"
+
+type (
+	// FullTextHit is a movie matching a full-text search, Score is its BM25 relevance and Fields
+	// the fields of the detail the words searched were found in, by their JSON name in the API
+	FullTextHit struct {
+		Movie  SearchDetail
+		Score  float64
+		Fields []string
+	}
+
+	// FullTextResult is one page of the movies matching a full-text search, best first
+	FullTextResult struct {
+		Hits []FullTextHit
+		// Total counts the movies matching on every page
+		Total int
+	}
+)
+
+// MovieIndex finds movies by the words of their title, plot, actors, director and genre. It only knows
+// the movies whose detail was indexed, the ones fetched so far
+type MovieIndex interface {
+	// Index adds the detail of a movie, replacing the one indexed before for the same IMDb ID
+	Index(ctx context.Context, detail *MovieDetail) error
+	// Search returns the page-th page of the movies having words of query, which also match the
+	// words they start
+	Search(ctx context.Context, query string, page uint32, filter SearchFilter) (*FullTextResult, error)
+	// Similar returns up to max movies like detail, the most similar first, leaving out detail itself. Only
+	// the movies sharing a genre or a person with it are similar, a release or a rating alone isn't enough
+	Similar(ctx context.Context, detail *MovieDetail, weights SimilarityWeights, max int) ([]SimilarMovie, error)
+	// Flush saves the movies indexed since the last flush
+	Flush(ctx context.Context) error
+}
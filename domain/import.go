@@ -0,0 +1,61 @@
+package model
+
+import "context"
+
+// formats of the watch histories users import
+const (
+	ImportLetterboxd = "letterboxd"
+	ImportIMDb       = "imdb"
+)
+
+type (
+	// ImportRequest is an exported watch history in Format, ImportLetterboxd or ImportIMDb
+	ImportRequest struct {
+		// Format is detected from the header of the CSV when empty
+		Format  string
+		Content []byte
+		// Unwatched tells the movies are to watch, as in watchlist exports, rather than watched ones
+		Unwatched bool
+		// WatchlistID is the watchlist the movies go to, a new one named WatchlistName is created when empty
+		WatchlistID   string
+		WatchlistName string
+	}
+
+	// ImportRow is a movie of a watch history, Number is its position in the CSV, 1 for the row after the header
+	ImportRow struct {
+		Number int
+		Title  string
+		Year   int
+		ImdbID string
+		// Rating is from 1 to 10, 0 when the movie isn't rated
+		Rating int
+		// WatchedOn is the day the movie was watched, as WatchedOnLayout, empty when unknown or not watched
+		WatchedOn string
+	}
+
+	// ImportIssue tells why a row wasn't imported, or only partly. Candidates are the movies an ambiguous row may be
+	ImportIssue struct {
+		Row        ImportRow
+		Reason     string
+		Candidates []SearchDetail
+	}
+
+	ImportResult struct {
+		WatchlistID string
+		// Rows counts the rows of the history, Added the movies added to the watchlist, Watched the ones marked
+		// as watched and Rated the ones rated
+		Rows    int
+		Added   int
+		Watched int
+		Rated   int
+		// Unmatched rows match no movie, Ambiguous ones several, Failed ones matched a movie that couldn't be saved
+		Unmatched []ImportIssue
+		Ambiguous []ImportIssue
+		Failed    []ImportIssue
+	}
+)
+
+// ImportUsecase imports the watch history of the caller of ctx into one of their watchlists and their reviews
+type ImportUsecase interface {
+	ImportHistory(ctx context.Context, req ImportRequest) (*ImportResult, error)
+}
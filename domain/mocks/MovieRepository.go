@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 	model "github.com/zenkobert/sbtest-2/domain"
 )
@@ -12,13 +14,13 @@ type MovieRepository struct {
 	mock.Mock
 }
 
-// GetMovieDetailByID provides a mock function with given fields: id
-func (_m *MovieRepository) GetMovieDetailByID(id string) (*model.MovieDetail, error) {
-	ret := _m.Called(id)
+// GetMovieDetailByID provides a mock function with given fields: ctx, id
+func (_m *MovieRepository) GetMovieDetailByID(ctx context.Context, id string) (*model.MovieDetail, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 *model.MovieDetail
-	if rf, ok := ret.Get(0).(func(string) *model.MovieDetail); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.MovieDetail); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.MovieDetail)
@@ -26,8 +28,8 @@ func (_m *MovieRepository) GetMovieDetailByID(id string) (*model.MovieDetail, er
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -35,13 +37,13 @@ func (_m *MovieRepository) GetMovieDetailByID(id string) (*model.MovieDetail, er
 	return r0, r1
 }
 
-// SearchMovies provides a mock function with given fields: title, page
-func (_m *MovieRepository) SearchMovies(title string, page uint32) (*model.MovieSearch, error) {
-	ret := _m.Called(title, page)
+// SearchMovies provides a mock function with given fields: ctx, title, page, filter
+func (_m *MovieRepository) SearchMovies(ctx context.Context, title string, page uint32, filter model.SearchFilter) (*model.MovieSearch, error) {
+	ret := _m.Called(ctx, title, page, filter)
 
 	var r0 *model.MovieSearch
-	if rf, ok := ret.Get(0).(func(string, uint32) *model.MovieSearch); ok {
-		r0 = rf(title, page)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint32, model.SearchFilter) *model.MovieSearch); ok {
+		r0 = rf(ctx, title, page, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.MovieSearch)
@@ -49,8 +51,8 @@ func (_m *MovieRepository) SearchMovies(title string, page uint32) (*model.Movie
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint32) error); ok {
-		r1 = rf(title, page)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint32, model.SearchFilter) error); ok {
+		r1 = rf(ctx, title, page, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 	model "github.com/zenkobert/sbtest-2/domain"
 )
@@ -12,13 +14,59 @@ type MovieUsecase struct {
 	mock.Mock
 }
 
-// GetMovieDetailByID provides a mock function with given fields: id
-func (_m *MovieUsecase) GetMovieDetailByID(id string) (*model.MovieDetail, error) {
-	ret := _m.Called(id)
+// Autocomplete provides a mock function with given fields: ctx, prefix, max
+func (_m *MovieUsecase) Autocomplete(ctx context.Context, prefix string, max int) ([]model.Completion, error) {
+	ret := _m.Called(ctx, prefix, max)
+
+	var r0 []model.Completion
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []model.Completion); ok {
+		r0 = rf(ctx, prefix, max)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Completion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, prefix, max)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FullTextSearch provides a mock function with given fields: ctx, query, page, filter
+func (_m *MovieUsecase) FullTextSearch(ctx context.Context, query string, page uint32, filter model.SearchFilter) (*model.FullTextResult, error) {
+	ret := _m.Called(ctx, query, page, filter)
+
+	var r0 *model.FullTextResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint32, model.SearchFilter) *model.FullTextResult); ok {
+		r0 = rf(ctx, query, page, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FullTextResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint32, model.SearchFilter) error); ok {
+		r1 = rf(ctx, query, page, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMovieDetailByID provides a mock function with given fields: ctx, id
+func (_m *MovieUsecase) GetMovieDetailByID(ctx context.Context, id string) (*model.MovieDetail, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 *model.MovieDetail
-	if rf, ok := ret.Get(0).(func(string) *model.MovieDetail); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.MovieDetail); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.MovieDetail)
@@ -26,8 +74,8 @@ func (_m *MovieUsecase) GetMovieDetailByID(id string) (*model.MovieDetail, error
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -49,13 +97,13 @@ func (_m *MovieUsecase) LogToDB(record string) error {
 	return r0
 }
 
-// SearchMovies provides a mock function with given fields: title, page
-func (_m *MovieUsecase) SearchMovies(title string, page uint32) (*model.MovieSearch, error) {
-	ret := _m.Called(title, page)
+// SearchMovies provides a mock function with given fields: ctx, title, page, filter
+func (_m *MovieUsecase) SearchMovies(ctx context.Context, title string, page uint32, filter model.SearchFilter) (*model.MovieSearch, error) {
+	ret := _m.Called(ctx, title, page, filter)
 
 	var r0 *model.MovieSearch
-	if rf, ok := ret.Get(0).(func(string, uint32) *model.MovieSearch); ok {
-		r0 = rf(title, page)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint32, model.SearchFilter) *model.MovieSearch); ok {
+		r0 = rf(ctx, title, page, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.MovieSearch)
@@ -63,8 +111,31 @@ func (_m *MovieUsecase) SearchMovies(title string, page uint32) (*model.MovieSea
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint32) error); ok {
-		r1 = rf(title, page)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint32, model.SearchFilter) error); ok {
+		r1 = rf(ctx, title, page, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SimilarMovies provides a mock function with given fields: ctx, id, max, excludeWatchlisted
+func (_m *MovieUsecase) SimilarMovies(ctx context.Context, id string, max int, excludeWatchlisted bool) ([]model.SimilarMovie, error) {
+	ret := _m.Called(ctx, id, max, excludeWatchlisted)
+
+	var r0 []model.SimilarMovie
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, bool) []model.SimilarMovie); ok {
+		r0 = rf(ctx, id, max, excludeWatchlisted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SimilarMovie)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, bool) error); ok {
+		r1 = rf(ctx, id, max, excludeWatchlisted)
 	} else {
 		r1 = ret.Error(1)
 	}
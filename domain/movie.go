@@ -1,5 +1,7 @@
 package model
 
+import "context"
+
 type (
 	SearchDetail struct {
 		Title  string `json:"Title"`
@@ -9,11 +11,21 @@ type (
 		Poster string `json:"Poster"`
 	}
 
+	// SearchFilter narrows a search down to one type of title or one release year, zero values match any
+	SearchFilter struct {
+		Type string // movie, series or episode
+		Year int
+	}
+
 	MovieSearch struct {
 		Search       []SearchDetail `json:"Search"`
 		TotalResults string         `json:"totalResults"`
 		Response     string         `json:"Response"`
 		Error        string         `json:"Error"`
+		// Suggestions are searchwords spelled like known titles, when the one searched found nothing.
+		// Corrected is the suggestion searched instead, if any
+		Suggestions []string `json:"-"`
+		Corrected   string   `json:"-"`
 	}
 
 	MovieRating struct {
@@ -45,18 +57,32 @@ type (
 		BoxOffice  string        `json:"BoxOffice"`
 		Production string        `json:"Production"`
 		Website    string        `json:"Website"`
-		Response   string        `json:"Response"`
-		Error      string        `json:"Error"`
+		// SeriesID, Season and Episode are only set for episodes
+		SeriesID string `json:"seriesID"`
+		Season   string `json:"Season"`
+		Episode  string `json:"Episode"`
+		Response string `json:"Response"`
+		Error    string `json:"Error"`
+		// Sources names the provider each field came from, by the JSON name of the field in the API,
+		// only when movies come from several providers
+		Sources map[string]string `json:"-"`
 	}
 )
 
 type MovieRepository interface {
-	SearchMovies(title string, page uint32) (result *MovieSearch, err error)
-	GetMovieDetailByID(id string) (detail *MovieDetail, err error)
+	SearchMovies(ctx context.Context, title string, page uint32, filter SearchFilter) (result *MovieSearch, err error)
+	GetMovieDetailByID(ctx context.Context, id string) (detail *MovieDetail, err error)
 }
 
 type MovieUsecase interface {
-	SearchMovies(title string, page uint32) (result *MovieSearch, err error)
-	GetMovieDetailByID(id string) (detail *MovieDetail, err error)
+	SearchMovies(ctx context.Context, title string, page uint32, filter SearchFilter) (result *MovieSearch, err error)
+	GetMovieDetailByID(ctx context.Context, id string) (detail *MovieDetail, err error)
+	// FullTextSearch finds movies by the words of their detail rather than their title, see MovieIndex
+	FullTextSearch(ctx context.Context, query string, page uint32, filter SearchFilter) (result *FullTextResult, err error)
+	// Autocomplete completes the beginning of a title being typed, see TitleCompleter
+	Autocomplete(ctx context.Context, prefix string, max int) (completions []Completion, err error)
+	// SimilarMovies returns up to max movies like the one of IMDb ID id, see MovieIndex.Similar. Where there
+	// are watchlists, excludeWatchlisted leaves out the movies in the ones of the caller, which is needed then
+	SimilarMovies(ctx context.Context, id string, max int, excludeWatchlisted bool) (movies []SimilarMovie, err error)
 	LogToDB(record string) error
 }
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CommunityRatingSource is the MovieRating source of the average rating users gave a movie
+const CommunityRatingSource = "Community"
+
+// moderation states of a review
+const (
+	// ReviewPublished reviews are listed and count towards the community rating
+	ReviewPublished = "published"
+	// ReviewHidden reviews were taken down by a moderator, only moderators see them
+	ReviewHidden = "hidden"
+)
+
+type (
+	// Review is the rating from 1 to 10 a user gave a movie, with an optional short text.
+	// A user has one review per movie
+	Review struct {
+		ID        string    `json:"id"`
+		ImdbID    string    `json:"imdb_id"`
+		Author    string    `json:"author"`
+		Rating    int       `json:"rating"`
+		Text      string    `json:"text,omitempty"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+
+		// State is ReviewPublished or ReviewHidden, the other fields tell who moderated the review last and why
+		State          string    `json:"state"`
+		ModeratedBy    string    `json:"moderated_by,omitempty"`
+		ModeratedAt    time.Time `json:"moderated_at"`
+		ModerationNote string    `json:"moderation_note,omitempty"`
+	}
+
+	// ReviewPage is one page of the reviews of a movie, with the community rating of all its published reviews
+	ReviewPage struct {
+		Reviews []*Review
+		// Total counts the reviews on every page
+		Total int
+		// Votes counts the published reviews, Average is their mean rating
+		Votes   int
+		Average float64
+	}
+)
+
+// CommunityRating is the MovieRating of average, the mean rating of the published reviews of a movie
+func CommunityRating(average float64) MovieRating {
+	return MovieRating{Source: CommunityRatingSource, Value: fmt.Sprintf("%.1f/10", average)}
+}
+
+type ReviewRepository interface {
+	// Upsert saves the changes change makes to the review with ID id, which is empty when there is none yet.
+	// Nothing is saved when change fails, upserts of the same review don't overlap
+	Upsert(ctx context.Context, id string, change func(review *Review) error) (*Review, error)
+	// Get returns ErrReviewNotFound for an unknown id
+	Get(ctx context.Context, id string) (*Review, error)
+	// Delete returns ErrReviewNotFound for an unknown id
+	Delete(ctx context.Context, id string) error
+	// ByMovie returns the reviews of the movie with IMDb ID imdbID, newest first
+	ByMovie(ctx context.Context, imdbID string) ([]*Review, error)
+}
+
+// ReviewUsecase manages the reviews of movies. Reviews are submitted and deleted by the caller of ctx, see
+// WithCaller, moderators may also hide them
+type ReviewUsecase interface {
+	// SubmitReview creates the review of the caller for the movie, or replaces its rating and text
+	SubmitReview(ctx context.Context, imdbID string, rating int, text string) (*Review, error)
+	// RateMovie sets the rating of the review of the caller for the movie, keeping its text
+	RateMovie(ctx context.Context, imdbID string, rating int) (*Review, error)
+	// DeleteReview deletes the review of the caller for the movie
+	DeleteReview(ctx context.Context, imdbID string) error
+	// Reviews returns the page-th page of the published reviews of the movie, includeHidden lists the hidden
+	// ones as well and is for moderators only
+	Reviews(ctx context.Context, imdbID string, page, pageSize int, includeHidden bool) (*ReviewPage, error)
+	// ModerateReview moves the review to state, note tells the author why
+	ModerateReview(ctx context.Context, id, state, note string) (*Review, error)
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MaxSearchwordLength is the longest searchword, in characters, no title is that long
+	MaxSearchwordLength = 200
+	// firstMovieYear is the year of the oldest movie known, years before it are part of the title
+	firstMovieYear = 1874
+	// announcedYears is how many years ahead movies are announced, later years are part of the title,
+	// Blade Runner 2049
+	announcedYears = 3
+)
+
+// ErrInvalidSearchword is returned for a searchword too long or with control characters, it is wrapped with
+// what is wrong
+var ErrInvalidSearchword = errors.New("invalid searchword")
+
+// NormalizeSearchword trims searchword and separates its words with single spaces, so the searchwords typed
+// differently search alike. It is empty when searchword has no words
+func NormalizeSearchword(searchword string) (string, error) {
+	for _, r := range searchword {
+		if r == utf8.RuneError || unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return "", fmt.Errorf("%w: it has the control character %U", ErrInvalidSearchword, r)
+		}
+	}
+
+	searchword = strings.Join(strings.Fields(searchword), " ")
+	if utf8.RuneCountInString(searchword) > MaxSearchwordLength {
+		return "", fmt.Errorf("%w: it is longer than %d characters", ErrInvalidSearchword, MaxSearchwordLength)
+	}
+
+	return searchword, nil
+}
+
+// SearchYear splits the release year ending searchword, Alien 1979 or Alien (1979), off its title. year is 0
+// when searchword doesn't end with a year or is only one, 1917
+func SearchYear(searchword string) (title string, year int) {
+	space := strings.LastIndexByte(searchword, ' ')
+	if space < 0 {
+		return searchword, 0
+	}
+
+	last := strings.TrimSuffix(strings.TrimPrefix(searchword[space+1:], "("), ")")
+	year, err := strconv.Atoi(last)
+	if err != nil || len(last) != 4 || year < firstMovieYear || year > time.Now().Year()+announcedYears {
+		return searchword, 0
+	}
+
+	return searchword[:space], year
+}
+
+// searchArticles are left out of the keys of the searchwords starting with them, so The Matrix and Matrix
+// share one
+var searchArticles = []string{"the ", "a ", "an "}
+
+// SearchKey folds the case and the accents of searchword, a normalized one, and drops the article it starts
+// with, the searchwords having the same key find the same movies
+func SearchKey(searchword string) string {
+	var folded strings.Builder
+	for _, r := range norm.NFD.String(searchword) {
+		if !unicode.Is(unicode.Mn, r) {
+			folded.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	key := folded.String()
+	for _, article := range searchArticles {
+		if strings.HasPrefix(key, article) && len(key) > len(article) {
+			return key[len(article):]
+		}
+	}
+
+	return key
+}
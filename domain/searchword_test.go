@@ -0,0 +1,29 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchKey(t *testing.T) {
+	t.Run("[SearchKey] case and accents folded", func(t *testing.T) {
+		assert.Equal(t, "amelie", SearchKey("Amélie"))
+		assert.Equal(t, SearchKey("AMÉLIE"), SearchKey("amelie"))
+	})
+
+	t.Run("[SearchKey] leading article dropped", func(t *testing.T) {
+		assert.Equal(t, "matrix", SearchKey("The Matrix"))
+		assert.Equal(t, SearchKey("Matrix"), SearchKey("the matrix"))
+		assert.Equal(t, "quiet place", SearchKey("A Quiet Place"))
+		assert.Equal(t, "american werewolf in london", SearchKey("An American Werewolf in London"))
+	})
+
+	t.Run("[SearchKey] articles only dropped as a leading word", func(t *testing.T) {
+		// a title that is only an article keeps it
+		assert.Equal(t, "the", SearchKey("The"))
+		assert.Equal(t, "theodore rex", SearchKey("Theodore Rex"))
+		assert.Equal(t, "annie hall", SearchKey("Annie Hall"))
+		assert.Equal(t, "lord of the rings", SearchKey("Lord of the Rings"))
+	})
+}
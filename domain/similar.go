@@ -0,0 +1,23 @@
+package model
+
+type (
+	// SimilarityWeights tell how much each thing two movies have in common counts in their similarity.
+	// Genres, Director, Writers and Cast weigh the share of the ones both have, Decade and Rating how close
+	// their release and their IMDb rating are
+	SimilarityWeights struct {
+		Genres   float64
+		Director float64
+		Writers  float64
+		Cast     float64
+		Decade   float64
+		Rating   float64
+	}
+
+	// SimilarMovie is a movie like another one, Shared tells what they have in common: genres, director,
+	// writers, cast, decade or rating
+	SimilarMovie struct {
+		Movie  SearchDetail
+		Score  float64
+		Shared []string
+	}
+)
@@ -0,0 +1,12 @@
+package model
+
+import "context"
+
+// TitleDictionary knows the words of movie titles, to tell how a searchword finding nothing may have been meant
+type TitleDictionary interface {
+	// Add learns the words of titles
+	Add(ctx context.Context, titles ...string)
+	// Suggest returns up to max searchwords spelled like the titles known, closest to searchword first.
+	// There are none when every word of searchword is known or close to none
+	Suggest(ctx context.Context, searchword string, max int) []string
+}
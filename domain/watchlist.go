@@ -0,0 +1,65 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// WatchedOnLayout is the layout of WatchlistItem.WatchedOn, a day without time zone
+const WatchedOnLayout = "2006-01-02"
+
+type (
+	// Watchlist is a list of movies its owner wants to watch, in the order they chose
+	Watchlist struct {
+		ID        string          `json:"id"`
+		Owner     string          `json:"owner"`
+		Name      string          `json:"name"`
+		CreatedAt time.Time       `json:"created_at"`
+		Items     []WatchlistItem `json:"items"`
+	}
+
+	WatchlistItem struct {
+		ImdbID  string    `json:"imdb_id"`
+		AddedAt time.Time `json:"added_at"`
+		// WatchedOn is the day the movie was watched, empty until it is
+		WatchedOn string `json:"watched_on,omitempty"`
+		// Movie is the detail of the movie, only filled when the items are listed
+		Movie *MovieDetail `json:"-"`
+	}
+)
+
+// Item returns the index of the item for the movie with IMDb ID imdbID, -1 when the list doesn't have it
+func (list *Watchlist) Item(imdbID string) int {
+	for i, item := range list.Items {
+		if item.ImdbID == imdbID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+type WatchlistRepository interface {
+	Create(ctx context.Context, list *Watchlist) error
+	// Get returns ErrWatchlistNotFound for an unknown id
+	Get(ctx context.Context, id string) (*Watchlist, error)
+	ListByOwner(ctx context.Context, owner string) ([]*Watchlist, error)
+	// Update saves the changes update makes to the watchlist with ID id, nothing is saved when it fails.
+	// Updates of the same watchlist don't overlap
+	Update(ctx context.Context, id string, update func(list *Watchlist) error) (*Watchlist, error)
+}
+
+// WatchlistUsecase manages the watchlists of the caller of ctx, see WithCaller. Watchlists of other users
+// are reported as not found
+type WatchlistUsecase interface {
+	CreateWatchlist(ctx context.Context, name string) (*Watchlist, error)
+	Watchlists(ctx context.Context) ([]*Watchlist, error)
+	// Items returns the watchlist with the details of its movies
+	Items(ctx context.Context, id string) (*Watchlist, error)
+	AddItem(ctx context.Context, id, imdbID string) (*Watchlist, error)
+	RemoveItem(ctx context.Context, id, imdbID string) (*Watchlist, error)
+	// ReorderItems puts the items in the order of imdbIDs, which must list every item once
+	ReorderItems(ctx context.Context, id string, imdbIDs []string) (*Watchlist, error)
+	// MarkWatched records the day the movie was watched, an empty watchedOn marks it as not watched
+	MarkWatched(ctx context.Context, id, imdbID, watchedOn string) (*Watchlist, error)
+}
@@ -1,4 +1,5 @@
 protoc delivery/grpc/movie.proto \
 --go_out=. \
 --go-grpc_out=require_unimplemented_servers=false:. \
---grpc-gateway_out=logtostderr=true:.
\ No newline at end of file
+--grpc-gateway_out=logtostderr=true:. \
+--openapiv2_out=logtostderr=true:.
\ No newline at end of file
@@ -2,98 +2,571 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"github.com/joho/godotenv"
+	"github.com/zenkobert/sbtest-2/common"
+	"github.com/zenkobert/sbtest-2/common/auth"
+	"github.com/zenkobert/sbtest-2/common/compression"
+	"github.com/zenkobert/sbtest-2/common/redact"
+	"github.com/zenkobert/sbtest-2/common/tlsconfig"
+	"github.com/zenkobert/sbtest-2/common/tracing"
+	"github.com/zenkobert/sbtest-2/config"
+	"github.com/zenkobert/sbtest-2/delivery/docs"
+	"github.com/zenkobert/sbtest-2/delivery/graphql"
 	server "github.com/zenkobert/sbtest-2/delivery/grpc"
+	"github.com/zenkobert/sbtest-2/delivery/health"
 	mw "github.com/zenkobert/sbtest-2/delivery/middleware"
+	"github.com/zenkobert/sbtest-2/delivery/rest"
+	"github.com/zenkobert/sbtest-2/delivery/singleport"
+	model "github.com/zenkobert/sbtest-2/domain"
 	repo "github.com/zenkobert/sbtest-2/repository"
 	usecase "github.com/zenkobert/sbtest-2/usecase"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 )
 
-var (
-	grpcPort, restPort, apiKey string
+const (
+	exitOK           = 0
+	exitServeError   = 1
+	exitDrainTimeout = 2
+	exitConfigError  = 3
 )
 
-func init() {
-	err := godotenv.Load("shouldnotbeuploaded.env")
-	if err != nil {
-		log.Fatalln(err)
-	}
+// inProcessBufferSize is the in-memory buffer between the gateway and the GRPC server in single port mode
+const inProcessBufferSize = 1 << 20
 
-	grpcPort = getEnvVariable("GRPC_PORT")
-	restPort = getEnvVariable("REST_PORT")
-	apiKey = getEnvVariable("API_KEY")
+var errDrainTimeout = errors.New("drain timeout exceeded, remaining connections were closed")
+
+type flusher interface {
+	Flush(ctx context.Context) error
 }
 
 func main() {
-	g := errgroup.Group{}
-	g.Go(func() error {
-		return startGrpcServer()
-	})
-	g.Go(func() error {
-		return startRestServer()
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	log.SetOutput(redact.NewWriter(os.Stderr))
+
+	cfg, err := config.Load(args, os.LookupEnv, os.Stderr)
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	if cfg != nil && cfg.PrintConfig {
+		cfg.Print(os.Stdout)
+	}
+	if err != nil {
+		log.Println(err)
+		return exitConfigError
+	}
+	if cfg.PrintConfig {
+		return exitOK
+	}
+	redact.Register(cfg.OMDb.APIKey, cfg.TMDb.APIKey, cfg.Auth.JWTSecret)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		ServiceName:  "search-movie",
 	})
+	if err != nil {
+		log.Println(err)
+		return exitServeError
+	}
+	defer shutdownTracing(context.Background())
+
+	providers, err := movieProviders(cfg)
+	if err != nil {
+		log.Println(err)
+		return exitConfigError
+	}
+	providerRepo, movieDependency := providers[0].Repo, providers[0].Name
+	if len(providers) > 1 {
+		providerRepo, err = repo.NewCompositeMovieRepo(providers, cfg.Precedence())
+		if err != nil {
+			log.Println(err)
+			return exitConfigError
+		}
+		movieDependency = "movies"
+	}
 
-	err := g.Wait()
+	// every detail fetched, whatever for, goes to the full-text index
+	movieIndex, err := repo.NewFullTextIndex(cfg.FullText.File)
 	if err != nil {
-		log.Fatal(err)
+		log.Println(err)
+		return exitConfigError
 	}
-}
+	indexedRepo := repo.NewIndexedMovieRepo(providerRepo, movieIndex)
 
-func startGrpcServer() error {
-	movieRepo := repo.NewMovieRepo(apiKey)
-	movieDB := repo.NewMovieDB("search.log")
-	movieUsecase := usecase.NewMovieUsecase(movieRepo, &movieDB)
-	movieServer := server.NewMovieServer(movieUsecase)
+	// the dataset is in memory already, only the answers of remote providers are worth caching and throttling
+	remote := false
+	for _, name := range cfg.Movies.Providers {
+		remote = remote || name != config.ProviderDataset
+	}
+	movieRepo := indexedRepo
+	if cfg.Cache.Enabled && remote {
+		movieRepo = repo.NewCachedMovieRepo(indexedRepo, cfg.Cache.TTL, cfg.Cache.MaxEntries)
+	}
+	movieDB := repo.NewMovieDB(cfg.Log.SearchLogFile)
+	// titles are completed from the dataset and the searches logged often enough, then from the movies found
+	completer := repo.NewTitleCompleter(cfg.Autocomplete.Limit)
+	for _, provider := range providers {
+		repo.AddDatasetCompletions(ctx, completer, provider.Repo)
+	}
+	if err := repo.AddSearchLogCompletions(ctx, completer, cfg.Log.SearchLogFile); err != nil {
+		log.Println(err)
+	}
+	movieUsecase := usecase.NewMovieUsecase(movieRepo, &movieDB, movieIndex, completer, model.SimilarityWeights{
+		Genres:   cfg.Similar.GenresWeight,
+		Director: cfg.Similar.DirectorWeight,
+		Writers:  cfg.Similar.WritersWeight,
+		Cast:     cfg.Similar.CastWeight,
+		Decade:   cfg.Similar.DecadeWeight,
+		Rating:   cfg.Similar.RatingWeight,
+	})
+	// misspelled searches get suggestions from the titles of the dataset, the searches logged often enough
+	// and the movies found since
+	if cfg.Suggest.Max > 0 {
+		dictionary := repo.NewTitleDictionary()
+		for _, provider := range providers {
+			repo.LearnDatasetTitles(ctx, dictionary, provider.Repo)
+		}
+		if err := repo.LearnSearchLog(ctx, dictionary, cfg.Log.SearchLogFile); err != nil {
+			log.Println(err)
+		}
+		movieUsecase = usecase.WithSuggestions(movieUsecase, dictionary, cfg.Suggest.Max, cfg.Suggest.Retry)
+	}
 	interceptor := mw.NewInterceptor(movieUsecase)
+	// watchlist and review calls are logged and traced even when their token is rejected, listing
+	// reviews needs no token
+	authenticator := mw.NewAuthenticator(auth.NewVerifier(cfg.Auth.JWTSecret),
+		"/"+server.Watchlist_ServiceDesc.ServiceName+"/",
+		"/"+server.Review_ServiceDesc.ServiceName+"/SubmitReview",
+		"/"+server.Review_ServiceDesc.ServiceName+"/DeleteReview",
+		"/"+server.Review_ServiceDesc.ServiceName+"/ModerateReview",
+	)
+	unary := mw.Chain(interceptor.Unary, authenticator.Unary)
 
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(interceptor.Unary))
-	server.RegisterSearchMovieServer(grpcServer, movieServer)
+	checker := health.NewChecker(cfg.Health.Interval, cfg.Health.Timeout)
+	// with several providers each one is reported, the services only need one of them up so only the
+	// composite of them gates readiness
+	if providerChecker, ok := providerRepo.(common.HealthChecker); ok {
+		checker.AddDependency(movieDependency, providerChecker)
+	}
+	for _, provider := range providers {
+		if providerChecker, ok := provider.Repo.(common.HealthChecker); ok && len(providers) > 1 {
+			checker.AddInformational(provider.Name, providerChecker)
+		}
+	}
+	checker.AddDependency("search-log", &movieDB)
+	checker.AddService(server.SearchMovie_ServiceDesc.ServiceName, movieDependency, "search-log")
+
+	// without a secret to verify tokens with, nobody could own a watchlist or a review
+	var servers services
+	if cfg.AuthEnabled() {
+		watchlistRepo, err := repo.NewWatchlistFileRepo(cfg.Watchlist.File)
+		if err != nil {
+			log.Println(err)
+			return exitConfigError
+		}
+		reviewRepo, err := repo.NewReviewFileRepo(cfg.Review.File)
+		if err != nil {
+			log.Println(err)
+			return exitConfigError
+		}
+
+		// imports look movies up at their own pace, through a cache of their own so a big one doesn't
+		// evict what the other calls cached
+		importRepo := movieRepo
+		if remote {
+			importRepo = repo.NewThrottledMovieRepo(indexedRepo, cfg.Import.LookupsPerSecond)
+			if cfg.Cache.Enabled {
+				importRepo = repo.NewCachedMovieRepo(importRepo, cfg.Cache.TTL, cfg.Import.MaxRows)
+			}
+		}
+		importUsecase := usecase.NewImportUsecase(ctx, importRepo,
+			usecase.NewWatchlistUsecase(watchlistRepo, importRepo),
+			usecase.NewReviewUsecase(reviewRepo, importRepo, cfg.Auth.Moderators),
+			cfg.Import.MaxRows)
+		servers.watchlist = server.NewWatchlistServer(usecase.NewWatchlistUsecase(watchlistRepo, movieRepo), importUsecase)
 
-	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+		if storeChecker, ok := watchlistRepo.(common.HealthChecker); ok {
+			checker.AddDependency("watchlist-store", storeChecker)
+		}
+		checker.AddService(server.Watchlist_ServiceDesc.ServiceName, movieDependency, "watchlist-store")
+
+		servers.review = server.NewReviewServer(usecase.NewReviewUsecase(reviewRepo, movieRepo, cfg.Auth.Moderators))
+		movieUsecase = usecase.WithCommunityRatings(movieUsecase, reviewRepo)
+		movieUsecase = usecase.WithWatchlistExclusion(movieUsecase, watchlistRepo)
+
+		if storeChecker, ok := reviewRepo.(common.HealthChecker); ok {
+			checker.AddDependency("review-store", storeChecker)
+		}
+		checker.AddService(server.Review_ServiceDesc.ServiceName, movieDependency, "review-store")
+	}
+	servers.movie = server.NewMovieServer(movieUsecase)
+
+	var tlsManager *tlsconfig.Manager
+	if cfg.TLSEnabled() {
+		tlsManager, err = tlsconfig.NewManager(tlsconfig.Options{
+			CertFile:     cfg.TLS.CertFile,
+			KeyFile:      cfg.TLS.KeyFile,
+			ClientCAFile: cfg.TLS.ClientCAFile,
+			ClientAuth:   cfg.TLS.ClientAuth,
+			CAFile:       cfg.TLS.CAFile,
+			ServerName:   cfg.TLS.ServerName,
+		})
+		if err != nil {
+			log.Println(err)
+			return exitConfigError
+		}
+	}
+
+	// the gateway connection must outlive the signal so in-flight REST requests can drain
+	gatewayCtx, cancelGateway := context.WithCancel(context.Background())
+	defer cancelGateway()
+
+	var grpcServer *grpc.Server
+	if cfg.SinglePort() {
+		// TLS is terminated in front of the multiplexer, the GRPC server itself speaks plaintext
+		grpcServer = newGrpcServer(cfg, nil, servers, unary, interceptor.Stream, checker)
+	} else {
+		grpcServer = newGrpcServer(cfg, tlsManager, servers, unary, interceptor.Stream, checker)
+	}
+
+	// the GRPC server answers with the compressor of the request
+	var gatewayOpts []grpc.DialOption
+	if cfg.REST.GatewayCompression != compression.None {
+		gatewayOpts = append(gatewayOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(cfg.REST.GatewayCompression)))
+	}
+
+	var gateway registerGateway
+	var inProcess *bufconn.Listener
+	switch {
+	case cfg.REST.Gateway == config.GatewayDirect:
+		gateway = directGateway(servers, unary)
+	case cfg.SinglePort():
+		inProcess = bufconn.Listen(inProcessBufferSize)
+		gateway = dialGateway("in-process", servers, append(gatewayOpts,
+			grpc.WithInsecure(),
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return inProcess.DialContext(ctx)
+			}),
+		)...)
+	default:
+		transport := grpc.WithInsecure()
+		if tlsManager != nil {
+			transport = grpc.WithTransportCredentials(credentials.NewTLS(tlsManager.ClientConfig()))
+		}
+		gateway = dialGateway(fmt.Sprintf("127.0.0.1:%s", cfg.GRPC.Port), servers, append(gatewayOpts, transport)...)
+	}
+
+	restServer, err := newRestServer(gatewayCtx, cfg, gateway, movieUsecase, interceptor.Record, checker)
 	if err != nil {
 		log.Println(err)
+		return exitServeError
+	}
+	if tlsManager != nil && !cfg.SinglePort() {
+		restServer.TLSConfig = tlsManager.ServerConfig("h2", "http/1.1")
 	}
 
-	log.Printf("GRPC Server Started. Listening to port %s", grpcPort)
-	return grpcServer.Serve(listener)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		checker.Run(gctx)
+		return nil
+	})
+	g.Go(func() error {
+		repo.FlushEvery(gctx, movieIndex, cfg.FullText.FlushInterval)
+		return nil
+	})
+	if tlsManager != nil {
+		g.Go(func() error {
+			tlsManager.Watch(gctx, cfg.TLS.ReloadInterval)
+			return nil
+		})
+	}
+	if cfg.SinglePort() {
+		g.Go(func() error {
+			listener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.Listen.Port))
+			if err != nil {
+				return err
+			}
+
+			var tlsConfig *tls.Config
+			if tlsManager != nil {
+				tlsConfig = tlsManager.ServerConfig("h2", "http/1.1")
+			}
+
+			log.Printf("GRPC and REST HTTP Server Started. Listening to port %s", cfg.Listen.Port)
+			return singleport.NewServer(grpcServer, restServer, tlsConfig).Serve(listener)
+		})
+		if inProcess != nil {
+			g.Go(func() error {
+				// the gateway reaches the GRPC server through this listener
+				return grpcServer.Serve(inProcess)
+			})
+		}
+	} else {
+		g.Go(func() error {
+			listener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPC.Port))
+			if err != nil {
+				return err
+			}
+
+			log.Printf("GRPC Server Started. Listening to port %s", cfg.GRPC.Port)
+			return grpcServer.Serve(listener)
+		})
+		g.Go(func() error {
+			// Start HTTP server (and proxy calls to gRPC server endpoint)
+			log.Printf("REST HTTP Server Started. Listening to port %s", cfg.REST.Port)
+			var err error
+			if tlsManager != nil {
+				// certificates come from restServer.TLSConfig
+				err = restServer.ListenAndServeTLS("", "")
+			} else {
+				err = restServer.ListenAndServe()
+			}
+			if err == http.ErrServerClosed {
+				return nil
+			}
+
+			return err
+		})
+	}
+	g.Go(func() error {
+		// either a signal arrived or one of the servers failed
+		<-gctx.Done()
+		return shutdown(grpcServer, restServer, checker, cfg.Shutdown.Timeout, &interceptor, movieIndex)
+	})
+
+	err = g.Wait()
+	switch {
+	case err == errDrainTimeout:
+		log.Println(err)
+		return exitDrainTimeout
+	case err != nil:
+		log.Println(err)
+		return exitServeError
+	}
+
+	log.Println("Servers stopped gracefully")
+	return exitOK
 }
 
-func startRestServer() error {
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
+// shutdown drains both servers within timeout: readiness goes to NOT_SERVING first
+// so load balancers stop routing here, then in-flight requests are given the chance to finish
+// and flushers, such as the pending search log records, to be written
+func shutdown(grpcServer *grpc.Server, restServer *http.Server, checker *health.Checker, timeout time.Duration, flushers ...flusher) error {
+	log.Printf("Shutting down, draining requests for up to %s", timeout)
+	checker.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	mux := runtime.NewServeMux()
-	err := server.RegisterSearchMovieHandlerFromEndpoint(
-		ctx,
-		mux,
-		fmt.Sprintf("127.0.0.1:%s", grpcPort),
-		[]grpc.DialOption{grpc.WithInsecure()},
-	)
-	if err != nil {
+	timedOut := false
+	if err := restServer.Shutdown(ctx); err != nil {
 		log.Println(err)
-		return err
+		restServer.Close()
+		timedOut = true
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+		timedOut = true
+	}
+
+	for _, f := range flushers {
+		if err := f.Flush(ctx); err != nil {
+			log.Println(err)
+			timedOut = true
+		}
+	}
+
+	if timedOut {
+		return errDrainTimeout
+	}
+
+	return nil
+}
+
+// movieProviders builds the repositories of movies.providers, in their order
+func movieProviders(cfg *config.Config) ([]repo.MovieProvider, error) {
+	providers := make([]repo.MovieProvider, 0, len(cfg.Movies.Providers))
+	for _, name := range cfg.Movies.Providers {
+		var movieRepo model.MovieRepository
+		switch name {
+		case config.ProviderOMDb:
+			movieRepo = repo.NewMovieRepo(cfg.OMDb.BaseURL, cfg.OMDb.APIKey, cfg.OMDb.Timeout)
+		case config.ProviderTMDb:
+			movieRepo = repo.NewTMDbMovieRepo(cfg.TMDb.BaseURL, cfg.TMDb.APIKey, cfg.TMDb.ImageBaseURL, cfg.TMDb.Timeout)
+		case config.ProviderDataset:
+			var err error
+			movieRepo, err = repo.NewDatasetRepo(cfg.Dataset.File)
+			if err != nil {
+				return nil, err
+			}
+		}
+		providers = append(providers, repo.MovieProvider{Name: name, Repo: movieRepo})
+	}
+
+	return providers, nil
+}
+
+// services are the servers of the GRPC services, the ones that are nil are not served
+type services struct {
+	movie     server.SearchMovieServer
+	watchlist server.WatchlistServer
+	review    server.ReviewServer
+}
+
+func newGrpcServer(cfg *config.Config, tlsManager *tlsconfig.Manager, servers services, unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor, checker *health.Checker) *grpc.Server {
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream)}
+	if tlsManager != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsManager.ServerConfig("h2"))))
 	}
 
-	// Start HTTP server (and proxy calls to gRPC server endpoint)
-	log.Printf("REST HTTP Server Started. Listening to port %s", restPort)
-	return http.ListenAndServe(fmt.Sprintf(":%s", restPort), mux)
+	grpcServer := grpc.NewServer(opts...)
+	server.RegisterSearchMovieServer(grpcServer, servers.movie)
+	if servers.watchlist != nil {
+		server.RegisterWatchlistServer(grpcServer, servers.watchlist)
+	}
+	if servers.review != nil {
+		server.RegisterReviewServer(grpcServer, servers.review)
+	}
+	healthpb.RegisterHealthServer(grpcServer, checker.Server)
+	if cfg.GRPC.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	return grpcServer
 }
 
-func getEnvVariable(key string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		log.Panicf("Can't find env variable : %s\n", key)
+// registerGateway wires the REST gateway handlers to the GRPC service
+type registerGateway func(ctx context.Context, gwmux *runtime.ServeMux) error
+
+// dialGateway proxies REST calls to the GRPC server at endpoint, dialed with dialOpts.
+// The services share the connection, only the ones servers has are proxied
+func dialGateway(endpoint string, servers services, dialOpts ...grpc.DialOption) registerGateway {
+	return func(ctx context.Context, gwmux *runtime.ServeMux) error {
+		conn, err := grpc.DialContext(ctx, endpoint, append(dialOpts, grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor))...)
+		if err != nil {
+			return err
+		}
+		go func() {
+			<-ctx.Done()
+			if err := conn.Close(); err != nil {
+				log.Println(err)
+			}
+		}()
+
+		if err := server.RegisterSearchMovieHandler(ctx, gwmux, conn); err != nil {
+			return err
+		}
+		if servers.watchlist != nil {
+			if err := server.RegisterWatchlistHandler(ctx, gwmux, conn); err != nil {
+				return err
+			}
+		}
+		if servers.review != nil {
+			return server.RegisterReviewHandler(ctx, gwmux, conn)
+		}
+
+		return nil
+	}
+}
+
+// directGateway calls the servers in-process, running the same interceptor as the GRPC server.
+// There is no network hop and nothing to dial at startup, but GRPC transport features
+// such as compression or stats handlers don't apply to REST calls
+func directGateway(servers services, unary grpc.UnaryServerInterceptor) registerGateway {
+	return func(ctx context.Context, gwmux *runtime.ServeMux) error {
+		if err := server.RegisterSearchMovieHandlerServer(ctx, gwmux, server.NewInterceptedMovieServer(servers.movie, unary)); err != nil {
+			return err
+		}
+		if servers.watchlist != nil {
+			if err := server.RegisterWatchlistHandlerServer(ctx, gwmux, server.NewInterceptedWatchlistServer(servers.watchlist, unary)); err != nil {
+				return err
+			}
+		}
+		if servers.review != nil {
+			return server.RegisterReviewHandlerServer(ctx, gwmux, server.NewInterceptedReviewServer(servers.review, unary))
+		}
+
+		return nil
+	}
+}
+
+func newRestServer(ctx context.Context, cfg *config.Config, register registerGateway, movieUsecase model.MovieUsecase, record func(string), checker *health.Checker) (*http.Server, error) {
+	gwmux := runtime.NewServeMux(rest.ServeMuxOptions()...)
+	err := register(ctx, gwmux)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", checker.LivenessHandler())
+	mux.Handle("/readyz", checker.ReadinessHandler())
+	compress := func(h http.Handler) http.Handler {
+		if len(cfg.RESTEncodings()) == 0 {
+			return h
+		}
+		return rest.Compress(h, cfg.RESTEncodings(), cfg.REST.CompressionMinSize)
+	}
+	mux.Handle("/openapi.json", compress(docs.SpecHandler(server.OpenAPISpec)))
+	mux.Handle("/docs", compress(docs.ExplorerHandler()))
+	if cfg.GraphQL.Enabled {
+		graphqlHandler, err := graphql.NewHandler(movieUsecase, graphql.Options{
+			MaxDepth:      cfg.GraphQL.MaxDepth,
+			MaxComplexity: cfg.GraphQL.MaxComplexity,
+			Record:        record,
+		})
+		if err != nil {
+			return nil, err
+		}
+		mux.Handle("/graphql", compress(graphqlHandler))
+	}
+	// the ETag is computed on the compressed body, each encoding is a representation of its own
+	mux.Handle("/", rest.Conditional(compress(rest.Negotiate(gwmux))))
+
+	var handler http.Handler = mux
+	if cfg.CORSEnabled() {
+		handler = rest.CORS(handler, rest.CORSOptions{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedMethods:   cfg.CORS.AllowedMethods,
+			AllowedHeaders:   cfg.CORS.AllowedHeaders,
+			ExposedHeaders:   cfg.CORS.ExposedHeaders,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           cfg.CORS.MaxAge,
+		})
 	}
 
-	return value
+	return &http.Server{
+		Addr:    fmt.Sprintf(":%s", cfg.REST.Port),
+		Handler: tracing.NewHandler(rest.RequestID(handler)),
+	}, nil
 }
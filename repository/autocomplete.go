@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// datasetCompletionMinVotes is how many IMDb votes a title of the dataset needs to complete searches,
+	// the others would take memory for titles hardly anybody looks for
+	datasetCompletionMinVotes = 100
+	// searchLogVotes is how many IMDb votes rating a title 10 a search of it counts like
+	searchLogVotes = 100
+)
+
+// completionArticles are left out of a second key of the titles starting with them, so The Dark Knight
+// completes dark as well
+var completionArticles = []string{"the ", "a ", "an "}
+
+// completionEntry is a title of the completer. Its completion has the popularity of the most popular
+// movie having the title, searches the one of the searchwords that are the title
+type completionEntry struct {
+	completion model.Completion
+	searches   float64
+}
+
+func (entry *completionEntry) popularity() float64 {
+	return entry.completion.Popularity + entry.searches
+}
+
+// merge learns completion, a completion of the title of entry. Popularities only grow
+func (entry *completionEntry) merge(completion model.Completion) {
+	switch {
+	case completion.ImdbID == "":
+		entry.searches += completion.Popularity
+		if entry.completion.Title == "" {
+			entry.completion.Title = completion.Title
+		}
+	case entry.completion.ImdbID == "" || completion.Popularity > entry.completion.Popularity:
+		entry.completion = completion
+	}
+}
+
+// better tells whether a ranks before b
+func (entry *completionEntry) better(other *completionEntry) bool {
+	if entry.popularity() != other.popularity() {
+		return entry.popularity() > other.popularity()
+	}
+	return entry.completion.Title < other.completion.Title
+}
+
+// completionNode is a node of a radix trie of folded titles, edge is the part of the key it adds to
+// its parent's. top caches the most popular entries of the keys below, so a prefix is completed
+// without walking its subtree
+type completionNode struct {
+	edge     string
+	children []*completionNode
+	top      []*completionEntry
+}
+
+func (node *completionNode) child(first byte) *completionNode {
+	for _, child := range node.children {
+		if child.edge[0] == first {
+			return child
+		}
+	}
+	return nil
+}
+
+// rank puts entry, which became more popular, in the top entries of node, of up to size entries
+func (node *completionNode) rank(entry *completionEntry, size int) {
+	i := len(node.top) - 1
+	for ; i >= 0 && node.top[i] != entry; i-- {
+	}
+	if i < 0 {
+		switch {
+		case len(node.top) < size:
+			node.top = append(node.top, entry)
+		case entry.better(node.top[size-1]):
+			node.top[size-1] = entry
+		default:
+			return
+		}
+		i = len(node.top) - 1
+	}
+
+	for ; i > 0 && node.top[i].better(node.top[i-1]); i-- {
+		node.top[i], node.top[i-1] = node.top[i-1], node.top[i]
+	}
+}
+
+// titleCompleter completes the titles of a radix trie of their folded keys, see foldTitle
+type titleCompleter struct {
+	mutex *sync.RWMutex
+	// size is how many completions a prefix has at most
+	size    int
+	root    *completionNode
+	entries map[string]*completionEntry
+}
+
+// NewTitleCompleter returns a completer knowing no title yet, completing prefixes with up to size titles
+func NewTitleCompleter(size int) model.TitleCompleter {
+	return &titleCompleter{
+		mutex:   &sync.RWMutex{},
+		size:    size,
+		root:    &completionNode{},
+		entries: map[string]*completionEntry{},
+	}
+}
+
+func (completer *titleCompleter) Add(ctx context.Context, completions ...model.Completion) {
+	completer.mutex.Lock()
+	defer completer.mutex.Unlock()
+
+	for _, completion := range completions {
+		key := foldTitle(completion.Title)
+		if key == "" {
+			continue
+		}
+
+		entry, ok := completer.entries[key]
+		if !ok {
+			entry = &completionEntry{}
+			completer.entries[key] = entry
+		}
+		entry.merge(completion)
+
+		// the space ending the keys lets a prefix ending with one complete a whole title as well
+		completer.rank(key+" ", entry)
+		for _, article := range completionArticles {
+			if strings.HasPrefix(key, article) && len(key) > len(article) {
+				completer.rank(key[len(article):]+" ", entry)
+			}
+		}
+	}
+}
+
+// rank ranks entry in the nodes from the root to the one of key, adding the nodes missing
+func (completer *titleCompleter) rank(key string, entry *completionEntry) {
+	node := completer.root
+	node.rank(entry, completer.size)
+	for key != "" {
+		child := node.child(key[0])
+		if child == nil {
+			child = &completionNode{edge: key}
+			node.children = append(node.children, child)
+		}
+
+		common := 0
+		for common < len(key) && common < len(child.edge) && key[common] == child.edge[common] {
+			common++
+		}
+		if common < len(child.edge) {
+			// the key leaves the edge midway, the part they share becomes a node of its own
+			split := &completionNode{
+				edge:     child.edge[:common],
+				children: []*completionNode{child},
+				top:      append([]*completionEntry{}, child.top...),
+			}
+			child.edge = child.edge[common:]
+			for i := range node.children {
+				if node.children[i] == child {
+					node.children[i] = split
+				}
+			}
+			child = split
+		}
+
+		node, key = child, key[common:]
+		node.rank(entry, completer.size)
+	}
+}
+
+// Complete completes prefix word by word: iron m completes Iron Man, a prefix ending with a space or
+// punctuation only completes whole words
+func (completer *titleCompleter) Complete(ctx context.Context, prefix string, max int) []model.Completion {
+	if max <= 0 || max > completer.size {
+		max = completer.size
+	}
+	completions := []model.Completion{}
+
+	key := foldTitle(prefix)
+	if key == "" {
+		return completions
+	}
+	if last, _ := utf8.DecodeLastRuneInString(prefix); !unicode.IsLetter(last) && !unicode.IsDigit(last) && !unicode.Is(unicode.Mn, last) {
+		key += " "
+	}
+
+	completer.mutex.RLock()
+	defer completer.mutex.RUnlock()
+
+	node := completer.root
+	for key != "" {
+		child := node.child(key[0])
+		if child == nil {
+			return completions
+		}
+		if strings.HasPrefix(child.edge, key) {
+			node = child
+			break
+		}
+		if !strings.HasPrefix(key, child.edge) {
+			return completions
+		}
+		node, key = child, key[len(child.edge):]
+	}
+
+	for _, entry := range node.top[:min(max, len(node.top))] {
+		completion := entry.completion
+		completion.Popularity = entry.popularity()
+		completions = append(completions, completion)
+	}
+
+	return completions
+}
+
+// foldTitle is the key of title in the completer: lower case words of letters and digits without their
+// accents, separated by one space
+func foldTitle(title string) string {
+	var key strings.Builder
+	separated := false
+	for _, r := range norm.NFD.String(title) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// an accent, apart from its letter once decomposed
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if separated && key.Len() > 0 {
+				key.WriteByte(' ')
+			}
+			separated = false
+			key.WriteRune(unicode.ToLower(r))
+		default:
+			separated = true
+		}
+	}
+
+	return key.String()
+}
+
+// AddDatasetCompletions adds the movies and series of the dataset movieRepo answers from having at least
+// datasetCompletionMinVotes votes to completer, the other repositories have none to add
+func AddDatasetCompletions(ctx context.Context, completer model.TitleCompleter, movieRepo model.MovieRepository) {
+	dataset, ok := movieRepo.(*datasetRepo)
+	if !ok {
+		return
+	}
+
+	completions := []model.Completion{}
+	for i := range dataset.titles {
+		title := &dataset.titles[i]
+		titleType := datasetTypes[title.Type]
+		if titleType != "movie" && titleType != "series" || title.Votes < datasetCompletionMinVotes {
+			continue
+		}
+		completions = append(completions, model.Completion{
+			Title:      title.Title,
+			ImdbID:     datasetID("tt", title.ID),
+			Year:       datasetYear(title),
+			Type:       titleType,
+			Popularity: model.TitlePopularity(int(title.Votes), float64(title.Rating)/10),
+		})
+	}
+	completer.Add(ctx, completions...)
+}
+
+// AddSearchLogCompletions adds the searchwords of the search log fileName searched at least searchLogMinCount
+// times to completer, more popular the more they were searched. A search log that doesn't exist yet has none
+func AddSearchLogCompletions(ctx context.Context, completer model.TitleCompleter, fileName string) error {
+	counts, err := searchLogCounts(fileName)
+	if err != nil {
+		return err
+	}
+
+	searchwords := []string{}
+	for searchword, count := range counts {
+		if count >= searchLogMinCount {
+			searchwords = append(searchwords, searchword)
+		}
+	}
+	sort.Strings(searchwords)
+
+	completions := make([]model.Completion, 0, len(searchwords))
+	for _, searchword := range searchwords {
+		completions = append(completions, model.Completion{
+			Title:      searchword,
+			Popularity: model.TitlePopularity(counts[searchword]*searchLogVotes, 10),
+		})
+	}
+	completer.Add(ctx, completions...)
+
+	return nil
+}
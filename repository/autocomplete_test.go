@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+func completedTitles(completions []model.Completion) []string {
+	titles := []string{}
+	for _, completion := range completions {
+		titles = append(titles, completion.Title)
+	}
+	return titles
+}
+
+func TestTitleCompleter(t *testing.T) {
+	completer := NewTitleCompleter(3)
+	completer.Add(context.TODO(),
+		model.Completion{Title: "Iron Man", ImdbID: "tt0371746", Year: "2008", Type: "movie", Popularity: 4.7},
+		model.Completion{Title: "Iron Man 2", ImdbID: "tt1228705", Year: "2010", Type: "movie", Popularity: 4.2},
+		model.Completion{Title: "Iron Man 3", ImdbID: "tt1300854", Year: "2013", Type: "movie", Popularity: 4.3},
+		model.Completion{Title: "The Iron Giant", ImdbID: "tt0129167", Year: "1999", Type: "movie", Popularity: 4.1},
+		model.Completion{Title: "Amélie", ImdbID: "tt0211915", Year: "2001", Type: "movie", Popularity: 4.6},
+		model.Completion{Title: "Ironclad", ImdbID: "tt1517260", Year: "2011", Type: "movie", Popularity: 3},
+		model.Completion{Title: "The Dark Knight", ImdbID: "tt0468569", Year: "2008", Type: "movie", Popularity: 5.3},
+		model.Completion{Title: "Dark", ImdbID: "tt5753856", Year: "2017–2020", Type: "series", Popularity: 4.8},
+	)
+
+	t.Run("[Complete] most popular first, up to the size of the completer", func(t *testing.T) {
+		assert.Equal(t, []string{"Iron Man", "Iron Man 3", "Iron Man 2"}, completedTitles(completer.Complete(context.TODO(), "iron", 0)))
+		assert.Equal(t, []string{"Iron Man", "Iron Man 3", "Iron Man 2"}, completedTitles(completer.Complete(context.TODO(), "iron", 10)))
+		assert.Equal(t, []string{"Iron Man"}, completedTitles(completer.Complete(context.TODO(), "iron", 1)))
+
+		completions := completer.Complete(context.TODO(), "Iron Man 2", 0)
+		assert.Equal(t, []model.Completion{{Title: "Iron Man 2", ImdbID: "tt1228705", Year: "2010", Type: "movie", Popularity: 4.2}}, completions)
+	})
+
+	t.Run("[Complete] case, accents and punctuation ignored", func(t *testing.T) {
+		for _, prefix := range []string{"ame", "AMÉ", "Amélie", "amelie!"} {
+			assert.Equal(t, []string{"Amélie"}, completedTitles(completer.Complete(context.TODO(), prefix, 0)), prefix)
+		}
+		assert.Equal(t, []string{"Iron Man 2"}, completedTitles(completer.Complete(context.TODO(), "iron-man 2", 0)))
+	})
+
+	t.Run("[Complete] a space only completes whole words", func(t *testing.T) {
+		assert.Equal(t, []string{"Iron Man", "Iron Man 3", "Iron Man 2"}, completedTitles(completer.Complete(context.TODO(), "iron ", 0)))
+		assert.Equal(t, []string{"Ironclad"}, completedTitles(completer.Complete(context.TODO(), "ironc", 0)))
+		assert.Equal(t, []string{"Iron Man 3"}, completedTitles(completer.Complete(context.TODO(), "iron man 3 ", 0)))
+	})
+
+	t.Run("[Complete] titles completed without their article too", func(t *testing.T) {
+		assert.Equal(t, []string{"The Dark Knight", "Dark"}, completedTitles(completer.Complete(context.TODO(), "dark", 0)))
+		assert.Equal(t, []string{"The Dark Knight"}, completedTitles(completer.Complete(context.TODO(), "the d", 0)))
+		assert.Equal(t, []string{"The Iron Giant"}, completedTitles(completer.Complete(context.TODO(), "iron g", 0)))
+	})
+
+	t.Run("[Complete] nothing to complete", func(t *testing.T) {
+		for _, prefix := range []string{"", " ", "?!", "zorro", "iron men", "ironmen"} {
+			completions := completer.Complete(context.TODO(), prefix, 0)
+			assert.NotNil(t, completions, prefix)
+			assert.Empty(t, completions, prefix)
+		}
+	})
+
+	t.Run("[Add] searches make titles more popular", func(t *testing.T) {
+		completer := NewTitleCompleter(3)
+		completer.Add(context.TODO(),
+			model.Completion{Title: "Star Wars", ImdbID: "tt0076759", Year: "1977", Type: "movie", Popularity: 4.8},
+			model.Completion{Title: "Stardust", ImdbID: "tt0486655", Year: "2007", Type: "movie", Popularity: 4.3},
+		)
+		completer.Add(context.TODO(), model.Completion{Title: "stardust", Popularity: 1})
+		completer.Add(context.TODO(), model.Completion{Title: "star trek", Popularity: 2})
+
+		completions := completer.Complete(context.TODO(), "star", 0)
+		assert.Equal(t, []model.Completion{
+			{Title: "Stardust", ImdbID: "tt0486655", Year: "2007", Type: "movie", Popularity: 5.3},
+			{Title: "Star Wars", ImdbID: "tt0076759", Year: "1977", Type: "movie", Popularity: 4.8},
+			{Title: "star trek", Popularity: 2},
+		}, completions)
+	})
+
+	t.Run("[Add] the most popular movie of a title completes it", func(t *testing.T) {
+		completer := NewTitleCompleter(3)
+		completer.Add(context.TODO(), model.Completion{Title: "dune", Popularity: 1})
+		completer.Add(context.TODO(), model.Completion{Title: "Dune", ImdbID: "tt0087182", Year: "1984", Type: "movie"})
+		assert.Equal(t, []model.Completion{{Title: "Dune", ImdbID: "tt0087182", Year: "1984", Type: "movie", Popularity: 1}}, completer.Complete(context.TODO(), "du", 0))
+
+		completer.Add(context.TODO(), model.Completion{Title: "Dune", ImdbID: "tt1160419", Year: "2021", Type: "movie", Popularity: 4.5})
+		completer.Add(context.TODO(), model.Completion{Title: "Dune", ImdbID: "tt0087182", Year: "1984", Type: "movie", Popularity: 3.5})
+		assert.Equal(t, []model.Completion{{Title: "Dune", ImdbID: "tt1160419", Year: "2021", Type: "movie", Popularity: 5.5}}, completer.Complete(context.TODO(), "du", 0))
+	})
+
+	t.Run("[Add] titles becoming popular pass the others", func(t *testing.T) {
+		completer := NewTitleCompleter(2)
+		for _, title := range []string{"Heat", "Heathers", "Heavy", "Heaven"} {
+			completer.Add(context.TODO(), model.Completion{Title: title, ImdbID: title, Popularity: 1})
+		}
+		assert.Equal(t, []string{"Heat", "Heathers"}, completedTitles(completer.Complete(context.TODO(), "hea", 0)))
+
+		completer.Add(context.TODO(), model.Completion{Title: "heaven", Popularity: 2})
+		assert.Equal(t, []string{"Heaven", "Heat"}, completedTitles(completer.Complete(context.TODO(), "hea", 0)))
+		assert.Equal(t, []string{"Heaven", "Heavy"}, completedTitles(completer.Complete(context.TODO(), "heav", 0)))
+		assert.Equal(t, []string{"Heat", "Heathers"}, completedTitles(completer.Complete(context.TODO(), "heat", 0)))
+	})
+}
+
+func TestAddSearchLogCompletions(t *testing.T) {
+	t.Run("[AddSearchLogCompletions] searchwords searched often enough are completed", func(t *testing.T) {
+		lines := []string{
+			`movie_search: 2026/10/19 15:44:32 /movie.SearchMovie/SearchMovie/ searchword:"godfathr"`,
+		}
+		for i := 0; i < searchLogMinCount; i++ {
+			lines = append(lines, `movie_search: 2026/10/19 15:44:34 /movie.SearchMovie/SearchMovie/ searchword:"Star Wars"`)
+		}
+		fileName := filepath.Join(t.TempDir(), "search.log")
+		os.WriteFile(fileName, []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+
+		completer := NewTitleCompleter(10)
+		if !assert.Nil(t, AddSearchLogCompletions(context.TODO(), completer, fileName)) {
+			return
+		}
+		completions := completer.Complete(context.TODO(), "star", 0)
+		if assert.Len(t, completions, 1) {
+			assert.Equal(t, "star wars", completions[0].Title)
+			assert.Equal(t, model.TitlePopularity(searchLogMinCount*searchLogVotes, 10), completions[0].Popularity)
+		}
+		assert.Empty(t, completer.Complete(context.TODO(), "god", 0))
+	})
+
+	t.Run("[AddSearchLogCompletions] no search log yet", func(t *testing.T) {
+		assert.Nil(t, AddSearchLogCompletions(context.TODO(), NewTitleCompleter(10), filepath.Join(t.TempDir(), "search.log")))
+	})
+}
+
+func TestAddDatasetCompletions(t *testing.T) {
+	t.Run("[AddDatasetCompletions] movies and series voted for enough only", func(t *testing.T) {
+		completer := NewTitleCompleter(10)
+		AddDatasetCompletions(context.TODO(), completer, newDatasetRepo(&datasetSnapshot{Titles: []datasetTitle{
+			{ID: 371746, Type: 0, Title: "Iron Man", StartYear: 2008, Rating: 79, Votes: 1100000},
+			{ID: 1228705, Type: 0, Title: "Iron Man 2", StartYear: 2010, Rating: 70, Votes: datasetCompletionMinVotes - 1},
+			{ID: 1234567, Type: 2, Title: "Iron Man Returns", StartYear: 2009, Rating: 80, Votes: 5000},
+			{ID: 1791528, Type: 1, Title: "Iron Fist", StartYear: 2017, EndYear: 2018, Rating: 64, Votes: 150000},
+		}}))
+		AddDatasetCompletions(context.TODO(), completer, &tmdbRepo{})
+
+		assert.Equal(t, []model.Completion{
+			{Title: "Iron Man", ImdbID: "tt0371746", Year: "2008", Type: "movie", Popularity: model.TitlePopularity(1100000, 7.9)},
+			{Title: "Iron Fist", ImdbID: "tt1791528", Year: "2017–2018", Type: "series", Popularity: model.TitlePopularity(150000, 6.4)},
+		}, completer.Complete(context.TODO(), "iron", 0))
+	})
+}
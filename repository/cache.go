@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+type cacheEntry struct {
+	key       string
+	value     interface{}
+	fetchedAt time.Time
+	expiresAt time.Time
+}
+
+// cachedMovieRepo keeps successful responses of the wrapped repository in memory.
+// Entries expire after ttl, the least recently used one is evicted once maxEntries is reached.
+// The freshness of every answer is recorded in the context, see model.WithFreshness
+type cachedMovieRepo struct {
+	MovieRepo  model.MovieRepository
+	ttl        time.Duration
+	maxEntries int
+
+	mutex   *sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	now     func() time.Time
+}
+
+func NewCachedMovieRepo(movieRepo model.MovieRepository, ttl time.Duration, maxEntries int) model.MovieRepository {
+	return &cachedMovieRepo{
+		MovieRepo:  movieRepo,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		mutex:      &sync.Mutex{},
+		entries:    map[string]*list.Element{},
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+func (repo *cachedMovieRepo) SearchMovies(ctx context.Context, title string, page uint32, filter model.SearchFilter) (result *model.MovieSearch, err error) {
+	key := fmt.Sprintf("search:%s:%d:%s:%d", model.SearchKey(title), page, filter.Type, filter.Year)
+	if cached, ok := repo.get(ctx, key); ok {
+		return cached.(*model.MovieSearch), nil
+	}
+
+	result, err = repo.MovieRepo.SearchMovies(ctx, title, page, filter)
+	if err == nil && result != nil && result.Error == "" {
+		repo.set(ctx, key, result)
+	}
+
+	return result, err
+}
+
+func (repo *cachedMovieRepo) GetMovieDetailByID(ctx context.Context, id string) (detail *model.MovieDetail, err error) {
+	key := "detail:" + id
+	if cached, ok := repo.get(ctx, key); ok {
+		return cached.(*model.MovieDetail), nil
+	}
+
+	detail, err = repo.MovieRepo.GetMovieDetailByID(ctx, id)
+	if err == nil && detail != nil && detail.Error == "" {
+		repo.set(ctx, key, detail)
+	}
+
+	return detail, err
+}
+
+func (repo *cachedMovieRepo) get(ctx context.Context, key string) (interface{}, bool) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	elem, ok := repo.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	now := repo.now()
+	if now.After(entry.expiresAt) {
+		repo.lru.Remove(elem)
+		delete(repo.entries, key)
+		return nil, false
+	}
+
+	repo.lru.MoveToFront(elem)
+	model.RecordFreshness(ctx, entry.fetchedAt, entry.expiresAt.Sub(now))
+	return entry.value, true
+}
+
+func (repo *cachedMovieRepo) set(ctx context.Context, key string, value interface{}) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.maxEntries <= 0 {
+		return
+	}
+
+	fetchedAt := repo.now()
+	model.RecordFreshness(ctx, fetchedAt, repo.ttl)
+	entry := &cacheEntry{key: key, value: value, fetchedAt: fetchedAt, expiresAt: fetchedAt.Add(repo.ttl)}
+	if elem, ok := repo.entries[key]; ok {
+		elem.Value = entry
+		repo.lru.MoveToFront(elem)
+		return
+	}
+
+	for repo.lru.Len() >= repo.maxEntries {
+		oldest := repo.lru.Back()
+		repo.lru.Remove(oldest)
+		delete(repo.entries, oldest.Value.(*cacheEntry).key)
+	}
+
+	repo.entries[key] = repo.lru.PushFront(entry)
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+func TestCachedSearchMovies(t *testing.T) {
+	t.Run("[SearchMovies] second call is served from cache", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("SearchMovies", testify.Anything, "ironman", uint32(1), testify.Anything).Return(&model.MovieSearch{TotalResults: "1"}, nil).Once()
+
+		repo := NewCachedMovieRepo(movieRepoMock, time.Minute, 10)
+		repo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{})
+		result, err := repo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "1", result.TotalResults)
+		}
+		movieRepoMock.AssertNumberOfCalls(t, "SearchMovies", 1)
+	})
+
+	t.Run("[SearchMovies] filtered searches are cached apart", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("SearchMovies", testify.Anything, "ironman", uint32(1), model.SearchFilter{}).Return(&model.MovieSearch{TotalResults: "9"}, nil)
+		movieRepoMock.On("SearchMovies", testify.Anything, "ironman", uint32(1), model.SearchFilter{Year: 2008}).Return(&model.MovieSearch{TotalResults: "1"}, nil)
+
+		repo := NewCachedMovieRepo(movieRepoMock, time.Minute, 10)
+		repo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{})
+		result, err := repo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{Year: 2008})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "1", result.TotalResults)
+		}
+		movieRepoMock.AssertNumberOfCalls(t, "SearchMovies", 2)
+	})
+
+	t.Run("[SearchMovies] searches differing in case and accents share their answer", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("SearchMovies", testify.Anything, "Amélie", uint32(1), testify.Anything).Return(&model.MovieSearch{TotalResults: "1"}, nil)
+
+		repo := NewCachedMovieRepo(movieRepoMock, time.Minute, 10)
+		repo.SearchMovies(context.TODO(), "Amélie", 1, model.SearchFilter{})
+		result, err := repo.SearchMovies(context.TODO(), "AMELIE", 1, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "1", result.TotalResults)
+		}
+		movieRepoMock.AssertNumberOfCalls(t, "SearchMovies", 1)
+	})
+
+	t.Run("[SearchMovies] errors and not found are not cached", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("SearchMovies", testify.Anything, "error", uint32(1), testify.Anything).Return(nil, errors.New("error"))
+		movieRepoMock.On("SearchMovies", testify.Anything, "notfound", uint32(1), testify.Anything).Return(&model.MovieSearch{Error: "Movie not found!"}, nil)
+
+		repo := NewCachedMovieRepo(movieRepoMock, time.Minute, 10)
+		for i := 0; i < 2; i++ {
+			repo.SearchMovies(context.TODO(), "error", 1, model.SearchFilter{})
+			repo.SearchMovies(context.TODO(), "notfound", 1, model.SearchFilter{})
+		}
+		movieRepoMock.AssertNumberOfCalls(t, "SearchMovies", 4)
+	})
+}
+
+func TestCachedGetMovieDetailByID(t *testing.T) {
+	t.Run("[GetMovieDetailByID] expired entry is fetched again", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("GetMovieDetailByID", testify.Anything, "tt1").Return(&model.MovieDetail{ImdbID: "tt1"}, nil)
+
+		now := time.Now()
+		repo := NewCachedMovieRepo(movieRepoMock, time.Minute, 10).(*cachedMovieRepo)
+		repo.now = func() time.Time { return now }
+
+		repo.GetMovieDetailByID(context.TODO(), "tt1")
+		repo.GetMovieDetailByID(context.TODO(), "tt1")
+		movieRepoMock.AssertNumberOfCalls(t, "GetMovieDetailByID", 1)
+
+		now = now.Add(2 * time.Minute)
+		repo.GetMovieDetailByID(context.TODO(), "tt1")
+		movieRepoMock.AssertNumberOfCalls(t, "GetMovieDetailByID", 2)
+	})
+
+	t.Run("[GetMovieDetailByID] least recently used entry is evicted", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		for _, id := range []string{"tt1", "tt2", "tt3"} {
+			movieRepoMock.On("GetMovieDetailByID", testify.Anything, id).Return(&model.MovieDetail{ImdbID: id}, nil)
+		}
+
+		repo := NewCachedMovieRepo(movieRepoMock, time.Minute, 2)
+		repo.GetMovieDetailByID(context.TODO(), "tt1")
+		repo.GetMovieDetailByID(context.TODO(), "tt2")
+		repo.GetMovieDetailByID(context.TODO(), "tt1")
+		repo.GetMovieDetailByID(context.TODO(), "tt3")
+		movieRepoMock.AssertNumberOfCalls(t, "GetMovieDetailByID", 3)
+
+		repo.GetMovieDetailByID(context.TODO(), "tt1")
+		movieRepoMock.AssertNumberOfCalls(t, "GetMovieDetailByID", 3)
+
+		repo.GetMovieDetailByID(context.TODO(), "tt2")
+		movieRepoMock.AssertNumberOfCalls(t, "GetMovieDetailByID", 4)
+	})
+
+	t.Run("[GetMovieDetailByID] freshness of the answer is recorded", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("GetMovieDetailByID", testify.Anything, "tt1").Return(&model.MovieDetail{ImdbID: "tt1"}, nil)
+
+		fetchedAt := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+		now := fetchedAt
+		repo := NewCachedMovieRepo(movieRepoMock, time.Minute, 10).(*cachedMovieRepo)
+		repo.now = func() time.Time { return now }
+
+		ctx, freshness := model.WithFreshness(context.TODO())
+		repo.GetMovieDetailByID(ctx, "tt1")
+		assert.Equal(t, model.Freshness{FetchedAt: fetchedAt, MaxAge: time.Minute}, *freshness)
+
+		now = now.Add(20 * time.Second)
+		ctx, freshness = model.WithFreshness(context.TODO())
+		repo.GetMovieDetailByID(ctx, "tt1")
+		assert.Equal(t, model.Freshness{FetchedAt: fetchedAt, MaxAge: 40 * time.Second}, *freshness)
+	})
+}
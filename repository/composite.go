@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/zenkobert/sbtest-2/common"
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// RatingsField is the field of the ratings, merged from every provider rather than taken from one
+const RatingsField = "ratings"
+
+// MovieProvider is a repository movies are looked up in and the name its answers are credited to
+type MovieProvider struct {
+	Name string
+	Repo model.MovieRepository
+}
+
+// mergedFields are the fields of a detail a provider can answer, by their JSON name in the API
+var mergedFields = []struct {
+	name  string
+	value func(detail *model.MovieDetail) *string
+}{
+	{"title", func(d *model.MovieDetail) *string { return &d.Title }},
+	{"year", func(d *model.MovieDetail) *string { return &d.Year }},
+	{"rated", func(d *model.MovieDetail) *string { return &d.Rated }},
+	{"released", func(d *model.MovieDetail) *string { return &d.Released }},
+	{"runtime", func(d *model.MovieDetail) *string { return &d.Runtime }},
+	{"genre", func(d *model.MovieDetail) *string { return &d.Genre }},
+	{"director", func(d *model.MovieDetail) *string { return &d.Director }},
+	{"writer", func(d *model.MovieDetail) *string { return &d.Writer }},
+	{"actors", func(d *model.MovieDetail) *string { return &d.Actors }},
+	{"plot", func(d *model.MovieDetail) *string { return &d.Plot }},
+	{"language", func(d *model.MovieDetail) *string { return &d.Language }},
+	{"country", func(d *model.MovieDetail) *string { return &d.Country }},
+	{"awards", func(d *model.MovieDetail) *string { return &d.Awards }},
+	{"poster", func(d *model.MovieDetail) *string { return &d.Poster }},
+	{"metascore", func(d *model.MovieDetail) *string { return &d.Metascore }},
+	{"imdbRating", func(d *model.MovieDetail) *string { return &d.ImdbRating }},
+	{"imdbVotes", func(d *model.MovieDetail) *string { return &d.ImdbVotes }},
+	{"type", func(d *model.MovieDetail) *string { return &d.Type }},
+	{"dvd", func(d *model.MovieDetail) *string { return &d.DVD }},
+	{"boxOffice", func(d *model.MovieDetail) *string { return &d.BoxOffice }},
+	{"production", func(d *model.MovieDetail) *string { return &d.Production }},
+	{"website", func(d *model.MovieDetail) *string { return &d.Website }},
+	{"seriesId", func(d *model.MovieDetail) *string { return &d.SeriesID }},
+	{"season", func(d *model.MovieDetail) *string { return &d.Season }},
+	{"episode", func(d *model.MovieDetail) *string { return &d.Episode }},
+}
+
+// episodeFields are the mergedFields only episodes have
+var episodeFields = map[string]bool{"seriesId": true, "season": true, "episode": true}
+
+// compositeMovieRepo looks movies up in several providers. Searches go to the first provider that
+// answers, falling back to the next one on errors such as an exhausted quota. Details are merged field by
+// field, each field from the first provider knowing it, asking the providers in turn only as long as
+// fields are missing
+type compositeMovieRepo struct {
+	providers []MovieProvider
+	// precedence lists the positions of the providers in the order a field is taken from them
+	precedence map[string][]int
+	order      []int
+}
+
+// NewCompositeMovieRepo queries providers in their order, except for the fields precedence lists
+// providers for, by their JSON name. Providers precedence doesn't list for a field come after, in order
+func NewCompositeMovieRepo(providers []MovieProvider, precedence map[string][]string) (model.MovieRepository, error) {
+	repo := &compositeMovieRepo{
+		providers:  providers,
+		precedence: map[string][]int{},
+	}
+
+	positions := map[string]int{}
+	for i, provider := range providers {
+		positions[provider.Name] = i
+		repo.order = append(repo.order, i)
+	}
+
+	known := map[string]bool{RatingsField: true}
+	for _, field := range mergedFields {
+		known[field.name] = true
+	}
+
+	for field, names := range precedence {
+		if !known[field] {
+			return nil, fmt.Errorf("unknown movie field %q in the provider precedence", field)
+		}
+
+		var order []int
+		listed := map[int]bool{}
+		for _, name := range names {
+			position, ok := positions[name]
+			if !ok {
+				return nil, fmt.Errorf("provider %q of the %s precedence is not a movie provider", name, field)
+			}
+			if !listed[position] {
+				listed[position] = true
+				order = append(order, position)
+			}
+		}
+		for _, position := range repo.order {
+			if !listed[position] {
+				order = append(order, position)
+			}
+		}
+		repo.precedence[field] = order
+	}
+
+	return repo, nil
+}
+
+// SearchMovies answers with the first provider that doesn't fail, a provider finding nothing
+// is an answer. When every provider fails the error of the first one is returned
+func (repo *compositeMovieRepo) SearchMovies(ctx context.Context, title string, page uint32, filter model.SearchFilter) (*model.MovieSearch, error) {
+	var firstErr error
+	for _, provider := range repo.providers {
+		result, err := provider.Repo.SearchMovies(ctx, title, page, filter)
+		if err == nil {
+			return result, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		log.Printf("searching with %s failed, falling back: %v", provider.Name, err)
+	}
+
+	return nil, firstErr
+}
+
+// detailLookup asks the providers for the detail of a movie one at a time, only once a field needs them
+type detailLookup struct {
+	ctx     context.Context
+	id      string
+	repo    *compositeMovieRepo
+	asked   []bool
+	details []*model.MovieDetail
+	errs    []error
+}
+
+// known is the detail of the provider at position when it knows the movie, the provider is asked first
+// when ask is set
+func (lookup *detailLookup) known(position int, ask bool) *model.MovieDetail {
+	if !lookup.asked[position] {
+		if !ask || lookup.ctx.Err() != nil {
+			return nil
+		}
+		lookup.asked[position] = true
+		lookup.details[position], lookup.errs[position] = lookup.repo.providers[position].Repo.GetMovieDetailByID(lookup.ctx, lookup.id)
+	}
+
+	detail := lookup.details[position]
+	if lookup.errs[position] != nil || detail == nil || detail.Error != "" {
+		return nil
+	}
+	return detail
+}
+
+// GetMovieDetailByID merges the details of the providers knowing the movie, the next provider is only
+// asked for the fields the ones before left empty or unknown. When none knows the movie, the answer of the
+// first provider that didn't fail is returned, and when every one failed the first error
+func (repo *compositeMovieRepo) GetMovieDetailByID(ctx context.Context, id string) (*model.MovieDetail, error) {
+	lookup := &detailLookup{
+		ctx:     ctx,
+		id:      id,
+		repo:    repo,
+		asked:   make([]bool, len(repo.providers)),
+		details: make([]*model.MovieDetail, len(repo.providers)),
+		errs:    make([]error, len(repo.providers)),
+	}
+	if merged := repo.merge(lookup); merged != nil {
+		return merged, nil
+	}
+
+	for i, detail := range lookup.details {
+		if lookup.errs[i] == nil && detail != nil {
+			return detail, nil
+		}
+	}
+	for _, err := range lookup.errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return nil, model.ErrUpstream
+}
+
+// merge takes each field from the first provider having a value for it by precedence, N/A when the ones
+// knowing the movie have it as unknown. Ratings are every rating of the providers asked, one per source,
+// the next one is asked only when none had ratings. It is nil when no provider knows the movie
+func (repo *compositeMovieRepo) merge(lookup *detailLookup) *model.MovieDetail {
+	merged := &model.MovieDetail{
+		Ratings:  []model.MovieRating{},
+		Response: "True",
+		Sources:  map[string]string{},
+	}
+
+	for _, field := range mergedFields {
+		value := field.value(merged)
+		// the fields of episodes are left empty for the other types, no provider is asked for them
+		ask := !episodeFields[field.name] || merged.Type == "episode"
+		for _, position := range repo.orderOf(field.name) {
+			detail := lookup.known(position, ask)
+			if detail == nil {
+				continue
+			}
+			found := *field.value(detail)
+			if found == notApplicable {
+				// unknown rather than empty, like fields of episodes for a movie
+				*value = notApplicable
+			} else if found != "" {
+				*value = found
+				merged.Sources[field.name] = repo.providers[position].Name
+				break
+			}
+		}
+	}
+
+	rated := map[string]bool{}
+	var ratingSources []string
+	for _, position := range repo.orderOf(RatingsField) {
+		detail := lookup.known(position, len(merged.Ratings) == 0)
+		if detail == nil {
+			continue
+		}
+		contributed := false
+		for _, rating := range detail.Ratings {
+			if !rated[rating.Source] {
+				rated[rating.Source] = true
+				merged.Ratings = append(merged.Ratings, rating)
+				contributed = true
+			}
+		}
+		if contributed {
+			ratingSources = append(ratingSources, repo.providers[position].Name)
+		}
+	}
+	if len(ratingSources) > 0 {
+		merged.Sources[RatingsField] = strings.Join(ratingSources, ", ")
+	}
+
+	for _, position := range repo.order {
+		if detail := lookup.known(position, false); detail != nil {
+			merged.ImdbID = detail.ImdbID
+			return merged
+		}
+	}
+
+	return nil
+}
+
+func (repo *compositeMovieRepo) orderOf(field string) []int {
+	if order, ok := repo.precedence[field]; ok {
+		return order
+	}
+
+	return repo.order
+}
+
+// Check succeeds as long as one provider is up, the others are fallen back from
+func (repo *compositeMovieRepo) Check(ctx context.Context) error {
+	var failures []string
+	for _, provider := range repo.providers {
+		checker, ok := provider.Repo.(common.HealthChecker)
+		if !ok {
+			return nil
+		}
+		err := checker.Check(ctx)
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", provider.Name, err))
+	}
+
+	return fmt.Errorf("no movie provider is up, %s", strings.Join(failures, ", "))
+}
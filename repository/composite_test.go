@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+// providerAnswering mocks a provider answering every detail and search with detail and search, or failing with err
+func providerAnswering(detail *model.MovieDetail, search *model.MovieSearch, err error) *mocks.MovieRepository {
+	movieRepo := &mocks.MovieRepository{}
+	movieRepo.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(detail, err)
+	movieRepo.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(search, err)
+
+	return movieRepo
+}
+
+func TestCompositeMovieRepo(t *testing.T) {
+	omdbDetail := &model.MovieDetail{
+		Title: "Iron Man", Year: "2008", Plot: "N/A", Poster: "https://omdb/poster.jpg", ImdbID: "tt0371746", Type: "movie",
+		Ratings: []model.MovieRating{{Source: "Internet Movie Database", Value: "7.9/10"}, {Source: "Metacritic", Value: "79/100"}},
+	}
+	datasetDetail := &model.MovieDetail{
+		Title: "Iron Man", Year: "2008", Rated: "N/A", Plot: "N/A", Director: "Jon Favreau", Poster: "N/A", ImdbID: "tt0371746", Type: "movie",
+		Ratings: []model.MovieRating{{Source: "Internet Movie Database", Value: "8.0/10"}},
+	}
+	tmdbDetail := &model.MovieDetail{
+		Title: "Iron Man", Plot: "Tony Stark builds a suit", Poster: "https://tmdb/poster.jpg", ImdbID: "tt0371746", Type: "movie",
+		Ratings: []model.MovieRating{{Source: "TMDb", Value: "7.6/10"}},
+	}
+	notFound := &model.MovieDetail{Response: "False", Error: "Incorrect IMDb ID."}
+
+	t.Run("[GetMovieDetailByID] fields merged by precedence, with their provider", func(t *testing.T) {
+		repo, err := NewCompositeMovieRepo([]MovieProvider{
+			{"omdb", providerAnswering(omdbDetail, nil, nil)},
+			{"dataset", providerAnswering(datasetDetail, nil, nil)},
+			{"tmdb", providerAnswering(tmdbDetail, nil, nil)},
+		}, map[string][]string{"poster": {"tmdb"}, "ratings": {"dataset", "tmdb"}})
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		detail, err := repo.GetMovieDetailByID(context.TODO(), "tt0371746")
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Equal(t, "True", detail.Response)
+		assert.Equal(t, "tt0371746", detail.ImdbID)
+		assert.Equal(t, "Iron Man", detail.Title)
+		assert.Equal(t, "Jon Favreau", detail.Director)
+		assert.Equal(t, "Tony Stark builds a suit", detail.Plot)
+		assert.Equal(t, "https://tmdb/poster.jpg", detail.Poster)
+		assert.Equal(t, []model.MovieRating{
+			{Source: "Internet Movie Database", Value: "8.0/10"},
+			{Source: "TMDb", Value: "7.6/10"},
+			{Source: "Metacritic", Value: "79/100"},
+		}, detail.Ratings)
+		assert.Equal(t, "omdb", detail.Sources["title"])
+		assert.Equal(t, "dataset", detail.Sources["director"])
+		assert.Equal(t, "tmdb", detail.Sources["plot"])
+		assert.Equal(t, "tmdb", detail.Sources["poster"])
+		assert.Equal(t, "dataset, tmdb, omdb", detail.Sources["ratings"])
+		// unknown to the providers knowing it, and not a field of movies
+		assert.Equal(t, "N/A", detail.Rated)
+		assert.Empty(t, detail.SeriesID)
+		assert.NotContains(t, detail.Sources, "seriesId")
+	})
+
+	t.Run("[GetMovieDetailByID] a complete answer asks no other provider", func(t *testing.T) {
+		complete := &model.MovieDetail{
+			Title: "Iron Man", Year: "2008", Rated: "PG-13", Released: "02 May 2008", Runtime: "126 min", Genre: "Action, Adventure, Sci-Fi",
+			Director: "Jon Favreau", Writer: "Mark Fergus", Actors: "Robert Downey Jr.", Plot: "Tony Stark builds a suit", Language: "English",
+			Country: "United States", Awards: "Nominated for 2 Oscars", Poster: "https://omdb/poster.jpg", Metascore: "79", ImdbRating: "7.9",
+			ImdbVotes: "1,000,000", ImdbID: "tt0371746", Type: "movie", DVD: "30 Sep 2008", BoxOffice: "$318,604,126",
+			Production: "Marvel", Website: "https://www.marvel.com/movies/iron-man",
+			Ratings: omdbDetail.Ratings,
+		}
+		tmdb := &mocks.MovieRepository{}
+		repo, _ := NewCompositeMovieRepo([]MovieProvider{
+			{"omdb", providerAnswering(complete, nil, nil)},
+			{"tmdb", tmdb},
+		}, nil)
+
+		detail, err := repo.GetMovieDetailByID(context.TODO(), "tt0371746")
+		if assert.Nil(t, err) {
+			assert.Equal(t, "omdb", detail.Sources["plot"])
+			assert.Equal(t, "omdb", detail.Sources["ratings"])
+		}
+		tmdb.AssertNumberOfCalls(t, "GetMovieDetailByID", 0)
+	})
+
+	t.Run("[GetMovieDetailByID] failing and not knowing providers are left out", func(t *testing.T) {
+		repo, _ := NewCompositeMovieRepo([]MovieProvider{
+			{"omdb", providerAnswering(nil, nil, model.ErrQuotaExceeded)},
+			{"tmdb", providerAnswering(notFound, nil, nil)},
+			{"dataset", providerAnswering(datasetDetail, nil, nil)},
+		}, nil)
+
+		detail, err := repo.GetMovieDetailByID(context.TODO(), "tt0371746")
+		if assert.Nil(t, err) {
+			assert.Equal(t, "Jon Favreau", detail.Director)
+			assert.Equal(t, "dataset", detail.Sources["title"])
+		}
+	})
+
+	t.Run("[GetMovieDetailByID] unknown movie, then every provider failing", func(t *testing.T) {
+		repo, _ := NewCompositeMovieRepo([]MovieProvider{
+			{"omdb", providerAnswering(nil, nil, model.ErrQuotaExceeded)},
+			{"dataset", providerAnswering(notFound, nil, nil)},
+		}, nil)
+		detail, err := repo.GetMovieDetailByID(context.TODO(), "tt0000001")
+		if assert.Nil(t, err) {
+			assert.Equal(t, notFound, detail)
+		}
+
+		repo, _ = NewCompositeMovieRepo([]MovieProvider{
+			{"omdb", providerAnswering(nil, nil, model.ErrQuotaExceeded)},
+			{"tmdb", providerAnswering(nil, nil, model.ErrUpstream)},
+		}, nil)
+		_, err = repo.GetMovieDetailByID(context.TODO(), "tt0371746")
+		assert.Equal(t, model.ErrQuotaExceeded, err)
+	})
+
+	t.Run("[SearchMovies] falls back on errors, not on empty results", func(t *testing.T) {
+		found := &model.MovieSearch{Search: []model.SearchDetail{{Title: "Iron Man"}}, TotalResults: "1", Response: "True"}
+		nothing := &model.MovieSearch{Response: "False", Error: "Movie not found!"}
+		last := providerAnswering(nil, found, nil)
+
+		repo, _ := NewCompositeMovieRepo([]MovieProvider{
+			{"omdb", providerAnswering(nil, nil, model.ErrQuotaExceeded)},
+			{"dataset", providerAnswering(nil, nothing, nil)},
+			{"tmdb", last},
+		}, nil)
+		result, err := repo.SearchMovies(context.TODO(), "Iron Man", 1, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, nothing, result)
+			last.AssertNotCalled(t, "SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+		}
+
+		repo, _ = NewCompositeMovieRepo([]MovieProvider{
+			{"omdb", providerAnswering(nil, nil, model.ErrQuotaExceeded)},
+			{"tmdb", providerAnswering(nil, nil, errors.New("dial tcp: i/o timeout"))},
+		}, nil)
+		_, err = repo.SearchMovies(context.TODO(), "Iron Man", 1, model.SearchFilter{})
+		assert.Equal(t, model.ErrQuotaExceeded, err)
+	})
+
+	t.Run("[NewCompositeMovieRepo] unknown field or provider in the precedence", func(t *testing.T) {
+		providers := []MovieProvider{{"omdb", &mocks.MovieRepository{}}, {"dataset", &mocks.MovieRepository{}}}
+
+		_, err := NewCompositeMovieRepo(providers, map[string][]string{"tagline": {"omdb"}})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `unknown movie field "tagline"`)
+		}
+
+		_, err = NewCompositeMovieRepo(providers, map[string][]string{"plot": {"tmdb"}})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `provider "tmdb" of the plot precedence`)
+		}
+	})
+}
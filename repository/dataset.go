@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// OMDb answers the providers standing in for it mimic
+const (
+	movieNotFound   = "Movie not found!"
+	incorrectImdbID = "Incorrect IMDb ID."
+	notApplicable   = "N/A"
+	// omdbPageSize is how many results a search page has
+	omdbPageSize = 10
+)
+
+const datasetRatingSource = "Internet Movie Database"
+
+// datasetTypes are the OMDb types of the titles kept from the dataset, a datasetTitle stores their index
+var datasetTypes = []string{"movie", "series", "episode", "game"}
+
+type (
+	// datasetTitle is a title of the IMDb dataset, IDs are the number of tt and nm IDs
+	// and zero values tell a field is unknown
+	datasetTitle struct {
+		ID        uint32
+		Type      uint8
+		Title     string
+		StartYear uint16
+		EndYear   uint16
+		Runtime   uint16
+		Genres    string
+		// Rating is in tenths, 79 for 7.9
+		Rating    uint8
+		Votes     uint32
+		Directors []uint32
+		Writers   []uint32
+		Actors    []uint32
+		SeriesID  uint32
+		Season    uint16
+		Episode   uint16
+	}
+
+	// datasetSnapshot is what BuildDataset writes and NewDatasetRepo reads, gob encoded and gzipped.
+	// Titles are sorted by ID
+	datasetSnapshot struct {
+		Titles []datasetTitle
+		Names  map[uint32]string
+	}
+)
+
+// datasetRepo answers from the IMDb dataset kept in memory. It has no plot, poster or release day,
+// those are N/A like OMDb does for values it doesn't know
+type datasetRepo struct {
+	titles []datasetTitle
+	names  map[uint32]string
+	// index maps the words of the titles to the positions of the titles having them, in increasing order
+	index map[string][]int32
+}
+
+// NewDatasetRepo loads the dataset fileName was built into by BuildDataset
+func NewDatasetRepo(fileName string) (model.MovieRepository, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading dataset %s: %w", fileName, err)
+	}
+
+	var snapshot datasetSnapshot
+	if err := gob.NewDecoder(reader).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("reading dataset %s: %w", fileName, err)
+	}
+
+	return newDatasetRepo(&snapshot), nil
+}
+
+func newDatasetRepo(snapshot *datasetSnapshot) *datasetRepo {
+	repo := &datasetRepo{
+		titles: snapshot.Titles,
+		names:  snapshot.Names,
+		index:  map[string][]int32{},
+	}
+
+	for i, title := range repo.titles {
+		seen := map[string]bool{}
+		for _, word := range datasetWords(title.Title) {
+			if !seen[word] {
+				seen[word] = true
+				repo.index[word] = append(repo.index[word], int32(i))
+			}
+		}
+	}
+
+	return repo
+}
+
+// SearchMovies finds the titles having every word of title.
+// A title that is exactly the one searched comes first, the others by number of votes
+func (repo *datasetRepo) SearchMovies(ctx context.Context, title string, page uint32, filter model.SearchFilter) (*model.MovieSearch, error) {
+	if page == 0 {
+		page = 1
+	}
+
+	words := datasetWords(title)
+	exact := strings.Join(words, " ")
+
+	type result struct {
+		title *datasetTitle
+		exact bool
+	}
+	var results []result
+	for _, position := range repo.match(words) {
+		found := &repo.titles[position]
+		if filter.Type != "" && datasetTypes[found.Type] != filter.Type {
+			continue
+		}
+		if filter.Year != 0 && int(found.StartYear) != filter.Year {
+			continue
+		}
+		results = append(results, result{found, strings.Join(datasetWords(found.Title), " ") == exact})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.exact != b.exact {
+			return a.exact
+		}
+		if a.title.Votes != b.title.Votes {
+			return a.title.Votes > b.title.Votes
+		}
+		return a.title.ID < b.title.ID
+	})
+
+	start := int(page-1) * omdbPageSize
+	if start >= len(results) {
+		return &model.MovieSearch{Response: "False", Error: movieNotFound}, nil
+	}
+	end := start + omdbPageSize
+	if end > len(results) {
+		end = len(results)
+	}
+
+	search := &model.MovieSearch{
+		TotalResults: strconv.Itoa(len(results)),
+		Response:     "True",
+	}
+	for _, result := range results[start:end] {
+		found := result.title
+		search.Search = append(search.Search, model.SearchDetail{
+			Title:  found.Title,
+			Year:   datasetYear(found),
+			ImdbID: datasetID("tt", found.ID),
+			Type:   datasetTypes[found.Type],
+			Poster: notApplicable,
+		})
+	}
+
+	return search, nil
+}
+
+// match returns the positions of the titles having every word, starting from the rarest one
+func (repo *datasetRepo) match(words []string) []int32 {
+	if len(words) == 0 {
+		return nil
+	}
+
+	postings := make([][]int32, 0, len(words))
+	for _, word := range words {
+		posting, ok := repo.index[word]
+		if !ok {
+			return nil
+		}
+		postings = append(postings, posting)
+	}
+	sort.Slice(postings, func(i, j int) bool { return len(postings[i]) < len(postings[j]) })
+
+	var matches []int32
+	for _, position := range postings[0] {
+		all := true
+		for _, posting := range postings[1:] {
+			i := sort.Search(len(posting), func(i int) bool { return posting[i] >= position })
+			if i == len(posting) || posting[i] != position {
+				all = false
+				break
+			}
+		}
+		if all {
+			matches = append(matches, position)
+		}
+	}
+
+	return matches
+}
+
+// GetMovieDetailByID answers an unknown or malformed ID like OMDb does, with an error in the detail
+func (repo *datasetRepo) GetMovieDetailByID(ctx context.Context, id string) (*model.MovieDetail, error) {
+	number, err := strconv.ParseUint(strings.TrimPrefix(id, "tt"), 10, 32)
+	if err != nil || !strings.HasPrefix(id, "tt") {
+		return &model.MovieDetail{Response: "False", Error: incorrectImdbID}, nil
+	}
+
+	i := sort.Search(len(repo.titles), func(i int) bool { return repo.titles[i].ID >= uint32(number) })
+	if i == len(repo.titles) || repo.titles[i].ID != uint32(number) {
+		return &model.MovieDetail{Response: "False", Error: incorrectImdbID}, nil
+	}
+	found := &repo.titles[i]
+
+	detail := &model.MovieDetail{
+		Title:      found.Title,
+		Year:       datasetYear(found),
+		Rated:      notApplicable,
+		Released:   notApplicable,
+		Runtime:    notApplicable,
+		Genre:      notApplicable,
+		Director:   repo.people(found.Directors),
+		Writer:     repo.people(found.Writers),
+		Actors:     repo.people(found.Actors),
+		Plot:       notApplicable,
+		Language:   notApplicable,
+		Country:    notApplicable,
+		Awards:     notApplicable,
+		Poster:     notApplicable,
+		Ratings:    []model.MovieRating{},
+		Metascore:  notApplicable,
+		ImdbRating: notApplicable,
+		ImdbVotes:  notApplicable,
+		ImdbID:     datasetID("tt", found.ID),
+		Type:       datasetTypes[found.Type],
+		DVD:        notApplicable,
+		BoxOffice:  notApplicable,
+		Production: notApplicable,
+		Website:    notApplicable,
+		Response:   "True",
+	}
+	if found.Runtime > 0 {
+		detail.Runtime = fmt.Sprintf("%d min", found.Runtime)
+	}
+	if found.Genres != "" {
+		detail.Genre = strings.ReplaceAll(found.Genres, ",", ", ")
+	}
+	if found.Votes > 0 {
+		rating := fmt.Sprintf("%d.%d", found.Rating/10, found.Rating%10)
+		detail.ImdbRating = rating
+		detail.ImdbVotes = groupThousands(uint64(found.Votes))
+		detail.Ratings = append(detail.Ratings, model.MovieRating{Source: datasetRatingSource, Value: rating + "/10"})
+	}
+	if found.SeriesID != 0 {
+		detail.SeriesID = datasetID("tt", found.SeriesID)
+		detail.Season = datasetNumber(found.Season)
+		detail.Episode = datasetNumber(found.Episode)
+	}
+
+	return detail, nil
+}
+
+// people joins the names of ids, N/A when there is none
+func (repo *datasetRepo) people(ids []uint32) string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if name, ok := repo.names[id]; ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return notApplicable
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// datasetWords splits a title into lower case words of letters and digits, the unit titles are indexed by
+func datasetWords(title string) []string {
+	return strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// datasetYear formats the years of a title like OMDb, 2008–2013 for a series that ended and 2008– for one still running
+func datasetYear(title *datasetTitle) string {
+	switch {
+	case title.StartYear == 0:
+		return notApplicable
+	case datasetTypes[title.Type] != "series":
+		return strconv.Itoa(int(title.StartYear))
+	case title.EndYear == 0:
+		return fmt.Sprintf("%d–", title.StartYear)
+	}
+
+	return fmt.Sprintf("%d–%d", title.StartYear, title.EndYear)
+}
+
+func datasetID(prefix string, number uint32) string {
+	return fmt.Sprintf("%s%07d", prefix, number)
+}
+
+func datasetNumber(number uint16) string {
+	if number == 0 {
+		return notApplicable
+	}
+
+	return strconv.Itoa(int(number))
+}
+
+// groupThousands formats n with comma separated thousands, as OMDb formats votes
+func groupThousands(n uint64) string {
+	digits := strconv.FormatUint(n, 10)
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return grouped.String()
+}
+
+// Check always succeeds, the dataset was loaded at startup and never changes
+func (repo *datasetRepo) Check(ctx context.Context) error {
+	return nil
+}
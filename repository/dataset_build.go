@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// datasetNull is how the dataset files write a missing value
+const datasetNull = `\N`
+
+// datasetMaxActors is how many actors a title keeps, OMDb lists about as many
+const datasetMaxActors = 4
+
+// datasetTitleTypes maps the title types of the dataset to the OMDb types, titles of other types are skipped
+var datasetTitleTypes = map[string]string{
+	"movie":        "movie",
+	"tvMovie":      "movie",
+	"short":        "movie",
+	"tvShort":      "movie",
+	"video":        "movie",
+	"tvSpecial":    "movie",
+	"tvSeries":     "series",
+	"tvMiniSeries": "series",
+	"tvEpisode":    "episode",
+	"videoGame":    "game",
+}
+
+// datasetBuilder holds the titles read so far while BuildDataset goes through the files
+type datasetBuilder struct {
+	titles    []datasetTitle
+	positions map[uint32]int
+	names     map[uint32]string
+}
+
+// BuildDataset reads the IMDb dataset files (https://datasets.imdbws.com) found in dir, title.basics,
+// title.ratings, title.episode, title.principals and name.basics, gzipped as downloaded or not, and
+// writes the titles NewDatasetRepo loads to out. Adult titles are left out. It returns how many titles
+// were written
+func BuildDataset(dir string, out io.Writer) (int, error) {
+	builder := &datasetBuilder{positions: map[uint32]int{}, names: map[uint32]string{}}
+
+	steps := []struct {
+		file    string
+		columns []string
+		read    func(fields []string) error
+	}{
+		{"title.basics", []string{"tconst", "titleType", "primaryTitle", "originalTitle", "isAdult", "startYear", "endYear", "runtimeMinutes", "genres"}, builder.readBasics},
+		{"title.ratings", []string{"tconst", "averageRating", "numVotes"}, builder.readRatings},
+		{"title.episode", []string{"tconst", "parentTconst", "seasonNumber", "episodeNumber"}, builder.readEpisode},
+		{"title.principals", []string{"tconst", "ordering", "nconst", "category"}, builder.readPrincipal},
+		{"name.basics", []string{"nconst", "primaryName"}, builder.readName},
+	}
+	for _, step := range steps {
+		if err := readDatasetFile(dir, step.file, step.columns, step.read); err != nil {
+			return 0, err
+		}
+	}
+
+	sort.Slice(builder.titles, func(i, j int) bool { return builder.titles[i].ID < builder.titles[j].ID })
+
+	writer := gzip.NewWriter(out)
+	if err := gob.NewEncoder(writer).Encode(&datasetSnapshot{Titles: builder.titles, Names: builder.names}); err != nil {
+		return 0, err
+	}
+	if err := writer.Close(); err != nil {
+		return 0, err
+	}
+
+	return len(builder.titles), nil
+}
+
+// readDatasetFile calls read with the fields of every row of name in dir, after checking its header
+// starts with columns
+func readDatasetFile(dir, name string, columns []string, read func(fields []string) error) error {
+	file, err := os.Open(filepath.Join(dir, name+".tsv.gz"))
+	if errors.Is(err, os.ErrNotExist) {
+		file, err = os.Open(filepath.Join(dir, name+".tsv"))
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(file.Name(), ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", name, err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	if !scanner.Scan() {
+		return fmt.Errorf("reading %s: no header", name)
+	}
+	header := strings.Split(scanner.Text(), "\t")
+	if len(header) < len(columns) || strings.Join(header[:len(columns)], "\t") != strings.Join(columns, "\t") {
+		return fmt.Errorf("reading %s: expected the columns %s", name, strings.Join(columns, ", "))
+	}
+
+	line := 1
+	for scanner.Scan() {
+		line++
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < len(columns) {
+			return fmt.Errorf("reading %s: line %d has %d columns, expected %d", name, line, len(fields), len(columns))
+		}
+		if err := read(fields); err != nil {
+			return fmt.Errorf("reading %s: line %d: %w", name, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (builder *datasetBuilder) readBasics(fields []string) error {
+	omdbType, ok := datasetTitleTypes[fields[1]]
+	if !ok || fields[4] == "1" {
+		return nil
+	}
+
+	id, err := parseDatasetID("tt", fields[0])
+	if err != nil {
+		return err
+	}
+
+	title := datasetTitle{
+		ID:        id,
+		Title:     fields[2],
+		StartYear: parseDatasetNumber(fields[5]),
+		EndYear:   parseDatasetNumber(fields[6]),
+		Runtime:   parseDatasetNumber(fields[7]),
+	}
+	for i, datasetType := range datasetTypes {
+		if datasetType == omdbType {
+			title.Type = uint8(i)
+		}
+	}
+	if fields[8] != datasetNull {
+		title.Genres = fields[8]
+	}
+
+	builder.positions[id] = len(builder.titles)
+	builder.titles = append(builder.titles, title)
+
+	return nil
+}
+
+func (builder *datasetBuilder) readRatings(fields []string) error {
+	title, err := builder.title(fields[0])
+	if title == nil {
+		return err
+	}
+
+	rating, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return fmt.Errorf("invalid rating %q", fields[1])
+	}
+	votes, err := strconv.ParseUint(fields[2], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid votes %q", fields[2])
+	}
+	title.Rating = uint8(rating*10 + 0.5)
+	title.Votes = uint32(votes)
+
+	return nil
+}
+
+func (builder *datasetBuilder) readEpisode(fields []string) error {
+	title, err := builder.title(fields[0])
+	if title == nil {
+		return err
+	}
+
+	title.SeriesID, err = parseDatasetID("tt", fields[1])
+	if err != nil {
+		return err
+	}
+	title.Season = parseDatasetNumber(fields[2])
+	title.Episode = parseDatasetNumber(fields[3])
+
+	return nil
+}
+
+// readPrincipal adds a director, writer or actor to their title in the order of the file, which is billing order
+func (builder *datasetBuilder) readPrincipal(fields []string) error {
+	title, err := builder.title(fields[0])
+	if title == nil {
+		return err
+	}
+
+	var people *[]uint32
+	switch fields[3] {
+	case "director":
+		people = &title.Directors
+	case "writer":
+		people = &title.Writers
+	case "actor", "actress", "self":
+		if len(title.Actors) < datasetMaxActors {
+			people = &title.Actors
+		}
+	}
+	if people == nil {
+		return nil
+	}
+
+	id, err := parseDatasetID("nm", fields[2])
+	if err != nil {
+		return err
+	}
+	*people = append(*people, id)
+	builder.names[id] = ""
+
+	return nil
+}
+
+// readName keeps the names of the people the titles credit
+func (builder *datasetBuilder) readName(fields []string) error {
+	id, err := parseDatasetID("nm", fields[0])
+	if err != nil {
+		return err
+	}
+	if _, ok := builder.names[id]; ok {
+		builder.names[id] = fields[1]
+	}
+
+	return nil
+}
+
+// title returns the title kept for a tconst, nil for the titles that were skipped
+func (builder *datasetBuilder) title(tconst string) (*datasetTitle, error) {
+	id, err := parseDatasetID("tt", tconst)
+	if err != nil {
+		return nil, err
+	}
+
+	position, ok := builder.positions[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &builder.titles[position], nil
+}
+
+func parseDatasetID(prefix, id string) (uint32, error) {
+	number, err := strconv.ParseUint(strings.TrimPrefix(id, prefix), 10, 32)
+	if err != nil || !strings.HasPrefix(id, prefix) {
+		return 0, fmt.Errorf("invalid ID %q", id)
+	}
+
+	return uint32(number), nil
+}
+
+// parseDatasetNumber reads years, runtimes, seasons and episodes, 0 when they are missing or out of range
+func parseDatasetNumber(value string) uint16 {
+	number, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return 0
+	}
+
+	return uint16(number)
+}
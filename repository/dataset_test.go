@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// fixtureDataset builds the dataset of testdata/imdb into a file and loads it
+func fixtureDataset(t *testing.T) model.MovieRepository {
+	fileName := filepath.Join(t.TempDir(), "imdb.dataset")
+	out, _ := os.Create(fileName)
+	count, err := BuildDataset("testdata/imdb", out)
+	out.Close()
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	// the adult title and the pilot are left out
+	assert.Equal(t, 8, count)
+
+	repo, err := NewDatasetRepo(fileName)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	return repo
+}
+
+func TestDatasetRepo(t *testing.T) {
+	repo := fixtureDataset(t)
+
+	t.Run("[SearchMovies] exact title first, then by votes", func(t *testing.T) {
+		result, err := repo.SearchMovies(context.TODO(), "iron man", 1, model.SearchFilter{})
+		if assert.Nil(t, err) && assert.Equal(t, "True", result.Response) {
+			assert.Equal(t, "6", result.TotalResults)
+			ids := []string{}
+			for _, found := range result.Search {
+				ids = append(ids, found.ImdbID)
+			}
+			assert.Equal(t, []string{"tt0371746", "tt1300854", "tt1228705", "tt2654124", "tt0837143", "tt1373211"}, ids)
+			assert.Equal(t, model.SearchDetail{Title: "Iron Man: Armored Adventures", Year: "2008–2012", ImdbID: "tt0837143", Type: "series", Poster: "N/A"}, result.Search[4])
+		}
+	})
+
+	t.Run("[SearchMovies] type and year filters", func(t *testing.T) {
+		result, err := repo.SearchMovies(context.TODO(), "Iron Man", 1, model.SearchFilter{Type: "movie", Year: 2013})
+		if assert.Nil(t, err) && assert.Len(t, result.Search, 2) {
+			assert.Equal(t, "Iron Man Three", result.Search[0].Title)
+			assert.Equal(t, "Iron Man: Rise of Technovore", result.Search[1].Title)
+		}
+	})
+
+	t.Run("[SearchMovies] words in any order and case, punctuation ignored", func(t *testing.T) {
+		result, err := repo.SearchMovies(context.TODO(), "RING lord, fellowship", 1, model.SearchFilter{})
+		if assert.Nil(t, err) && assert.Len(t, result.Search, 1) {
+			assert.Equal(t, "tt0120737", result.Search[0].ImdbID)
+		}
+	})
+
+	t.Run("[SearchMovies] nothing found and pages past the end", func(t *testing.T) {
+		for _, search := range []struct {
+			title string
+			page  uint32
+		}{{"Iron Woman", 1}, {"Iron Man", 2}, {"", 1}} {
+			result, err := repo.SearchMovies(context.TODO(), search.title, search.page, model.SearchFilter{})
+			if assert.Nil(t, err) {
+				assert.Equal(t, &model.MovieSearch{Response: "False", Error: "Movie not found!"}, result)
+			}
+		}
+	})
+
+	t.Run("[GetMovieDetailByID] detail with credits and rating", func(t *testing.T) {
+		detail, err := repo.GetMovieDetailByID(context.TODO(), "tt0371746")
+		if assert.Nil(t, err) {
+			assert.Equal(t, "Iron Man", detail.Title)
+			assert.Equal(t, "2008", detail.Year)
+			assert.Equal(t, "126 min", detail.Runtime)
+			assert.Equal(t, "Action, Adventure, Sci-Fi", detail.Genre)
+			assert.Equal(t, "Jon Favreau", detail.Director)
+			assert.Equal(t, "Mark Fergus, Hawk Ostby", detail.Writer)
+			assert.Equal(t, "Robert Downey Jr., Jeff Bridges, Gwyneth Paltrow, Terrence Howard", detail.Actors)
+			assert.Equal(t, "7.9", detail.ImdbRating)
+			assert.Equal(t, "1,107,231", detail.ImdbVotes)
+			assert.Equal(t, []model.MovieRating{{Source: "Internet Movie Database", Value: "7.9/10"}}, detail.Ratings)
+			assert.Equal(t, "N/A", detail.Plot)
+			assert.Equal(t, "movie", detail.Type)
+			assert.Equal(t, "True", detail.Response)
+			assert.Empty(t, detail.SeriesID)
+		}
+	})
+
+	t.Run("[GetMovieDetailByID] episode of a series, without rating", func(t *testing.T) {
+		detail, err := repo.GetMovieDetailByID(context.TODO(), "tt1373211")
+		if assert.Nil(t, err) {
+			assert.Equal(t, "episode", detail.Type)
+			assert.Equal(t, "tt0837143", detail.SeriesID)
+			assert.Equal(t, "1", detail.Season)
+			assert.Equal(t, "1", detail.Episode)
+			assert.Equal(t, "N/A", detail.ImdbRating)
+			assert.Equal(t, "N/A", detail.Runtime)
+			assert.Equal(t, "N/A", detail.Director)
+			assert.Empty(t, detail.Ratings)
+		}
+	})
+
+	t.Run("[GetMovieDetailByID] unknown, skipped and malformed IDs", func(t *testing.T) {
+		for _, id := range []string{"tt0000001", "tt9999901", "nm0000375", "tt"} {
+			detail, err := repo.GetMovieDetailByID(context.TODO(), id)
+			if assert.Nil(t, err, id) {
+				assert.Equal(t, "Incorrect IMDb ID.", detail.Error, id)
+			}
+		}
+	})
+}
+
+func TestBuildDataset(t *testing.T) {
+	t.Run("[BuildDataset] gzipped files as downloaded", func(t *testing.T) {
+		dir := t.TempDir()
+		files, _ := filepath.Glob("testdata/imdb/*.tsv")
+		for _, file := range files {
+			content, _ := ioutil.ReadFile(file)
+			var gzipped bytes.Buffer
+			writer := gzip.NewWriter(&gzipped)
+			writer.Write(content)
+			writer.Close()
+			ioutil.WriteFile(filepath.Join(dir, filepath.Base(file)+".gz"), gzipped.Bytes(), 0644)
+		}
+
+		count, err := BuildDataset(dir, ioutil.Discard)
+		assert.Nil(t, err)
+		assert.Equal(t, 8, count)
+	})
+
+	t.Run("[BuildDataset] missing file", func(t *testing.T) {
+		dir := t.TempDir()
+		content, _ := ioutil.ReadFile("testdata/imdb/title.basics.tsv")
+		ioutil.WriteFile(filepath.Join(dir, "title.basics.tsv"), content, 0644)
+
+		_, err := BuildDataset(dir, ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "reading title.ratings")
+		}
+	})
+
+	t.Run("[BuildDataset] unexpected columns", func(t *testing.T) {
+		dir := t.TempDir()
+		ioutil.WriteFile(filepath.Join(dir, "title.basics.tsv"), []byte("tconst\tprimaryTitle\ntt0371746\tIron Man\n"), 0644)
+
+		_, err := BuildDataset(dir, ioutil.Discard)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "reading title.basics: expected the columns tconst, titleType")
+		}
+	})
+
+	t.Run("[NewDatasetRepo] not a dataset", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "imdb.dataset")
+		ioutil.WriteFile(fileName, []byte("tconst\n"), 0644)
+
+		_, err := NewDatasetRepo(fileName)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "reading dataset "+fileName)
+		}
+	})
+}
+
+func TestGroupThousands(t *testing.T) {
+	t.Run("[groupThousands]", func(t *testing.T) {
+		assert.Equal(t, "0", groupThousands(0))
+		assert.Equal(t, "999", groupThousands(999))
+		assert.Equal(t, "1,000", groupThousands(1000))
+		assert.Equal(t, "4,294,967,295", groupThousands(4294967295))
+	})
+}
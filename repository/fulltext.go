@@ -0,0 +1,510 @@
+package repository
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// BM25 parameters, the usual ones
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+const (
+	// minPrefixSize is the size of the shortest word matching the words it starts, shorter ones match too many
+	minPrefixSize = 3
+	// prefixWeight discounts a word matched by its start rather than fully
+	prefixWeight = 0.5
+)
+
+// fullTextFields are the fields of a detail searched, by their JSON name in the API, and how much a word
+// found in each counts: a word of the title tells more about a movie than a word of its plot
+var fullTextFields = []struct {
+	name  string
+	boost float64
+	text  func(detail *model.MovieDetail) string
+}{
+	{"title", 3, func(d *model.MovieDetail) string { return d.Title }},
+	{"actors", 2, func(d *model.MovieDetail) string { return d.Actors }},
+	{"director", 2, func(d *model.MovieDetail) string { return d.Director }},
+	{"genre", 1.5, func(d *model.MovieDetail) string { return d.Genre }},
+	{"plot", 1, func(d *model.MovieDetail) string { return d.Plot }},
+}
+
+// fullTextStopWords are left out of queries, unless the query has nothing else
+var fullTextStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "about": true, "by": true, "film": true, "for": true, "from": true,
+	"in": true, "movie": true, "movies": true, "of": true, "on": true, "the": true, "to": true, "with": true,
+}
+
+type (
+	// fullTextDocument is an indexed movie as it is saved, the texts of its fields in the order of fullTextFields.
+	// Writer and Rating aren't searched, similar movies are ranked by them as well
+	fullTextDocument struct {
+		Movie  model.SearchDetail
+		Texts  []string
+		Writer string
+		Rating string
+	}
+
+	// fullTextPosting tells how many times a word is in a field of a document
+	fullTextPosting struct {
+		doc   int32
+		field uint8
+		count uint16
+	}
+)
+
+// fullTextIndex is an inverted index of the movie details in memory, ranked with BM25 over the fields of
+// fullTextFields. It is saved to a gob file, gzipped, when flushed with changes
+type fullTextIndex struct {
+	mutex    *sync.RWMutex
+	fileName string
+
+	docs []*fullTextDocument
+	// lengths counts the words of each field of docs
+	lengths [][]int
+	byID    map[string]int32
+	// postings maps the words to the documents and fields having them, in increasing document order
+	postings map[string][]fullTextPosting
+	// words are the keys of postings, sorted for prefix lookups
+	words []string
+	// totalLengths counts the words of each field over every document
+	totalLengths []int
+	dirty        bool
+}
+
+// NewFullTextIndex loads the index saved in fileName, which is created on the first flush with changes
+func NewFullTextIndex(fileName string) (model.MovieIndex, error) {
+	index := &fullTextIndex{
+		mutex:        &sync.RWMutex{},
+		fileName:     fileName,
+		byID:         map[string]int32{},
+		postings:     map[string][]fullTextPosting{},
+		totalLengths: make([]int, len(fullTextFields)),
+	}
+
+	file, err := os.Open(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading full-text index %s: %w", fileName, err)
+	}
+	var docs []*fullTextDocument
+	if err := gob.NewDecoder(reader).Decode(&docs); err != nil {
+		return nil, fmt.Errorf("reading full-text index %s: %w", fileName, err)
+	}
+	for _, doc := range docs {
+		if len(doc.Texts) == len(fullTextFields) {
+			index.add(doc, int32(len(index.docs)))
+		}
+	}
+
+	return index, nil
+}
+
+func (index *fullTextIndex) Index(ctx context.Context, detail *model.MovieDetail) error {
+	doc := &fullTextDocument{
+		Movie: model.SearchDetail{
+			Title:  detail.Title,
+			Year:   detail.Year,
+			ImdbID: detail.ImdbID,
+			Type:   detail.Type,
+			Poster: detail.Poster,
+		},
+		Texts: make([]string, len(fullTextFields)),
+	}
+	if detail.Writer != notApplicable {
+		doc.Writer = detail.Writer
+	}
+	if detail.ImdbRating != notApplicable {
+		doc.Rating = detail.ImdbRating
+	}
+	for i, field := range fullTextFields {
+		if text := field.text(detail); text != notApplicable {
+			doc.Texts[i] = text
+		}
+	}
+
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	position := int32(len(index.docs))
+	if replaced, ok := index.byID[detail.ImdbID]; ok {
+		if sameDocument(index.docs[replaced], doc) {
+			return nil
+		}
+		// the document is replaced where it was, docs doesn't grow when a movie changes
+		index.remove(replaced)
+		position = replaced
+	}
+	index.add(doc, position)
+	index.dirty = true
+
+	return nil
+}
+
+// add puts doc at position in the documents, after the last one or in the place of a removed one, and its
+// words in the postings. The caller holds the write lock
+func (index *fullTextIndex) add(doc *fullTextDocument, position int32) {
+	if position == int32(len(index.docs)) {
+		index.docs = append(index.docs, doc)
+		index.lengths = append(index.lengths, nil)
+	}
+	index.docs[position] = doc
+	index.byID[doc.Movie.ImdbID] = position
+
+	lengths := make([]int, len(fullTextFields))
+	for field, text := range doc.Texts {
+		counts := map[string]uint16{}
+		var order []string
+		for _, word := range datasetWords(text) {
+			if counts[word] == 0 {
+				order = append(order, word)
+			}
+			counts[word]++
+			lengths[field]++
+		}
+		for _, word := range order {
+			if _, ok := index.postings[word]; !ok {
+				i := sort.SearchStrings(index.words, word)
+				index.words = append(index.words, "")
+				copy(index.words[i+1:], index.words[i:])
+				index.words[i] = word
+			}
+			index.postings[word] = insertPosting(index.postings[word], fullTextPosting{position, uint8(field), counts[word]})
+		}
+		index.totalLengths[field] += lengths[field]
+	}
+	index.lengths[position] = lengths
+}
+
+// insertPosting keeps postings in increasing document order, a document in the place of a removed one
+// doesn't go last
+func insertPosting(postings []fullTextPosting, posting fullTextPosting) []fullTextPosting {
+	i := sort.Search(len(postings), func(i int) bool {
+		return postings[i].doc > posting.doc
+	})
+	postings = append(postings, fullTextPosting{})
+	copy(postings[i+1:], postings[i:])
+	postings[i] = posting
+
+	return postings
+}
+
+// remove takes the document at position out of the postings, add puts the one replacing it in its place
+// so the other positions don't move. The caller holds the write lock
+func (index *fullTextIndex) remove(position int32) {
+	doc := index.docs[position]
+	for _, text := range doc.Texts {
+		for _, word := range datasetWords(text) {
+			postings, ok := index.postings[word]
+			if !ok {
+				continue
+			}
+			kept := postings[:0]
+			for _, posting := range postings {
+				if posting.doc != position {
+					kept = append(kept, posting)
+				}
+			}
+			if len(kept) > 0 {
+				index.postings[word] = kept
+				continue
+			}
+			delete(index.postings, word)
+			i := sort.SearchStrings(index.words, word)
+			index.words = append(index.words[:i], index.words[i+1:]...)
+		}
+	}
+	for field, length := range index.lengths[position] {
+		index.totalLengths[field] -= length
+	}
+
+	delete(index.byID, doc.Movie.ImdbID)
+}
+
+// Search ranks the documents having one of the words of query at least, words of 3 letters or more
+// also match the words they start, for half as much
+func (index *fullTextIndex) Search(ctx context.Context, query string, page uint32, filter model.SearchFilter) (*model.FullTextResult, error) {
+	if page == 0 {
+		page = 1
+	}
+
+	index.mutex.RLock()
+	defer index.mutex.RUnlock()
+
+	count := len(index.byID)
+	if count == 0 {
+		return &model.FullTextResult{}, nil
+	}
+	averages := make([]float64, len(fullTextFields))
+	for field, total := range index.totalLengths {
+		averages[field] = math.Max(float64(total)/float64(count), 1)
+	}
+
+	type match struct {
+		score  float64
+		fields []bool
+	}
+	matches := map[int32]*match{}
+	for _, term := range queryWords(query) {
+		// a document matching a query word several ways only counts its best one
+		best := map[int32]float64{}
+		bestFields := map[int32][]bool{}
+		for _, word := range index.expand(term) {
+			weight := 1.0
+			if word != term {
+				weight = prefixWeight
+			}
+			postings := index.postings[word]
+			idf := math.Log(1 + (float64(count)-float64(documentCount(postings))+0.5)/(float64(documentCount(postings))+0.5))
+
+			scores := map[int32]float64{}
+			fields := map[int32][]bool{}
+			for _, posting := range postings {
+				length := float64(index.lengths[posting.doc][posting.field])
+				frequency := float64(posting.count)
+				norm := frequency * (bm25K1 + 1) / (frequency + bm25K1*(1-bm25B+bm25B*length/averages[posting.field]))
+				scores[posting.doc] += weight * idf * fullTextFields[posting.field].boost * norm
+				if fields[posting.doc] == nil {
+					fields[posting.doc] = make([]bool, len(fullTextFields))
+				}
+				fields[posting.doc][posting.field] = true
+			}
+			for doc, score := range scores {
+				if score > best[doc] {
+					best[doc] = score
+					bestFields[doc] = fields[doc]
+				}
+			}
+		}
+
+		for doc, score := range best {
+			found, ok := matches[doc]
+			if !ok {
+				found = &match{fields: make([]bool, len(fullTextFields))}
+				matches[doc] = found
+			}
+			found.score += score
+			for field, in := range bestFields[doc] {
+				found.fields[field] = found.fields[field] || in
+			}
+		}
+	}
+
+	hits := make([]model.FullTextHit, 0, len(matches))
+	for position, found := range matches {
+		doc := index.docs[position]
+		if !matchesFilter(doc.Movie, filter) {
+			continue
+		}
+
+		hit := model.FullTextHit{Movie: doc.Movie, Score: found.score}
+		for field, in := range found.fields {
+			if in {
+				hit.Fields = append(hit.Fields, fullTextFields[field].name)
+			}
+		}
+		hits = append(hits, hit)
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Movie.ImdbID < hits[j].Movie.ImdbID
+	})
+
+	result := &model.FullTextResult{Total: len(hits)}
+	start := int(page-1) * omdbPageSize
+	if start < len(hits) {
+		result.Hits = hits[start:min(len(hits), start+omdbPageSize)]
+	}
+
+	return result, nil
+}
+
+// expand returns the indexed words term matches, itself and the words it starts when it is long enough.
+// The caller holds the lock
+func (index *fullTextIndex) expand(term string) []string {
+	if len([]rune(term)) < minPrefixSize {
+		if _, ok := index.postings[term]; ok {
+			return []string{term}
+		}
+		return nil
+	}
+
+	var words []string
+	for i := sort.SearchStrings(index.words, term); i < len(index.words) && strings.HasPrefix(index.words[i], term); i++ {
+		words = append(words, index.words[i])
+	}
+
+	return words
+}
+
+// Flush writes the index to a temporary file renamed over the saved one, so a crash never leaves it half written
+func (index *fullTextIndex) Flush(ctx context.Context) error {
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	if !index.dirty {
+		return nil
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(index.fileName), ".fulltext-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	writer := gzip.NewWriter(file)
+	err = gob.NewEncoder(writer).Encode(index.docs)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), index.fileName)
+	}
+	if err != nil {
+		return fmt.Errorf("saving full-text index %s: %w", index.fileName, err)
+	}
+
+	index.dirty = false
+	return nil
+}
+
+// FlushEvery flushes index every interval until ctx is done, changes made since the last flush are
+// lost on a crash
+func FlushEvery(ctx context.Context, index model.MovieIndex, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := index.Flush(ctx); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+// queryWords are the words of query searched, once each and without stop words unless it only has those
+func queryWords(query string) []string {
+	var words, stopWords []string
+	seen := map[string]bool{}
+	for _, word := range datasetWords(query) {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		if fullTextStopWords[word] {
+			stopWords = append(stopWords, word)
+		} else {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return stopWords
+	}
+
+	return words
+}
+
+// documentCount counts the documents of postings, which are in increasing document order
+func documentCount(postings []fullTextPosting) int {
+	count := 0
+	for i, posting := range postings {
+		if i == 0 || posting.doc != postings[i-1].doc {
+			count++
+		}
+	}
+
+	return count
+}
+
+func matchesFilter(movie model.SearchDetail, filter model.SearchFilter) bool {
+	if filter.Type != "" && movie.Type != filter.Type {
+		return false
+	}
+	if filter.Year != 0 {
+		year, err := strconv.Atoi(yearOf(movie.Year))
+		if err != nil || year != filter.Year {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameDocument(a, b *fullTextDocument) bool {
+	if a.Movie != b.Movie || a.Writer != b.Writer || a.Rating != b.Rating {
+		return false
+	}
+	for i := range a.Texts {
+		if a.Texts[i] != b.Texts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// indexedMovieRepo adds every movie detail the wrapped repository finds to a full-text index
+type indexedMovieRepo struct {
+	MovieRepo model.MovieRepository
+	Index     model.MovieIndex
+}
+
+func NewIndexedMovieRepo(movieRepo model.MovieRepository, index model.MovieIndex) model.MovieRepository {
+	return &indexedMovieRepo{
+		MovieRepo: movieRepo,
+		Index:     index,
+	}
+}
+
+func (repo *indexedMovieRepo) SearchMovies(ctx context.Context, title string, page uint32, filter model.SearchFilter) (*model.MovieSearch, error) {
+	return repo.MovieRepo.SearchMovies(ctx, title, page, filter)
+}
+
+func (repo *indexedMovieRepo) GetMovieDetailByID(ctx context.Context, id string) (*model.MovieDetail, error) {
+	detail, err := repo.MovieRepo.GetMovieDetailByID(ctx, id)
+	if err != nil || detail == nil || detail.Error != "" {
+		return detail, err
+	}
+
+	// the detail is still worth answering when it can't be indexed
+	if err := repo.Index.Index(ctx, detail); err != nil {
+		log.Println("indexing", id, ":", err)
+	}
+
+	return detail, nil
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+var fullTextDetails = []*model.MovieDetail{
+	{
+		Title: "Heat", Year: "1995", ImdbID: "tt0113277", Type: "movie", Poster: "N/A", Genre: "Action, Crime, Drama",
+		Director: "Michael Mann", Actors: "Al Pacino, Robert De Niro, Val Kilmer",
+		Plot: "A group of high-end professional thieves start to feel the heat from the LAPD when they unknowingly leave a clue at their latest heist.",
+	},
+	{
+		Title: "Dog Day Afternoon", Year: "1975", ImdbID: "tt0072890", Type: "movie", Poster: "N/A", Genre: "Biography, Crime, Drama",
+		Director: "Sidney Lumet", Actors: "Al Pacino, John Cazale, Penelope Allen",
+		Plot: "Three amateur bank robbers plan to hold up a bank. A nice simple robbery: walk in, take the money, and run.",
+	},
+	{
+		Title: "Ocean's Eleven", Year: "2001", ImdbID: "tt0240772", Type: "movie", Poster: "N/A", Genre: "Crime, Thriller",
+		Director: "Steven Soderbergh", Actors: "George Clooney, Brad Pitt, Julia Roberts",
+		Plot: "Danny Ocean and his ten accomplices plan to rob three Las Vegas casinos simultaneously in a heist.",
+	},
+	{
+		Title: "Heist", Year: "2015", ImdbID: "tt3276924", Type: "movie", Poster: "N/A", Genre: "Action, Crime, Thriller",
+		Director: "Scott Mann", Actors: "Robert De Niro, Jeffrey Dean Morgan, Dave Bautista",
+		Plot: "A father is without the means to pay for his daughter's medical treatment.",
+	},
+	{
+		Title: "Heat Wave", Year: "2009", ImdbID: "tt1000001", Type: "series", Poster: "N/A", Genre: "N/A",
+		Director: "N/A", Actors: "N/A", Plot: "N/A",
+	},
+}
+
+func newTestIndex(t *testing.T) model.MovieIndex {
+	index, err := NewFullTextIndex(filepath.Join(t.TempDir(), "fulltext.index"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, detail := range fullTextDetails {
+		if err := index.Index(context.TODO(), detail); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return index
+}
+
+func hitIDs(result *model.FullTextResult) []string {
+	ids := []string{}
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.Movie.ImdbID)
+	}
+
+	return ids
+}
+
+func TestFullTextIndex(t *testing.T) {
+	t.Run("[Search] ranked by BM25 over the fields, stop words left out", func(t *testing.T) {
+		index := newTestIndex(t)
+
+		result, err := index.Search(context.TODO(), "heist movie with Al Pacino", 1, model.SearchFilter{})
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Equal(t, 4, result.Total)
+		// Heat has both the heist and Al Pacino, a title counts more than a plot
+		assert.Equal(t, []string{"tt0113277", "tt0072890", "tt3276924", "tt0240772"}, hitIDs(result))
+		assert.Equal(t, []string{"actors", "plot"}, result.Hits[0].Fields)
+		assert.Equal(t, []string{"actors"}, result.Hits[1].Fields)
+		assert.Equal(t, []string{"title"}, result.Hits[2].Fields)
+		assert.Greater(t, result.Hits[2].Score, result.Hits[3].Score)
+		assert.Equal(t, fullTextDetails[0].Title, result.Hits[0].Movie.Title)
+		assert.Greater(t, result.Hits[0].Score, result.Hits[1].Score)
+	})
+
+	t.Run("[Search] words match the words they start, for less", func(t *testing.T) {
+		index := newTestIndex(t)
+
+		result, err := index.Search(context.TODO(), "pacin", 1, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.ElementsMatch(t, []string{"tt0113277", "tt0072890"}, hitIDs(result))
+		}
+
+		// heat is a word of its own and the start of none, heist only starts heist
+		exact, _ := index.Search(context.TODO(), "heat", 1, model.SearchFilter{})
+		prefix, _ := index.Search(context.TODO(), "hea", 1, model.SearchFilter{})
+		if assert.Len(t, exact.Hits, 2) && assert.Len(t, prefix.Hits, 2) {
+			assert.Greater(t, exact.Hits[0].Score, prefix.Hits[0].Score)
+		}
+
+		// too short to match anything but itself
+		result, _ = index.Search(context.TODO(), "he", 1, model.SearchFilter{})
+		assert.Empty(t, result.Hits)
+	})
+
+	t.Run("[Search] several words, filters and pages", func(t *testing.T) {
+		index := newTestIndex(t)
+
+		// robert also starts Julia Roberts
+		result, _ := index.Search(context.TODO(), "robert de niro", 1, model.SearchFilter{})
+		if assert.Len(t, result.Hits, 3) {
+			assert.ElementsMatch(t, []string{"tt0113277", "tt3276924"}, hitIDs(result)[:2])
+			assert.Equal(t, "tt0240772", result.Hits[2].Movie.ImdbID)
+		}
+
+		result, _ = index.Search(context.TODO(), "heat", 1, model.SearchFilter{Type: "series"})
+		assert.Equal(t, []string{"tt1000001"}, hitIDs(result))
+
+		result, _ = index.Search(context.TODO(), "crime", 1, model.SearchFilter{Year: 2001})
+		assert.Equal(t, []string{"tt0240772"}, hitIDs(result))
+
+		result, _ = index.Search(context.TODO(), "crime", 2, model.SearchFilter{})
+		assert.Equal(t, 4, result.Total)
+		assert.Empty(t, result.Hits)
+	})
+
+	t.Run("[Index] a movie indexed again replaces its words", func(t *testing.T) {
+		index := newTestIndex(t)
+
+		changed := *fullTextDetails[3]
+		changed.Title = "Bus 657"
+		if !assert.Nil(t, index.Index(context.TODO(), &changed)) {
+			return
+		}
+
+		result, _ := index.Search(context.TODO(), "heist", 1, model.SearchFilter{})
+		assert.NotContains(t, hitIDs(result), "tt3276924")
+		result, _ = index.Search(context.TODO(), "bus", 1, model.SearchFilter{})
+		assert.Equal(t, []string{"tt3276924"}, hitIDs(result))
+	})
+
+	t.Run("[Index] a movie indexed again keeps its place", func(t *testing.T) {
+		index := newTestIndex(t).(*fullTextIndex)
+
+		for _, plot := range []string{"A detective hunts a crew of thieves.", "Al Pacino chases Robert De Niro."} {
+			changed := *fullTextDetails[0]
+			changed.Plot = plot
+			if !assert.Nil(t, index.Index(context.TODO(), &changed)) {
+				return
+			}
+		}
+
+		assert.Len(t, index.docs, len(fullTextDetails))
+		assert.Equal(t, int32(0), index.byID["tt0113277"])
+		// Heat is back first among the documents of pacino, counted once despite being in two of its fields
+		postings := index.postings["pacino"]
+		if assert.Len(t, postings, 3) {
+			assert.Equal(t, []int32{0, 0, 1}, []int32{postings[0].doc, postings[1].doc, postings[2].doc})
+			assert.Equal(t, 2, documentCount(postings))
+		}
+		result, _ := index.Search(context.TODO(), "pacino", 1, model.SearchFilter{})
+		assert.Equal(t, []string{"tt0113277", "tt0072890"}, hitIDs(result))
+	})
+
+	t.Run("[Flush] saved index is loaded back, only once changed", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "fulltext.index")
+		index, err := NewFullTextIndex(fileName)
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		assert.Nil(t, index.Flush(context.TODO()))
+		_, err = os.Stat(fileName)
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+
+		for _, detail := range fullTextDetails {
+			index.Index(context.TODO(), detail)
+		}
+		if !assert.Nil(t, index.Flush(context.TODO())) {
+			return
+		}
+
+		loaded, err := NewFullTextIndex(fileName)
+		if assert.Nil(t, err) {
+			expected, _ := index.Search(context.TODO(), "heist al pacino", 1, model.SearchFilter{})
+			actual, _ := loaded.Search(context.TODO(), "heist al pacino", 1, model.SearchFilter{})
+			assert.Equal(t, expected, actual)
+		}
+	})
+
+	t.Run("[NewFullTextIndex] unreadable index", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "fulltext.index")
+		os.WriteFile(fileName, []byte("not gzip"), 0o644)
+
+		_, err := NewFullTextIndex(fileName)
+		assert.Error(t, err)
+	})
+}
+
+func TestIndexedMovieRepo(t *testing.T) {
+	t.Run("[GetMovieDetailByID] found details are indexed", func(t *testing.T) {
+		movieRepo := &mocks.MovieRepository{}
+		movieRepo.On("GetMovieDetailByID", testify.Anything, "tt0113277").Return(fullTextDetails[0], nil)
+		movieRepo.On("GetMovieDetailByID", testify.Anything, "tt0000001").Return(&model.MovieDetail{Response: "False", Error: "Incorrect IMDb ID."}, nil)
+		movieIndex := &mocks.MovieIndex{}
+		movieIndex.On("Index", testify.Anything, fullTextDetails[0]).Return(errors.New("disk full"))
+
+		repo := NewIndexedMovieRepo(movieRepo, movieIndex)
+		detail, err := repo.GetMovieDetailByID(context.TODO(), "tt0113277")
+		if assert.Nil(t, err) {
+			assert.Equal(t, fullTextDetails[0], detail)
+		}
+		_, err = repo.GetMovieDetailByID(context.TODO(), "tt0000001")
+		assert.Nil(t, err)
+
+		movieIndex.AssertNumberOfCalls(t, "Index", 1)
+	})
+}
@@ -1,42 +1,59 @@
 package repository
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	model "github.com/zenkobert/sbtest-2/domain"
 
 	"github.com/zenkobert/sbtest-2/common"
+	"github.com/zenkobert/sbtest-2/common/redact"
+	"github.com/zenkobert/sbtest-2/common/tracing"
 )
 
-var host string = "http://www.omdbapi.com"
+// omdbRequestLimitReached is the error OMDb answers with once the api key daily limit is used up
+const omdbRequestLimitReached = "Request limit reached!"
 
 type movieRepo struct {
 	Client common.HTTPClient
+	host   string
 	apiKey string
 }
 
-func NewMovieRepo(apiKey string) model.MovieRepository {
+func NewMovieRepo(host, apiKey string, timeout time.Duration) model.MovieRepository {
 	return &movieRepo{
-		Client: &http.Client{},
+		Client: tracing.NewHTTPClient(&http.Client{Timeout: timeout}),
+		host:   strings.TrimSuffix(host, "/"),
 		apiKey: apiKey,
 	}
 }
 
-func (repo *movieRepo) SearchMovies(title string, page uint32) (result *model.MovieSearch, err error) {
-	url := fmt.Sprintf("%s/?apikey=%s&s=%s&page=%d", host, repo.apiKey, title, page)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (repo *movieRepo) SearchMovies(ctx context.Context, title string, page uint32, filter model.SearchFilter) (result *model.MovieSearch, err error) {
+	params := neturl.Values{"s": {title}, "page": {strconv.FormatUint(uint64(page), 10)}}
+	if filter.Type != "" {
+		params.Set("type", filter.Type)
+	}
+	if filter.Year != 0 {
+		params.Set("y", strconv.Itoa(filter.Year))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, repo.url(params), nil)
 	if err != nil {
+		err = redact.Error(err)
 		log.Println(err)
 		return result, err
 	}
 
 	resp, err := repo.Client.Do(req)
 	if err != nil {
+		err = redact.Error(err)
 		log.Println(err)
 		return result, err
 	}
@@ -58,22 +75,23 @@ func (repo *movieRepo) SearchMovies(title string, page uint32) (result *model.Mo
 
 		if resp.StatusCode >= 400 {
 			log.Println(result.Error)
-			return result, errors.New("oops, something happened")
+			return result, upstreamError(result.Error)
 		}
 	}
 	return result, nil
 }
 
-func (repo *movieRepo) GetMovieDetailByID(id string) (detail *model.MovieDetail, err error) {
-	url := fmt.Sprintf("%s/?apikey=%s&i=%s", host, repo.apiKey, id)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (repo *movieRepo) GetMovieDetailByID(ctx context.Context, id string) (detail *model.MovieDetail, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, repo.url(neturl.Values{"i": {id}}), nil)
 	if err != nil {
+		err = redact.Error(err)
 		log.Println(err)
 		return detail, err
 	}
 
 	resp, err := repo.Client.Do(req)
 	if err != nil {
+		err = redact.Error(err)
 		log.Println(err)
 		return detail, err
 	}
@@ -94,9 +112,45 @@ func (repo *movieRepo) GetMovieDetailByID(id string) (detail *model.MovieDetail,
 
 		if resp.StatusCode >= 400 {
 			log.Println(detail.Error)
-			return detail, errors.New("oops, something happened")
+			return detail, upstreamError(detail.Error)
 		}
 	}
 
 	return detail, nil
 }
+
+// url is the OMDb URL of a request with params, escaped whatever they hold
+func (repo *movieRepo) url(params neturl.Values) string {
+	params.Set("apikey", repo.apiKey)
+	return repo.host + "/?" + params.Encode()
+}
+
+// upstreamError tells the quota errors apart from the other OMDb error answers
+func upstreamError(message string) error {
+	if message == omdbRequestLimitReached {
+		return model.ErrQuotaExceeded
+	}
+
+	return model.ErrUpstream
+}
+
+// Check reports whether OMDb is reachable. The probe carries no api key so it
+// doesn't count against the daily quota, any non 5xx answer means the API is up
+func (repo *movieRepo) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, repo.host, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := repo.Client.Do(req)
+	if err != nil {
+		return redact.Error(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("omdb responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -2,15 +2,21 @@ package repository
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"net/url"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	testify "github.com/stretchr/testify/mock"
 	"github.com/zenkobert/sbtest-2/common/mocks"
+	"github.com/zenkobert/sbtest-2/common/tracing"
 	model "github.com/zenkobert/sbtest-2/domain"
 )
 
@@ -48,6 +54,11 @@ var (
 		"Error": "Invalid API Key"
 	}`
 
+	quotaJsonResponse = `{
+		"Response": "False",
+		"Error": "Request limit reached!"
+	}`
+
 	getMovieDetailJsonResponse = `
 		{
 			"Title": "title",
@@ -87,11 +98,12 @@ func TestNewMovieRepo(t *testing.T) {
 		apiKey := "randomKey"
 
 		expected := &movieRepo{
-			Client: &http.Client{},
+			Client: tracing.NewHTTPClient(&http.Client{Timeout: time.Second}),
+			host:   "http://www.omdbapi.com",
 			apiKey: apiKey,
 		}
 
-		actual := NewMovieRepo(apiKey)
+		actual := NewMovieRepo("http://www.omdbapi.com/", apiKey, time.Second)
 		assert.Equal(t, expected, actual)
 	})
 }
@@ -106,7 +118,7 @@ func TestSearchMovie(t *testing.T) {
 			apiKey: "abc",
 		}
 
-		_, err := movieRepo.SearchMovies("ironman", 1)
+		_, err := movieRepo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{})
 		assert.Error(t, err)
 	})
 
@@ -119,7 +131,7 @@ func TestSearchMovie(t *testing.T) {
 			apiKey: "abc",
 		}
 
-		_, err := movieRepo.SearchMovies("ironman", 1)
+		_, err := movieRepo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{})
 		if assert.Error(t, err) {
 			assert.Equal(t, "read error", err.Error())
 		}
@@ -143,12 +155,48 @@ func TestSearchMovie(t *testing.T) {
 			TotalResults: "1",
 			Response:     "True",
 		}
-		result, err := movieRepo.SearchMovies("ironman", 1)
+		result, err := movieRepo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{})
 		if assert.Nil(t, err) {
 			assert.Equal(t, expectedResult, result)
 		}
 	})
 
+	t.Run("[SearchMovies] filter is sent as OMDb params", func(t *testing.T) {
+		httpClientMock := &mocks.HTTPClient{}
+		dummyBody := ioutil.NopCloser(bytes.NewReader([]byte(searchMovieJsonResponse)))
+
+		httpClientMock.On("Do", testify.MatchedBy(func(req *http.Request) bool {
+			query := req.URL.Query()
+			return query.Get("s") == "ironman" && query.Get("type") == "series" && query.Get("y") == "2008"
+		})).Return(&http.Response{Body: dummyBody, StatusCode: 200}, nil)
+
+		movieRepo := &movieRepo{
+			Client: httpClientMock,
+			apiKey: "abc",
+		}
+
+		_, err := movieRepo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{Type: "series", Year: 2008})
+		assert.Nil(t, err)
+	})
+
+	t.Run("[SearchMovies] searchword escaped, it can't add OMDb params", func(t *testing.T) {
+		httpClientMock := &mocks.HTTPClient{}
+		dummyBody := ioutil.NopCloser(bytes.NewReader([]byte(searchMovieJsonResponse)))
+
+		httpClientMock.On("Do", testify.MatchedBy(func(req *http.Request) bool {
+			query := req.URL.Query()
+			return query.Get("s") == "Fast & Furious #1&apikey=other" && query.Get("apikey") == "abc" && query.Get("page") == "2"
+		})).Return(&http.Response{Body: dummyBody, StatusCode: 200}, nil)
+
+		movieRepo := &movieRepo{
+			Client: httpClientMock,
+			apiKey: "abc",
+		}
+
+		_, err := movieRepo.SearchMovies(context.TODO(), "Fast & Furious #1&apikey=other", 2, model.SearchFilter{})
+		assert.Nil(t, err)
+	})
+
 	t.Run("[SearchMovies] response body unmarshall error", func(t *testing.T) {
 		httpClientMock := &mocks.HTTPClient{}
 		dummyBody := ioutil.NopCloser(bytes.NewReader([]byte(searchMovieInvalidJsonResponse)))
@@ -160,7 +208,7 @@ func TestSearchMovie(t *testing.T) {
 			apiKey: "abc",
 		}
 
-		result, err := movieRepo.SearchMovies("ironman", 1)
+		result, err := movieRepo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{})
 		fmt.Println(result)
 		assert.Error(t, err)
 	})
@@ -176,11 +224,26 @@ func TestSearchMovie(t *testing.T) {
 			apiKey: "abc",
 		}
 
-		_, err := movieRepo.SearchMovies("ironman", 1)
+		_, err := movieRepo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{})
 		if assert.Error(t, err) {
 			assert.Equal(t, errors.New("oops, something happened"), err)
 		}
 	})
+
+	t.Run("[SearchMovies] request limit reached", func(t *testing.T) {
+		httpClientMock := &mocks.HTTPClient{}
+		dummyBody := ioutil.NopCloser(bytes.NewReader([]byte(quotaJsonResponse)))
+
+		httpClientMock.On("Do", testify.Anything).Return(&http.Response{Body: dummyBody, StatusCode: 401}, nil)
+
+		movieRepo := &movieRepo{
+			Client: httpClientMock,
+			apiKey: "abc",
+		}
+
+		_, err := movieRepo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{})
+		assert.Equal(t, model.ErrQuotaExceeded, err)
+	})
 }
 
 func TestGetMovieDetailByID(t *testing.T) {
@@ -193,7 +256,7 @@ func TestGetMovieDetailByID(t *testing.T) {
 			apiKey: "abc",
 		}
 
-		_, err := movieRepo.GetMovieDetailByID("id")
+		_, err := movieRepo.GetMovieDetailByID(context.TODO(), "id")
 		assert.Error(t, err)
 	})
 
@@ -206,7 +269,7 @@ func TestGetMovieDetailByID(t *testing.T) {
 			apiKey: "abc",
 		}
 
-		_, err := movieRepo.GetMovieDetailByID("id")
+		_, err := movieRepo.GetMovieDetailByID(context.TODO(), "id")
 		if assert.Error(t, err) {
 			assert.Equal(t, "read error", err.Error())
 		}
@@ -231,7 +294,7 @@ func TestGetMovieDetailByID(t *testing.T) {
 				{"source", "value"},
 			},
 		}
-		result, err := movieRepo.GetMovieDetailByID("id")
+		result, err := movieRepo.GetMovieDetailByID(context.TODO(), "id")
 		if assert.Nil(t, err) {
 			assert.Equal(t, expectedResult, result)
 		}
@@ -248,7 +311,7 @@ func TestGetMovieDetailByID(t *testing.T) {
 			apiKey: "abc",
 		}
 
-		result, err := movieRepo.GetMovieDetailByID("id")
+		result, err := movieRepo.GetMovieDetailByID(context.TODO(), "id")
 		fmt.Println(result)
 		assert.Error(t, err)
 	})
@@ -264,9 +327,89 @@ func TestGetMovieDetailByID(t *testing.T) {
 			apiKey: "abc",
 		}
 
-		_, err := movieRepo.GetMovieDetailByID("id")
+		_, err := movieRepo.GetMovieDetailByID(context.TODO(), "id")
 		if assert.Error(t, err) {
 			assert.Equal(t, errors.New("oops, something happened"), err)
 		}
 	})
+
+	t.Run("[GetMovieDetailByID] request limit reached", func(t *testing.T) {
+		httpClientMock := &mocks.HTTPClient{}
+		dummyBody := ioutil.NopCloser(bytes.NewReader([]byte(quotaJsonResponse)))
+
+		httpClientMock.On("Do", testify.Anything).Return(&http.Response{Body: dummyBody, StatusCode: 401}, nil)
+
+		movieRepo := &movieRepo{
+			Client: httpClientMock,
+			apiKey: "abc",
+		}
+
+		_, err := movieRepo.GetMovieDetailByID(context.TODO(), "id")
+		assert.Equal(t, model.ErrQuotaExceeded, err)
+	})
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("[Check] omdb unreachable", func(t *testing.T) {
+		httpClientMock := &mocks.HTTPClient{}
+		httpClientMock.On("Do", testify.Anything).Return(nil, errors.New("dial tcp: no such host"))
+
+		movieRepo := &movieRepo{Client: httpClientMock}
+		assert.Error(t, movieRepo.Check(context.TODO()))
+	})
+
+	t.Run("[Check] omdb responds with 5xx", func(t *testing.T) {
+		httpClientMock := &mocks.HTTPClient{}
+		httpClientMock.On("Do", testify.Anything).Return(&http.Response{Body: http.NoBody, StatusCode: 503}, nil)
+
+		movieRepo := &movieRepo{Client: httpClientMock}
+		assert.Error(t, movieRepo.Check(context.TODO()))
+	})
+
+	t.Run("[Check] probe never sends the api key", func(t *testing.T) {
+		httpClientMock := &mocks.HTTPClient{}
+		httpClientMock.On("Do", testify.MatchedBy(func(req *http.Request) bool {
+			return req.URL.Query().Get("apikey") == ""
+		})).Return(&http.Response{Body: http.NoBody, StatusCode: 401}, nil)
+
+		movieRepo := &movieRepo{Client: httpClientMock, apiKey: "abc"}
+		assert.Nil(t, movieRepo.Check(context.TODO()))
+	})
+}
+
+func TestAPIKeyRedaction(t *testing.T) {
+	apiKey := "s3cr3tKey"
+	urlErr := func(req *http.Request) error {
+		return &url.Error{Op: "Get", URL: req.URL.String(), Err: errors.New("dial tcp: i/o timeout")}
+	}
+
+	for name, call := range map[string]func(repo *movieRepo) error{
+		"SearchMovies": func(repo *movieRepo) error {
+			_, err := repo.SearchMovies(context.TODO(), "ironman", 1, model.SearchFilter{})
+			return err
+		},
+		"GetMovieDetailByID": func(repo *movieRepo) error {
+			_, err := repo.GetMovieDetailByID(context.TODO(), "tt0371746")
+			return err
+		},
+		"Check": func(repo *movieRepo) error {
+			return repo.Check(context.TODO())
+		},
+	} {
+		t.Run(fmt.Sprintf("[%s] api key is not in the error nor the log", name), func(t *testing.T) {
+			logOutput := &bytes.Buffer{}
+			log.SetOutput(logOutput)
+			defer log.SetOutput(os.Stderr)
+
+			httpClientMock := &mocks.HTTPClient{}
+			httpClientMock.On("Do", testify.Anything).Return(nil, urlErr)
+
+			movieRepo := &movieRepo{Client: httpClientMock, host: "http://www.omdbapi.com", apiKey: apiKey}
+			err := call(movieRepo)
+			if assert.Error(t, err) {
+				assert.NotContains(t, err.Error(), apiKey)
+			}
+			assert.NotContains(t, logOutput.String(), apiKey)
+		})
+	}
 }
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"log"
 	"os"
 	"sync"
@@ -31,3 +32,16 @@ func (db *movieDB) Log(record string) error {
 
 	return nil
 }
+
+// Check makes sure the log file can still be opened for writing
+func (db *movieDB) Check(ctx context.Context) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	f, err := os.OpenFile(db.fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"os"
 	"sync"
 	"testing"
@@ -43,3 +44,18 @@ func TestLog(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestCheckLogFile(t *testing.T) {
+	t.Run("[Check] log file is writable", func(t *testing.T) {
+		tempFileName := "tempCheck.log"
+		defer os.Remove(tempFileName)
+
+		movieDB := NewMovieDB(tempFileName)
+		assert.Nil(t, movieDB.Check(context.TODO()))
+	})
+
+	t.Run("[Check] return error if malformed filename", func(t *testing.T) {
+		movieDB := NewMovieDB("tempF///ilelog")
+		assert.Error(t, movieDB.Check(context.TODO()))
+	})
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// reviewFileRepo keeps every review in memory and in a JSON file, rewritten on each change like watchlistFileRepo
+type reviewFileRepo struct {
+	mutex    *sync.Mutex
+	fileName string
+	reviews  map[string]*model.Review
+}
+
+// NewReviewFileRepo loads the reviews saved in fileName, which is created on the first change
+func NewReviewFileRepo(fileName string) (model.ReviewRepository, error) {
+	repo := &reviewFileRepo{
+		mutex:    &sync.Mutex{},
+		fileName: fileName,
+		reviews:  map[string]*model.Review{},
+	}
+
+	content, err := ioutil.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return repo, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var reviews []*model.Review
+	if err := json.Unmarshal(content, &reviews); err != nil {
+		return nil, fmt.Errorf("reading reviews from %s: %w", fileName, err)
+	}
+	for _, review := range reviews {
+		repo.reviews[review.ID] = review
+	}
+
+	return repo, nil
+}
+
+func (repo *reviewFileRepo) Upsert(ctx context.Context, id string, change func(review *model.Review) error) (*model.Review, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	// change works on a copy so a failed change or save leaves the review as it was
+	review := &model.Review{}
+	if saved, ok := repo.reviews[id]; ok {
+		copied := *saved
+		review = &copied
+	}
+	if err := change(review); err != nil {
+		return nil, err
+	}
+	review.ID = id
+
+	reviews := repo.copyReviews()
+	reviews[id] = review
+	if err := repo.save(reviews); err != nil {
+		return nil, err
+	}
+
+	repo.reviews = reviews
+	copied := *review
+	return &copied, nil
+}
+
+func (repo *reviewFileRepo) Get(ctx context.Context, id string) (*model.Review, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	review, ok := repo.reviews[id]
+	if !ok {
+		return nil, model.ErrReviewNotFound
+	}
+
+	copied := *review
+	return &copied, nil
+}
+
+func (repo *reviewFileRepo) Delete(ctx context.Context, id string) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.reviews[id]; !ok {
+		return model.ErrReviewNotFound
+	}
+
+	reviews := repo.copyReviews()
+	delete(reviews, id)
+	if err := repo.save(reviews); err != nil {
+		return err
+	}
+
+	repo.reviews = reviews
+	return nil
+}
+
+func (repo *reviewFileRepo) ByMovie(ctx context.Context, imdbID string) ([]*model.Review, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	reviews := []*model.Review{}
+	for _, review := range repo.reviews {
+		if review.ImdbID == imdbID {
+			copied := *review
+			reviews = append(reviews, &copied)
+		}
+	}
+	sort.Slice(reviews, func(i, j int) bool {
+		if !reviews[i].CreatedAt.Equal(reviews[j].CreatedAt) {
+			return reviews[i].CreatedAt.After(reviews[j].CreatedAt)
+		}
+		return reviews[i].ID < reviews[j].ID
+	})
+
+	return reviews, nil
+}
+
+// copyReviews returns a new map of the reviews in memory, changes are made to it and only kept once saved.
+// The caller holds the mutex
+func (repo *reviewFileRepo) copyReviews() map[string]*model.Review {
+	reviews := make(map[string]*model.Review, len(repo.reviews)+1)
+	for id, review := range repo.reviews {
+		reviews[id] = review
+	}
+
+	return reviews
+}
+
+// save writes reviews to the file, the caller holds the mutex
+func (repo *reviewFileRepo) save(reviews map[string]*model.Review) error {
+	sorted := make([]*model.Review, 0, len(reviews))
+	for _, review := range reviews {
+		sorted = append(sorted, review)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	content, err := json.MarshalIndent(sorted, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(repo.fileName, content)
+}
+
+// Check makes sure the directory of the file can still be written to
+func (repo *reviewFileRepo) Check(ctx context.Context) error {
+	f, err := ioutil.TempFile(filepath.Dir(repo.fileName), ".reviews-check-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+
+	return os.Remove(f.Name())
+}
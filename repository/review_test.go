@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenkobert/sbtest-2/common"
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// rate returns a change giving the review rating, set up as a new review of imdbID when it is one
+func rate(imdbID, author string, rating int, createdAt time.Time) func(review *model.Review) error {
+	return func(review *model.Review) error {
+		if review.CreatedAt.IsZero() {
+			review.ImdbID = imdbID
+			review.Author = author
+			review.State = model.ReviewPublished
+			review.CreatedAt = createdAt
+		}
+		review.Rating = rating
+		return nil
+	}
+}
+
+func TestReviewFileRepo(t *testing.T) {
+	created := time.Date(2021, 9, 3, 16, 20, 0, 0, time.UTC)
+
+	t.Run("[NewReviewFileRepo] missing file is an empty store", func(t *testing.T) {
+		repo, err := NewReviewFileRepo(filepath.Join(t.TempDir(), "reviews.json"))
+		if assert.Nil(t, err) {
+			reviews, err := repo.ByMovie(context.TODO(), "tt0371746")
+			assert.Nil(t, err)
+			assert.Empty(t, reviews)
+		}
+	})
+
+	t.Run("[NewReviewFileRepo] corrupted file", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "reviews.json")
+		ioutil.WriteFile(fileName, []byte("{"), 0644)
+
+		_, err := NewReviewFileRepo(fileName)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "reading reviews from "+fileName)
+		}
+	})
+
+	t.Run("[Upsert] reviews survive a restart", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "reviews.json")
+		repo, _ := NewReviewFileRepo(fileName)
+		repo.Upsert(context.TODO(), "a", rate("tt0371746", "user-1", 8, created))
+		repo.Upsert(context.TODO(), "b", rate("tt0371746", "user-2", 6, created.Add(time.Hour)))
+		repo.Upsert(context.TODO(), "c", rate("tt1228705", "user-1", 7, created))
+		review, err := repo.Upsert(context.TODO(), "a", rate("tt0371746", "user-1", 9, created.Add(2*time.Hour)))
+		if assert.Nil(t, err) {
+			assert.Equal(t, "a", review.ID)
+			assert.Equal(t, 9, review.Rating)
+			assert.Equal(t, created, review.CreatedAt)
+		}
+
+		reopened, err := NewReviewFileRepo(fileName)
+		if assert.Nil(t, err) {
+			reviews, err := reopened.ByMovie(context.TODO(), "tt0371746")
+			if assert.Nil(t, err) && assert.Len(t, reviews, 2) {
+				// newest first
+				assert.Equal(t, "b", reviews[0].ID)
+				assert.Equal(t, "a", reviews[1].ID)
+				assert.Equal(t, 9, reviews[1].Rating)
+			}
+		}
+
+		// no temporary file is left behind
+		files, _ := ioutil.ReadDir(filepath.Dir(fileName))
+		assert.Len(t, files, 1)
+	})
+
+	t.Run("[Upsert] failed change saves nothing", func(t *testing.T) {
+		repo, _ := NewReviewFileRepo(filepath.Join(t.TempDir(), "reviews.json"))
+		repo.Upsert(context.TODO(), "a", rate("tt0371746", "user-1", 8, created))
+
+		_, err := repo.Upsert(context.TODO(), "a", func(review *model.Review) error {
+			review.Rating = 1
+			return errors.New("error")
+		})
+		assert.Equal(t, "error", err.Error())
+
+		_, err = repo.Upsert(context.TODO(), "b", func(review *model.Review) error {
+			return errors.New("error")
+		})
+		assert.Equal(t, "error", err.Error())
+
+		review, _ := repo.Get(context.TODO(), "a")
+		assert.Equal(t, 8, review.Rating)
+		_, err = repo.Get(context.TODO(), "b")
+		assert.Equal(t, model.ErrReviewNotFound, err)
+	})
+
+	t.Run("[Upsert] write error keeps nothing", func(t *testing.T) {
+		repo, _ := NewReviewFileRepo(filepath.Join(t.TempDir(), "missing", "reviews.json"))
+
+		_, err := repo.Upsert(context.TODO(), "a", rate("tt0371746", "user-1", 8, created))
+		assert.Error(t, err)
+
+		_, err = repo.Get(context.TODO(), "a")
+		assert.Equal(t, model.ErrReviewNotFound, err)
+	})
+
+	t.Run("[Get] returned reviews don't change the stored ones", func(t *testing.T) {
+		repo, _ := NewReviewFileRepo(filepath.Join(t.TempDir(), "reviews.json"))
+		repo.Upsert(context.TODO(), "a", rate("tt0371746", "user-1", 8, created))
+
+		review, _ := repo.Get(context.TODO(), "a")
+		review.Rating = 1
+		reviews, _ := repo.ByMovie(context.TODO(), "tt0371746")
+		reviews[0].Rating = 1
+
+		review, _ = repo.Get(context.TODO(), "a")
+		assert.Equal(t, 8, review.Rating)
+	})
+
+	t.Run("[Delete] deleted reviews are gone after a restart", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "reviews.json")
+		repo, _ := NewReviewFileRepo(fileName)
+		repo.Upsert(context.TODO(), "a", rate("tt0371746", "user-1", 8, created))
+
+		assert.Nil(t, repo.Delete(context.TODO(), "a"))
+		assert.Equal(t, model.ErrReviewNotFound, repo.Delete(context.TODO(), "a"))
+
+		reopened, _ := NewReviewFileRepo(fileName)
+		_, err := reopened.Get(context.TODO(), "a")
+		assert.Equal(t, model.ErrReviewNotFound, err)
+	})
+
+	t.Run("[Check] directory of the file", func(t *testing.T) {
+		dir := t.TempDir()
+		repo, _ := NewReviewFileRepo(filepath.Join(dir, "reviews.json"))
+		checker := repo.(common.HealthChecker)
+		assert.Nil(t, checker.Check(context.TODO()))
+
+		os.RemoveAll(dir)
+		assert.Error(t, checker.Check(context.TODO()))
+	})
+}
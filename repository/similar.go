@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+const (
+	// maxRatingGap is the widest gap between two IMDb ratings, rated 1 and 10, their ratings share nothing
+	maxRatingGap = 9
+	// closeRatingGap is the widest gap between the ratings of movies rated alike
+	closeRatingGap = 1
+)
+
+// similarFeatures are what movies are compared on, the names of the people lower case. decade and rating
+// are 0 when unknown
+type similarFeatures struct {
+	genres    []string
+	directors []string
+	writers   []string
+	cast      []string
+	decade    int
+	rating    float64
+}
+
+func detailFeatures(detail *model.MovieDetail) *similarFeatures {
+	rating, _ := strconv.ParseFloat(detail.ImdbRating, 64)
+
+	return &similarFeatures{
+		genres:    similarNames(detail.Genre),
+		directors: similarNames(detail.Director),
+		writers:   similarNames(detail.Writer),
+		cast:      similarNames(detail.Actors),
+		decade:    releaseDecade(detail.Year),
+		rating:    rating,
+	}
+}
+
+func documentFeatures(doc *fullTextDocument) *similarFeatures {
+	features := &similarFeatures{
+		writers: similarNames(doc.Writer),
+		decade:  releaseDecade(doc.Movie.Year),
+	}
+	features.rating, _ = strconv.ParseFloat(doc.Rating, 64)
+	for i, field := range fullTextFields {
+		switch field.name {
+		case "genre":
+			features.genres = similarNames(doc.Texts[i])
+		case "director":
+			features.directors = similarNames(doc.Texts[i])
+		case "actors":
+			features.cast = similarNames(doc.Texts[i])
+		}
+	}
+
+	return features
+}
+
+// similarNames are the names of a list separated by commas, once each and lower case. What writers wrote
+// is left out, Jonathan Nolan (screenplay) and Jonathan Nolan (story) are the same writer
+func similarNames(list string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, name := range strings.Split(list, ",") {
+		if i := strings.IndexByte(name, '('); i >= 0 {
+			name = name[:i]
+		}
+		name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+		if name == "" || name == strings.ToLower(notApplicable) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// releaseDecade is the decade year, a year or the years of a series, starts in
+func releaseDecade(year string) int {
+	start, err := strconv.Atoi(yearOf(year))
+	if err != nil {
+		return 0
+	}
+
+	return start / 10 * 10
+}
+
+// overlap is the Jaccard index of two sets of names, how many they share out of how many they have
+func overlap(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for _, name := range a {
+		for _, other := range b {
+			if name == other {
+				shared++
+				break
+			}
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// similarity scores how much other is like movie. It is 0, sharing nothing, when they have neither a genre
+// nor a person in common
+func similarity(movie, other *similarFeatures, weights model.SimilarityWeights) (score float64, shared []string) {
+	for _, feature := range []struct {
+		name   string
+		weight float64
+		a, b   []string
+	}{
+		{"genres", weights.Genres, movie.genres, other.genres},
+		{"director", weights.Director, movie.directors, other.directors},
+		{"writers", weights.Writers, movie.writers, other.writers},
+		{"cast", weights.Cast, movie.cast, other.cast},
+	} {
+		if share := overlap(feature.a, feature.b); share > 0 {
+			score += feature.weight * share
+			shared = append(shared, feature.name)
+		}
+	}
+	if len(shared) == 0 {
+		return 0, nil
+	}
+
+	if movie.decade != 0 && other.decade != 0 {
+		switch gap := movie.decade - other.decade; {
+		case gap == 0:
+			score += weights.Decade
+			shared = append(shared, "decade")
+		case gap == 10 || gap == -10:
+			score += weights.Decade / 2
+		}
+	}
+	if movie.rating != 0 && other.rating != 0 {
+		gap := math.Abs(movie.rating - other.rating)
+		score += weights.Rating * math.Max(0, 1-gap/maxRatingGap)
+		if gap <= closeRatingGap {
+			shared = append(shared, "rating")
+		}
+	}
+
+	return score, shared
+}
+
+// Similar compares detail to every movie indexed, the ones scoring alike rank by IMDb ID so the order
+// never depends on the one they were indexed in
+func (index *fullTextIndex) Similar(ctx context.Context, detail *model.MovieDetail, weights model.SimilarityWeights, max int) ([]model.SimilarMovie, error) {
+	movie := detailFeatures(detail)
+	similar := []model.SimilarMovie{}
+
+	index.mutex.RLock()
+	for _, doc := range index.docs {
+		if doc.Movie.ImdbID == detail.ImdbID {
+			continue
+		}
+		score, shared := similarity(movie, documentFeatures(doc), weights)
+		if len(shared) > 0 {
+			similar = append(similar, model.SimilarMovie{Movie: doc.Movie, Score: score, Shared: shared})
+		}
+	}
+	index.mutex.RUnlock()
+
+	sort.Slice(similar, func(i, j int) bool {
+		if similar[i].Score != similar[j].Score {
+			return similar[i].Score > similar[j].Score
+		}
+		return similar[i].Movie.ImdbID < similar[j].Movie.ImdbID
+	})
+	if max > 0 && len(similar) > max {
+		similar = similar[:max]
+	}
+
+	return similar, nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+var darkKnight = &model.MovieDetail{
+	Title: "The Dark Knight", Year: "2008", ImdbID: "tt0468569", Type: "movie", Poster: "N/A", Genre: "Action, Crime, Drama",
+	Director: "Christopher Nolan", Writer: "Jonathan Nolan (screenplay), Christopher Nolan (screenplay), Christopher Nolan (story), David S. Goyer (story)",
+	Actors: "Christian Bale, Heath Ledger, Aaron Eckhart", ImdbRating: "9.0",
+}
+
+var similarDetails = []*model.MovieDetail{
+	darkKnight,
+	{
+		Title: "Batman Begins", Year: "2005", ImdbID: "tt0372784", Type: "movie", Poster: "N/A", Genre: "Action, Crime, Drama",
+		Director: "Christopher Nolan", Writer: "Christopher Nolan (story), David S. Goyer (screenplay)",
+		Actors: "Christian Bale, Michael Caine, Ken Watanabe", ImdbRating: "8.2",
+	},
+	{
+		Title: "Heat", Year: "1995", ImdbID: "tt0113277", Type: "movie", Poster: "N/A", Genre: "Action, Crime, Drama",
+		Director: "Michael Mann", Writer: "Michael Mann", Actors: "Al Pacino, Robert De Niro, Val Kilmer", ImdbRating: "8.3",
+	},
+	{
+		Title: "Inception", Year: "2010", ImdbID: "tt1375666", Type: "movie", Poster: "N/A", Genre: "Action, Adventure, Sci-Fi",
+		Director: "Christopher Nolan", Writer: "Christopher Nolan", Actors: "Leonardo DiCaprio, Joseph Gordon-Levitt, Elliot Page", ImdbRating: "8.8",
+	},
+	{
+		Title: "The Notebook", Year: "2004", ImdbID: "tt0332280", Type: "movie", Poster: "N/A", Genre: "Drama, Romance",
+		Director: "Nick Cassavetes", Writer: "Jeremy Leven (screenplay), Jan Sardi (adaptation), Nicholas Sparks (novel)",
+		Actors: "Gena Rowlands, James Garner, Rachel McAdams", ImdbRating: "7.8",
+	},
+	{
+		Title: "Toy Story", Year: "1995", ImdbID: "tt0114709", Type: "movie", Poster: "N/A", Genre: "Animation, Adventure, Comedy",
+		Director: "John Lasseter", Writer: "John Lasseter (original story by), Pete Docter (original story by)",
+		Actors: "Tom Hanks, Tim Allen, Don Rickles", ImdbRating: "8.3",
+	},
+	{
+		Title: "Heat Wave", Year: "2009", ImdbID: "tt1000001", Type: "series", Poster: "N/A", Genre: "N/A",
+		Director: "N/A", Writer: "N/A", Actors: "N/A", ImdbRating: "N/A",
+	},
+}
+
+var similarWeights = model.SimilarityWeights{Genres: 3, Director: 2, Writers: 1.5, Cast: 2, Decade: 1, Rating: 1}
+
+func newSimilarIndex(t *testing.T, fileName string) model.MovieIndex {
+	index, err := NewFullTextIndex(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, detail := range similarDetails {
+		if err := index.Index(context.TODO(), detail); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return index
+}
+
+func similarIDs(movies []model.SimilarMovie) []string {
+	ids := []string{}
+	for _, movie := range movies {
+		ids = append(ids, movie.Movie.ImdbID)
+	}
+	return ids
+}
+
+func TestSimilar(t *testing.T) {
+	t.Run("[Similar] scored by what the movies share", func(t *testing.T) {
+		movies, err := newSimilarIndex(t, filepath.Join(t.TempDir(), "fulltext.index")).Similar(context.TODO(), darkKnight, similarWeights, 0)
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		if assert.Equal(t, []string{"tt0372784", "tt1375666", "tt0113277", "tt0332280"}, similarIDs(movies)) {
+			// every genre 3, the director 2, 2 of 3 writers 1.5*2/3, 1 of 5 actors 2/5, the decade 1, 0.8 apart 1-0.8/9
+			assert.InDelta(t, 3+2+1+0.4+1+(1-0.8/9), movies[0].Score, 1e-9)
+			assert.Equal(t, []string{"genres", "director", "writers", "cast", "decade", "rating"}, movies[0].Shared)
+			// 1 of 5 genres 3/5, the director 2, 1 of 3 writers 1.5/3, the next decade 1/2, 0.2 apart 1-0.2/9
+			assert.InDelta(t, 0.6+2+0.5+0.5+(1-0.2/9), movies[1].Score, 1e-9)
+			assert.Equal(t, []string{"genres", "director", "writers", "rating"}, movies[1].Shared)
+			// every genre 3, the previous decade 1/2, 0.7 apart 1-0.7/9
+			assert.InDelta(t, 3+0.5+(1-0.7/9), movies[2].Score, 1e-9)
+			assert.Equal(t, []string{"genres", "rating"}, movies[2].Shared)
+			// 1 of 4 genres 3/4, the decade 1, 1.2 apart 1-1.2/9 which is no close rating
+			assert.InDelta(t, 0.75+1+(1-1.2/9), movies[3].Score, 1e-9)
+			assert.Equal(t, []string{"genres", "decade"}, movies[3].Shared)
+			assert.Equal(t, model.SearchDetail{Title: "Batman Begins", Year: "2005", ImdbID: "tt0372784", Type: "movie", Poster: "N/A"}, movies[0].Movie)
+		}
+	})
+
+	t.Run("[Similar] up to max movies", func(t *testing.T) {
+		movies, _ := newSimilarIndex(t, filepath.Join(t.TempDir(), "fulltext.index")).Similar(context.TODO(), darkKnight, similarWeights, 2)
+		assert.Equal(t, []string{"tt0372784", "tt1375666"}, similarIDs(movies))
+	})
+
+	t.Run("[Similar] weights change the ranking, ties by IMDb ID", func(t *testing.T) {
+		index := newSimilarIndex(t, filepath.Join(t.TempDir(), "fulltext.index"))
+
+		movies, _ := index.Similar(context.TODO(), darkKnight, model.SimilarityWeights{Director: 1}, 0)
+		assert.Equal(t, []string{"tt0372784", "tt1375666", "tt0113277", "tt0332280"}, similarIDs(movies))
+		assert.Equal(t, []float64{1, 1, 0, 0}, []float64{movies[0].Score, movies[1].Score, movies[2].Score, movies[3].Score})
+
+		movies, _ = index.Similar(context.TODO(), darkKnight, model.SimilarityWeights{Genres: 1, Rating: 10}, 0)
+		assert.Equal(t, []string{"tt0113277", "tt0372784", "tt1375666", "tt0332280"}, similarIDs(movies))
+	})
+
+	t.Run("[Similar] a movie not indexed, sharing nothing", func(t *testing.T) {
+		index := newSimilarIndex(t, filepath.Join(t.TempDir(), "fulltext.index"))
+
+		movies, err := index.Similar(context.TODO(), &model.MovieDetail{Title: "Free Solo", ImdbID: "tt7775622", Genre: "Documentary, Sport", Director: "Jimmy Chin, Elizabeth Chai Vasarhelyi"}, similarWeights, 0)
+		if assert.Nil(t, err) {
+			assert.NotNil(t, movies)
+			assert.Empty(t, movies)
+		}
+
+		movies, _ = index.Similar(context.TODO(), &model.MovieDetail{Title: "Soul", ImdbID: "tt2948372", Writer: "Pete Docter (original story by)", Year: "2020"}, similarWeights, 0)
+		if assert.Equal(t, []string{"tt0114709"}, similarIDs(movies)) {
+			// 1 of 2 writers, 1995 is decades before
+			assert.Equal(t, 1.5/2, movies[0].Score)
+			assert.Equal(t, []string{"writers"}, movies[0].Shared)
+		}
+	})
+
+	t.Run("[Similar] writers and ratings saved with the index", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "fulltext.index")
+		saved := newSimilarIndex(t, fileName)
+		expected, _ := saved.Similar(context.TODO(), darkKnight, similarWeights, 0)
+		if !assert.Nil(t, saved.Flush(context.TODO())) {
+			return
+		}
+
+		index, err := NewFullTextIndex(fileName)
+		if !assert.Nil(t, err) {
+			return
+		}
+		movies, _ := index.Similar(context.TODO(), darkKnight, similarWeights, 0)
+		assert.Equal(t, expected, movies)
+	})
+}
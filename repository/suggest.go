@@ -0,0 +1,357 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+const (
+	// minSpellingSize is the size of the shortest word suggestions respell, shorter ones are too short to tell
+	// a typo from another word
+	minSpellingSize = 3
+	// maxCandidates bounds the words most alike a misspelled one whose edit distance is computed
+	maxCandidates = 100
+	// spellingsPerWord is how many spellings of each misspelled word suggestions are made of
+	spellingsPerWord = 3
+	// searchLogMinCount is how many times a searchword must have been searched to be learnt from the search
+	// log, misspellings are rarely searched that often
+	searchLogMinCount = 3
+	// maxSearchLogLine is the size of the longest line of the search log read, a search is far shorter
+	maxSearchLogLine = 64 * 1024
+)
+
+// searchLogSearchword finds the searchword of the SearchMovie calls of the search log, quoted like prototext does
+var searchLogSearchword = regexp.MustCompile(`/movie\.SearchMovie/SearchMovie/ .*searchword:\s*("(?:[^"\\]|\\.)*")`)
+
+// titleDictionary suggests spellings word by word: the words alike a misspelled one, sharing the most
+// bigrams, are the candidates, the ones within an edit distance of 1, or 2 for words longer than 4 letters,
+// are its spellings. Words are numbered to keep the bigrams of a whole dataset small
+type titleDictionary struct {
+	mutex *sync.RWMutex
+	words []string
+	ids   map[string]int32
+	// counts is the number of titles learnt having each word, by ID
+	counts []int
+	// grams maps the bigrams of the words to the IDs of the words having them
+	grams map[string][]int32
+}
+
+// spelling is a way to spell a word of a searchword, distance edits away from it
+type spelling struct {
+	word     string
+	distance int
+	count    int
+}
+
+// NewTitleDictionary returns a dictionary knowing no title yet
+func NewTitleDictionary() model.TitleDictionary {
+	return &titleDictionary{
+		mutex: &sync.RWMutex{},
+		ids:   map[string]int32{},
+		grams: map[string][]int32{},
+	}
+}
+
+func (dictionary *titleDictionary) Add(ctx context.Context, titles ...string) {
+	dictionary.mutex.Lock()
+	defer dictionary.mutex.Unlock()
+
+	for _, title := range titles {
+		seen := map[string]bool{}
+		for _, word := range datasetWords(title) {
+			if seen[word] {
+				continue
+			}
+			seen[word] = true
+
+			id, ok := dictionary.ids[word]
+			if !ok {
+				id = int32(len(dictionary.words))
+				dictionary.ids[word] = id
+				dictionary.words = append(dictionary.words, word)
+				dictionary.counts = append(dictionary.counts, 0)
+				for _, gram := range wordGrams(word) {
+					dictionary.grams[gram] = append(dictionary.grams[gram], id)
+				}
+			}
+			dictionary.counts[id]++
+		}
+	}
+}
+
+// Suggest respells the words of searchword it doesn't know.
+// The first suggestion has the closest spelling of each, the others one word spelled otherwise
+func (dictionary *titleDictionary) Suggest(ctx context.Context, searchword string, max int) []string {
+	words := datasetWords(searchword)
+	if len(words) == 0 || max <= 0 {
+		return nil
+	}
+
+	dictionary.mutex.RLock()
+	defer dictionary.mutex.RUnlock()
+
+	spellings := make([][]spelling, len(words))
+	misspelled := false
+	for i, word := range words {
+		if id, ok := dictionary.ids[word]; ok {
+			spellings[i] = []spelling{{word: word, count: dictionary.counts[id]}}
+			continue
+		}
+
+		spellings[i] = dictionary.spell(word)
+		if len(spellings[i]) == 0 {
+			// close to nothing known, left as searched
+			spellings[i] = []spelling{{word: word}}
+			continue
+		}
+		misspelled = true
+	}
+	if !misspelled {
+		return nil
+	}
+
+	type suggestion struct {
+		words    []string
+		distance int
+		count    int
+	}
+	best := suggestion{words: make([]string, len(words))}
+	for i, alternatives := range spellings {
+		best.words[i] = alternatives[0].word
+		best.distance += alternatives[0].distance
+		best.count += alternatives[0].count
+	}
+	suggestions := []suggestion{best}
+	for i, alternatives := range spellings {
+		for _, alternative := range alternatives[1:] {
+			other := suggestion{
+				words:    append([]string{}, best.words...),
+				distance: best.distance - alternatives[0].distance + alternative.distance,
+				count:    best.count - alternatives[0].count + alternative.count,
+			}
+			other.words[i] = alternative.word
+			suggestions = append(suggestions, other)
+		}
+	}
+	sort.SliceStable(suggestions[1:], func(i, j int) bool {
+		a, b := suggestions[1+i], suggestions[1+j]
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		return a.count > b.count
+	})
+
+	result := []string{}
+	for _, suggestion := range suggestions {
+		if len(result) == max {
+			break
+		}
+		result = append(result, strings.Join(suggestion.words, " "))
+	}
+
+	return result
+}
+
+// spell returns the closest spellings of word, a word it doesn't know, fewest edits then most titles first.
+// A word two known words were glued into is spelled as both, one edit away
+func (dictionary *titleDictionary) spell(word string) []spelling {
+	runes := []rune(word)
+	if len(runes) < minSpellingSize {
+		return nil
+	}
+	maxDistance := 1
+	if len(runes) > 4 {
+		maxDistance = 2
+	}
+
+	// Dice coefficient of the bigrams
+	grams := wordGrams(word)
+	shared := map[int32]int{}
+	for _, gram := range grams {
+		for _, id := range dictionary.grams[gram] {
+			shared[id]++
+		}
+	}
+	type candidate struct {
+		id         int32
+		similarity float64
+	}
+	candidates := make([]candidate, 0, len(shared))
+	for id, count := range shared {
+		candidates = append(candidates, candidate{id, 2 * float64(count) / float64(len(grams)+len(wordGrams(dictionary.words[id])))})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].similarity != candidates[j].similarity {
+			return candidates[i].similarity > candidates[j].similarity
+		}
+		return candidates[i].id < candidates[j].id
+	})
+	if len(candidates) > maxCandidates {
+		candidates = candidates[:maxCandidates]
+	}
+
+	spellings := []spelling{}
+	for _, candidate := range candidates {
+		other := []rune(dictionary.words[candidate.id])
+		if len(other)-len(runes) > maxDistance || len(runes)-len(other) > maxDistance {
+			continue
+		}
+		if distance := editDistance(runes, other); distance <= maxDistance {
+			spellings = append(spellings, spelling{string(other), distance, dictionary.counts[candidate.id]})
+		}
+	}
+
+	for i := minSpellingSize - 1; i <= len(runes)-minSpellingSize+1; i++ {
+		left, leftKnown := dictionary.ids[string(runes[:i])]
+		right, rightKnown := dictionary.ids[string(runes[i:])]
+		if leftKnown && rightKnown {
+			spellings = append(spellings, spelling{string(runes[:i]) + " " + string(runes[i:]), 1, min(dictionary.counts[left], dictionary.counts[right])})
+		}
+	}
+
+	sort.Slice(spellings, func(i, j int) bool {
+		if spellings[i].distance != spellings[j].distance {
+			return spellings[i].distance < spellings[j].distance
+		}
+		if spellings[i].count != spellings[j].count {
+			return spellings[i].count > spellings[j].count
+		}
+		return spellings[i].word < spellings[j].word
+	})
+	if len(spellings) > spellingsPerWord {
+		spellings = spellings[:spellingsPerWord]
+	}
+
+	return spellings
+}
+
+// wordGrams returns the bigrams of word, with its start and end marked so the first and last letters count as much
+func wordGrams(word string) []string {
+	runes := append(append([]rune{'^'}, []rune(word)...), '$')
+
+	seen := map[string]bool{}
+	grams := make([]string, 0, len(runes)-1)
+	for i := 0; i+2 <= len(runes); i++ {
+		gram := string(runes[i : i+2])
+		if !seen[gram] {
+			seen[gram] = true
+			grams = append(grams, gram)
+		}
+	}
+
+	return grams
+}
+
+// editDistance is the number of letters to insert, delete, substitute or swap with the next one to turn a into b
+func editDistance(a, b []rune) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(min(rows[i-1][j]+1, rows[i][j-1]+1), rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(a)][len(b)]
+}
+
+// LearnSearchLog adds the searchwords of the search log fileName searched at least searchLogMinCount times
+// to dictionary. A search log that doesn't exist yet has nothing to learn
+func LearnSearchLog(ctx context.Context, dictionary model.TitleDictionary, fileName string) error {
+	counts, err := searchLogCounts(fileName)
+	if err != nil {
+		return err
+	}
+
+	searchwords := []string{}
+	for searchword, count := range counts {
+		if count >= searchLogMinCount {
+			searchwords = append(searchwords, searchword)
+		}
+	}
+	sort.Strings(searchwords)
+	dictionary.Add(ctx, searchwords...)
+
+	return nil
+}
+
+// searchLogCounts counts how many times each searchword of the search log fileName was searched, by its
+// words separated by spaces. A search log that doesn't exist yet has no searchwords
+func searchLogCounts(fileName string) (map[string]int, error) {
+	counts := map[string]int{}
+	file, err := os.Open(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return counts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// a longer line is no search, it is skipped rather than failing the whole log
+	reader := bufio.NewReaderSize(file, maxSearchLogLine)
+	for {
+		line, tooLong, err := reader.ReadLine()
+		for tooLong && err == nil {
+			_, tooLong, err = reader.ReadLine()
+			line = nil
+		}
+		if err == io.EOF {
+			return counts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		match := searchLogSearchword.FindSubmatch(line)
+		if match == nil {
+			continue
+		}
+		searchword, err := strconv.Unquote(string(match[1]))
+		if err != nil {
+			continue
+		}
+		if words := datasetWords(searchword); len(words) > 0 {
+			counts[strings.Join(words, " ")]++
+		}
+	}
+}
+
+// LearnDatasetTitles adds the titles of the dataset movieRepo answers from to dictionary, the other
+// repositories have none to learn
+func LearnDatasetTitles(ctx context.Context, dictionary model.TitleDictionary, movieRepo model.MovieRepository) {
+	dataset, ok := movieRepo.(*datasetRepo)
+	if !ok {
+		return
+	}
+
+	titles := make([]string, 0, len(dataset.titles))
+	for _, title := range dataset.titles {
+		titles = append(titles, title.Title)
+	}
+	dictionary.Add(ctx, titles...)
+}
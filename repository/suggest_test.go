@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTitleDictionary(t *testing.T) {
+	dictionary := NewTitleDictionary()
+	dictionary.Add(context.TODO(),
+		"The Godfather", "The Godfather Part II", "The Dark Knight", "Iron Man", "Iron Man 2",
+		"Star Wars", "The Mask", "The Mask of Zorro", "Musk", "Most Wanted",
+	)
+
+	t.Run("[Suggest] misspelled words respelled, known ones kept", func(t *testing.T) {
+		testCases := map[string]string{
+			"godfathr":        "godfather",
+			"teh dark knigth": "the dark knight",
+			"star wras":       "star wars",
+			"iron mann":       "iron man",
+			// glued words
+			"ironman": "iron man",
+		}
+
+		for searchword, expected := range testCases {
+			suggestions := dictionary.Suggest(context.TODO(), searchword, 5)
+			if assert.NotEmpty(t, suggestions, searchword) {
+				assert.Equal(t, expected, suggestions[0], searchword)
+			}
+		}
+	})
+
+	t.Run("[Suggest] nothing to suggest", func(t *testing.T) {
+		for _, searchword := range []string{"the godfather", "Iron Man 2", "zzzzzz", "iron mn", "", "the the"} {
+			assert.Empty(t, dictionary.Suggest(context.TODO(), searchword, 5), searchword)
+		}
+		assert.Empty(t, dictionary.Suggest(context.TODO(), "godfathr", 0))
+	})
+
+	t.Run("[Suggest] other spellings, of the most titles first", func(t *testing.T) {
+		assert.Equal(t, []string{"the mask", "the most", "the musk"}, dictionary.Suggest(context.TODO(), "the mosk", 5))
+		assert.Equal(t, []string{"the mask", "the most"}, dictionary.Suggest(context.TODO(), "the mosk", 2))
+	})
+}
+
+func TestLearnSearchLog(t *testing.T) {
+	t.Run("[LearnSearchLog] searchwords searched often enough are learnt", func(t *testing.T) {
+		lines := []string{
+			`movie_search: 2026/10/19 15:44:31 /movie.SearchMovie/GetMovieDetail/ id:"tt0076759"`,
+			`movie_search: 2026/10/19 15:44:31 /movie.SearchMovie/FullTextSearch/ query:"star wars"`,
+			`movie_search: 2026/10/19 15:44:32 /movie.SearchMovie/SearchMovie/ searchword:"godfathr"`,
+			`movie_search: 2026/10/19 15:44:33 /movie.SearchMovie/SearchMovie/ searchword:"godfathr"  pagination:2`,
+		}
+		for i := 0; i < searchLogMinCount; i++ {
+			lines = append(lines, `movie_search: 2026/10/19 15:44:34 /movie.SearchMovie/SearchMovie/ searchword:"Star \"Wars\""  all_pages:true`)
+		}
+		fileName := filepath.Join(t.TempDir(), "search.log")
+		os.WriteFile(fileName, []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+
+		dictionary := NewTitleDictionary()
+		if !assert.Nil(t, LearnSearchLog(context.TODO(), dictionary, fileName)) {
+			return
+		}
+		assert.Equal(t, []string{"star wars"}, dictionary.Suggest(context.TODO(), "star wras", 5))
+		assert.Empty(t, dictionary.Suggest(context.TODO(), "godfather", 5))
+	})
+
+	t.Run("[LearnSearchLog] lines too long to be searches are skipped", func(t *testing.T) {
+		lines := []string{`movie_search: 2026/10/19 15:44:30 /movie.Watchlist/ImportHistory/ content:"` + strings.Repeat("x", 2*maxSearchLogLine) + `"`}
+		for i := 0; i < searchLogMinCount; i++ {
+			lines = append(lines, `movie_search: 2026/10/19 15:44:34 /movie.SearchMovie/SearchMovie/ searchword:"star wars"`)
+		}
+		lines = append(lines, `movie_search: 2026/10/19 15:44:35 /movie.SearchMovie/SearchMovie/ searchword:"`+strings.Repeat("star wars ", maxSearchLogLine)+`"`)
+		fileName := filepath.Join(t.TempDir(), "search.log")
+		os.WriteFile(fileName, []byte(strings.Join(lines, "\n")), 0o644)
+
+		dictionary := NewTitleDictionary()
+		if !assert.Nil(t, LearnSearchLog(context.TODO(), dictionary, fileName)) {
+			return
+		}
+		assert.Equal(t, []string{"star wars"}, dictionary.Suggest(context.TODO(), "star wras", 5))
+	})
+
+	t.Run("[LearnSearchLog] no search log yet", func(t *testing.T) {
+		assert.Nil(t, LearnSearchLog(context.TODO(), NewTitleDictionary(), filepath.Join(t.TempDir(), "search.log")))
+	})
+}
+
+func TestLearnDatasetTitles(t *testing.T) {
+	t.Run("[LearnDatasetTitles] titles of the dataset only", func(t *testing.T) {
+		dictionary := NewTitleDictionary()
+		LearnDatasetTitles(context.TODO(), dictionary, newDatasetRepo(&datasetSnapshot{Titles: []datasetTitle{{ID: 371746, Title: "Iron Man"}}}))
+		LearnDatasetTitles(context.TODO(), dictionary, &tmdbRepo{})
+
+		assert.Equal(t, []string{"iron man"}, dictionary.Suggest(context.TODO(), "iorn man", 5))
+	})
+}
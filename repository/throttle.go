@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// throttledMovieRepo spaces the requests to the wrapped repository out evenly, callers wait for their turn
+// or until their context is done
+type throttledMovieRepo struct {
+	MovieRepo model.MovieRepository
+	interval  time.Duration
+
+	mutex *sync.Mutex
+	next  time.Time
+	now   func() time.Time
+}
+
+// NewThrottledMovieRepo lets perSecond requests through every second
+func NewThrottledMovieRepo(movieRepo model.MovieRepository, perSecond float64) model.MovieRepository {
+	return &throttledMovieRepo{
+		MovieRepo: movieRepo,
+		interval:  time.Duration(float64(time.Second) / perSecond),
+		mutex:     &sync.Mutex{},
+		now:       time.Now,
+	}
+}
+
+func (repo *throttledMovieRepo) SearchMovies(ctx context.Context, title string, page uint32, filter model.SearchFilter) (result *model.MovieSearch, err error) {
+	if err := repo.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return repo.MovieRepo.SearchMovies(ctx, title, page, filter)
+}
+
+func (repo *throttledMovieRepo) GetMovieDetailByID(ctx context.Context, id string) (detail *model.MovieDetail, err error) {
+	if err := repo.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return repo.MovieRepo.GetMovieDetailByID(ctx, id)
+}
+
+// wait books the next free slot then sleeps until it comes
+func (repo *throttledMovieRepo) wait(ctx context.Context) error {
+	repo.mutex.Lock()
+	now := repo.now()
+	slot := repo.next
+	if slot.Before(now) {
+		slot = now
+	}
+	repo.next = slot.Add(repo.interval)
+	repo.mutex.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+func TestThrottledMovieRepo(t *testing.T) {
+	movieRepo := func() *mocks.MovieRepository {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{Response: "True"}, nil)
+		movieRepoMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(&model.MovieDetail{Response: "True"}, nil)
+		return movieRepoMock
+	}
+
+	t.Run("[GetMovieDetailByID] requests are spaced out", func(t *testing.T) {
+		repo := NewThrottledMovieRepo(movieRepo(), 50)
+
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			detail, err := repo.GetMovieDetailByID(context.TODO(), "tt0371746")
+			assert.Nil(t, err)
+			assert.Equal(t, "True", detail.Response)
+		}
+		// the first request goes through right away, the 3 others wait 20ms each
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(60*time.Millisecond))
+	})
+
+	t.Run("[SearchMovies] searches and details share the pace", func(t *testing.T) {
+		repo := NewThrottledMovieRepo(movieRepo(), 20)
+
+		start := time.Now()
+		repo.GetMovieDetailByID(context.TODO(), "tt0371746")
+		result, err := repo.SearchMovies(context.TODO(), "iron", 1, model.SearchFilter{})
+		assert.Nil(t, err)
+		assert.Equal(t, "True", result.Response)
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(50*time.Millisecond))
+	})
+
+	t.Run("[GetMovieDetailByID] waiting stops with the context", func(t *testing.T) {
+		movieRepoMock := movieRepo()
+		repo := NewThrottledMovieRepo(movieRepoMock, 0.1)
+		repo.GetMovieDetailByID(context.TODO(), "tt0371746")
+
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+		defer cancel()
+		_, err := repo.GetMovieDetailByID(ctx, "tt0371746")
+		assert.Equal(t, context.DeadlineExceeded, err)
+		movieRepoMock.AssertNumberOfCalls(t, "GetMovieDetailByID", 1)
+	})
+}
@@ -0,0 +1,419 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"github.com/zenkobert/sbtest-2/common"
+	"github.com/zenkobert/sbtest-2/common/redact"
+	"github.com/zenkobert/sbtest-2/common/tracing"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	tmdbRatingSource = "TMDb"
+	// tmdbPageSize is how many results a TMDb search page has, two OMDb pages
+	tmdbPageSize = 20
+	// tmdbMaxActors is how many actors a detail lists, OMDb lists about as many
+	tmdbMaxActors = 4
+)
+
+// errTMDbNotFound is TMDb answering 404, for a title it doesn't know
+var errTMDbNotFound = errors.New("not found on TMDb")
+
+type (
+	tmdbNamed struct {
+		Name        string `json:"name"`
+		EnglishName string `json:"english_name"`
+	}
+
+	tmdbCredits struct {
+		Cast []struct {
+			Name string `json:"name"`
+		} `json:"cast"`
+		Crew []struct {
+			Name       string `json:"name"`
+			Job        string `json:"job"`
+			Department string `json:"department"`
+		} `json:"crew"`
+	}
+
+	// tmdbTitle is a movie or a TV show, search results only have some of the fields
+	tmdbTitle struct {
+		ID                  int         `json:"id"`
+		Title               string      `json:"title"`
+		Name                string      `json:"name"`
+		ReleaseDate         string      `json:"release_date"`
+		FirstAirDate        string      `json:"first_air_date"`
+		LastAirDate         string      `json:"last_air_date"`
+		Status              string      `json:"status"`
+		Runtime             int         `json:"runtime"`
+		EpisodeRunTime      []int       `json:"episode_run_time"`
+		Genres              []tmdbNamed `json:"genres"`
+		Overview            string      `json:"overview"`
+		SpokenLanguages     []tmdbNamed `json:"spoken_languages"`
+		ProductionCountries []tmdbNamed `json:"production_countries"`
+		ProductionCompanies []tmdbNamed `json:"production_companies"`
+		CreatedBy           []tmdbNamed `json:"created_by"`
+		PosterPath          string      `json:"poster_path"`
+		VoteAverage         float64     `json:"vote_average"`
+		VoteCount           int         `json:"vote_count"`
+		Revenue             uint64      `json:"revenue"`
+		Homepage            string      `json:"homepage"`
+		Credits             tmdbCredits `json:"credits"`
+	}
+
+	tmdbSearch struct {
+		Results      []tmdbTitle `json:"results"`
+		TotalResults int         `json:"total_results"`
+	}
+
+	tmdbFind struct {
+		MovieResults []tmdbTitle `json:"movie_results"`
+		TVResults    []tmdbTitle `json:"tv_results"`
+	}
+
+	tmdbExternalIDs struct {
+		ImdbID string `json:"imdb_id"`
+	}
+)
+
+// tmdbRepo looks movies up with a TMDb style API (v3), answering like OMDb. Movies and series are
+// searched, episodes and games aren't
+type tmdbRepo struct {
+	Client    common.HTTPClient
+	host      string
+	apiKey    string
+	imageHost string
+}
+
+// NewTMDbMovieRepo calls the API at host, imageHost is the base URL of the posters, e.g. https://image.tmdb.org/t/p/w500
+func NewTMDbMovieRepo(host, apiKey, imageHost string, timeout time.Duration) model.MovieRepository {
+	return &tmdbRepo{
+		Client:    tracing.NewHTTPClient(&http.Client{Timeout: timeout}),
+		host:      strings.TrimSuffix(host, "/"),
+		apiKey:    apiKey,
+		imageHost: strings.TrimSuffix(imageHost, "/"),
+	}
+}
+
+// SearchMovies answers an OMDb page of 10 results from half a TMDb page, then looks the IMDb IDs of those
+// results up as TMDb searches don't have them. Results without an IMDb ID are left out
+func (repo *tmdbRepo) SearchMovies(ctx context.Context, title string, page uint32, filter model.SearchFilter) (*model.MovieSearch, error) {
+	if page == 0 {
+		page = 1
+	}
+
+	kind, yearParam := "movie", "year"
+	switch filter.Type {
+	case "", "movie":
+	case "series":
+		kind, yearParam = "tv", "first_air_date_year"
+	default:
+		return &model.MovieSearch{Response: "False", Error: movieNotFound}, nil
+	}
+
+	params := neturl.Values{"query": {title}, "page": {fmt.Sprint((page-1)/2 + 1)}}
+	if filter.Year != 0 {
+		params.Set(yearParam, fmt.Sprint(filter.Year))
+	}
+
+	var search tmdbSearch
+	if err := repo.get(ctx, "/search/"+kind, params, &search); err != nil {
+		return nil, err
+	}
+
+	results := search.Results
+	if page%2 == 0 {
+		results = results[min(len(results), omdbPageSize):]
+	}
+	results = results[:min(len(results), omdbPageSize)]
+	if len(results) == 0 {
+		return &model.MovieSearch{Response: "False", Error: movieNotFound}, nil
+	}
+
+	imdbIDs := make([]string, len(results))
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i := range results {
+		i := i
+		group.Go(func() error {
+			var ids tmdbExternalIDs
+			err := repo.get(groupCtx, fmt.Sprintf("/%s/%d/external_ids", kind, results[i].ID), neturl.Values{}, &ids)
+			if errors.Is(err, errTMDbNotFound) {
+				return nil
+			}
+			imdbIDs[i] = ids.ImdbID
+			return err
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	result := &model.MovieSearch{TotalResults: fmt.Sprint(search.TotalResults), Response: "True"}
+	for i, found := range results {
+		if imdbIDs[i] == "" {
+			continue
+		}
+		result.Search = append(result.Search, model.SearchDetail{
+			Title:  firstOf(found.Title, found.Name),
+			Year:   orNotApplicable(yearOf(firstOf(found.ReleaseDate, found.FirstAirDate))),
+			ImdbID: imdbIDs[i],
+			Type:   omdbType(kind),
+			Poster: repo.poster(found.PosterPath),
+		})
+	}
+	if len(result.Search) == 0 {
+		return &model.MovieSearch{Response: "False", Error: movieNotFound}, nil
+	}
+
+	return result, nil
+}
+
+// GetMovieDetailByID finds the TMDb movie or series of the IMDb ID, then gets its detail and credits
+func (repo *tmdbRepo) GetMovieDetailByID(ctx context.Context, id string) (*model.MovieDetail, error) {
+	var find tmdbFind
+	err := repo.get(ctx, "/find/"+neturl.PathEscape(id), neturl.Values{"external_source": {"imdb_id"}}, &find)
+	if errors.Is(err, errTMDbNotFound) {
+		return &model.MovieDetail{Response: "False", Error: incorrectImdbID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var kind string
+	var found tmdbTitle
+	switch {
+	case len(find.MovieResults) > 0:
+		kind, found = "movie", find.MovieResults[0]
+	case len(find.TVResults) > 0:
+		kind, found = "tv", find.TVResults[0]
+	default:
+		return &model.MovieDetail{Response: "False", Error: incorrectImdbID}, nil
+	}
+
+	var title tmdbTitle
+	err = repo.get(ctx, fmt.Sprintf("/%s/%d", kind, found.ID), neturl.Values{"append_to_response": {"credits"}}, &title)
+	if errors.Is(err, errTMDbNotFound) {
+		return &model.MovieDetail{Response: "False", Error: incorrectImdbID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.detail(id, kind, &title), nil
+}
+
+func (repo *tmdbRepo) detail(id, kind string, title *tmdbTitle) *model.MovieDetail {
+	var directors, writers, actors []string
+	for _, person := range title.CreatedBy {
+		writers = appendUnique(writers, person.Name)
+	}
+	for _, member := range title.Credits.Crew {
+		switch {
+		case member.Job == "Director":
+			directors = appendUnique(directors, member.Name)
+		case member.Department == "Writing":
+			writers = appendUnique(writers, member.Name)
+		}
+	}
+	for _, member := range title.Credits.Cast {
+		if len(actors) < tmdbMaxActors {
+			actors = append(actors, member.Name)
+		}
+	}
+
+	runtime := title.Runtime
+	if runtime == 0 && len(title.EpisodeRunTime) > 0 {
+		runtime = title.EpisodeRunTime[0]
+	}
+
+	detail := &model.MovieDetail{
+		Title:      firstOf(title.Title, title.Name),
+		Year:       orNotApplicable(yearOf(firstOf(title.ReleaseDate, title.FirstAirDate))),
+		Rated:      notApplicable,
+		Released:   notApplicable,
+		Runtime:    notApplicable,
+		Genre:      joinNames(title.Genres, false),
+		Director:   orNotApplicable(strings.Join(directors, ", ")),
+		Writer:     orNotApplicable(strings.Join(writers, ", ")),
+		Actors:     orNotApplicable(strings.Join(actors, ", ")),
+		Plot:       orNotApplicable(title.Overview),
+		Language:   joinNames(title.SpokenLanguages, true),
+		Country:    joinNames(title.ProductionCountries, false),
+		Awards:     notApplicable,
+		Poster:     repo.poster(title.PosterPath),
+		Ratings:    []model.MovieRating{},
+		Metascore:  notApplicable,
+		ImdbRating: notApplicable,
+		ImdbVotes:  notApplicable,
+		ImdbID:     id,
+		Type:       omdbType(kind),
+		DVD:        notApplicable,
+		BoxOffice:  notApplicable,
+		Production: joinNames(title.ProductionCompanies, false),
+		Website:    orNotApplicable(title.Homepage),
+		Response:   "True",
+	}
+	if kind == "tv" && detail.Year != notApplicable {
+		detail.Year += "–"
+		if title.Status == "Ended" || title.Status == "Canceled" {
+			detail.Year += yearOf(title.LastAirDate)
+		}
+	}
+	if released, err := time.Parse("2006-01-02", firstOf(title.ReleaseDate, title.FirstAirDate)); err == nil {
+		detail.Released = released.Format("02 Jan 2006")
+	}
+	if runtime > 0 {
+		detail.Runtime = fmt.Sprintf("%d min", runtime)
+	}
+	if title.VoteCount > 0 {
+		detail.Ratings = append(detail.Ratings, model.MovieRating{Source: tmdbRatingSource, Value: fmt.Sprintf("%.1f/10", title.VoteAverage)})
+	}
+	if title.Revenue > 0 {
+		detail.BoxOffice = "$" + groupThousands(title.Revenue)
+	}
+
+	return detail
+}
+
+// get calls path with params and decodes the JSON answer into v, TMDb errors are told apart like OMDb ones
+func (repo *tmdbRepo) get(ctx context.Context, path string, params neturl.Values, v interface{}) error {
+	params.Set("api_key", repo.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, repo.host+path+"?"+params.Encode(), nil)
+	if err != nil {
+		err = redact.Error(err)
+		log.Println(err)
+		return err
+	}
+
+	resp, err := repo.Client.Do(req)
+	if err != nil {
+		err = redact.Error(err)
+		log.Println(err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errTMDbNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return model.ErrQuotaExceeded
+	case resp.StatusCode >= 400:
+		log.Printf("tmdb responded with status %d to %s", resp.StatusCode, path)
+		return model.ErrUpstream
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+func (repo *tmdbRepo) poster(path string) string {
+	if path == "" {
+		return notApplicable
+	}
+
+	return repo.imageHost + path
+}
+
+// Check reports whether TMDb is reachable, without an api key so it costs no request of the quota
+func (repo *tmdbRepo) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, repo.host, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := repo.Client.Do(req)
+	if err != nil {
+		return redact.Error(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("tmdb responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// omdbType is the OMDb type of a kind of TMDb title
+func omdbType(kind string) string {
+	if kind == "tv" {
+		return "series"
+	}
+
+	return "movie"
+}
+
+// joinNames joins the names of TMDb objects, their English names when english is set, N/A when there is none
+func joinNames(named []tmdbNamed, english bool) string {
+	names := make([]string, 0, len(named))
+	for _, item := range named {
+		name := item.Name
+		if english && item.EnglishName != "" {
+			name = item.EnglishName
+		}
+		names = appendUnique(names, name)
+	}
+
+	return orNotApplicable(strings.Join(names, ", "))
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
+
+// yearOf is the year of a YYYY-MM-DD date, empty when the date is
+func yearOf(date string) string {
+	if len(date) < 4 {
+		return ""
+	}
+
+	return date[:4]
+}
+
+func firstOf(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func orNotApplicable(value string) string {
+	if value == "" {
+		return notApplicable
+	}
+
+	return value
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	"github.com/zenkobert/sbtest-2/common/mocks"
+	"github.com/zenkobert/sbtest-2/common/tracing"
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+var (
+	tmdbSearchJsonResponse = `{
+		"page": 1,
+		"results": [
+			{"id": 1726, "title": "Iron Man", "release_date": "2008-04-30", "poster_path": "/iron.jpg"},
+			{"id": 10138, "title": "Iron Man 2", "release_date": "2010-04-28", "poster_path": ""},
+			{"id": 99999, "title": "Iron Man fan film", "release_date": ""}
+		],
+		"total_results": 3
+	}`
+
+	tmdbFindJsonResponse = `{"movie_results": [{"id": 1726, "title": "Iron Man"}], "tv_results": []}`
+
+	tmdbMovieJsonResponse = `{
+		"id": 1726,
+		"title": "Iron Man",
+		"release_date": "2008-04-30",
+		"runtime": 126,
+		"genres": [{"name": "Action"}, {"name": "Science Fiction"}],
+		"overview": "Tony Stark builds a suit",
+		"spoken_languages": [{"name": "English", "english_name": "English"}, {"name": "فارسی", "english_name": "Persian"}],
+		"production_countries": [{"name": "United States of America"}],
+		"production_companies": [{"name": "Marvel Studios"}],
+		"poster_path": "/iron.jpg",
+		"vote_average": 7.64,
+		"vote_count": 25000,
+		"revenue": 585174222,
+		"homepage": "",
+		"credits": {
+			"cast": [{"name": "Robert Downey Jr."}, {"name": "Terrence Howard"}, {"name": "Jeff Bridges"}, {"name": "Gwyneth Paltrow"}, {"name": "Leslie Bibb"}],
+			"crew": [
+				{"name": "Jon Favreau", "job": "Director", "department": "Directing"},
+				{"name": "Mark Fergus", "job": "Screenplay", "department": "Writing"},
+				{"name": "Hawk Ostby", "job": "Screenplay", "department": "Writing"},
+				{"name": "Mark Fergus", "job": "Story", "department": "Writing"}
+			]
+		}
+	}`
+)
+
+// tmdbRoutes are the status and body TMDb answers, by path
+type tmdbRoutes map[string]struct {
+	status int
+	body   string
+}
+
+// tmdbAnswering mocks TMDb answering the paths of routes, 404 to the others
+func tmdbAnswering(routes tmdbRoutes) *mocks.HTTPClient {
+	httpClientMock := &mocks.HTTPClient{}
+	httpClientMock.On("Do", testify.Anything).Return(func(req *http.Request) *http.Response {
+		route, ok := routes[req.URL.Path]
+		if !ok {
+			route.status, route.body = http.StatusNotFound, `{"success": false, "status_code": 34}`
+		}
+		return &http.Response{StatusCode: route.status, Body: ioutil.NopCloser(bytes.NewReader([]byte(route.body)))}
+	}, nil)
+
+	return httpClientMock
+}
+
+func TestNewTMDbMovieRepo(t *testing.T) {
+	t.Run("[NewTMDbMovieRepo]", func(t *testing.T) {
+		expected := &tmdbRepo{
+			Client:    tracing.NewHTTPClient(&http.Client{Timeout: time.Second}),
+			host:      "https://api.themoviedb.org/3",
+			apiKey:    "randomKey",
+			imageHost: "https://image.tmdb.org/t/p/w500",
+		}
+
+		actual := NewTMDbMovieRepo("https://api.themoviedb.org/3/", "randomKey", "https://image.tmdb.org/t/p/w500/", time.Second)
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func TestTMDbSearchMovies(t *testing.T) {
+	t.Run("[SearchMovies] results with their IMDb ID, like OMDb", func(t *testing.T) {
+		httpClientMock := tmdbAnswering(tmdbRoutes{
+			"/search/movie":             {200, tmdbSearchJsonResponse},
+			"/movie/1726/external_ids":  {200, `{"imdb_id": "tt0371746"}`},
+			"/movie/10138/external_ids": {200, `{"imdb_id": "tt1228705"}`},
+			"/movie/99999/external_ids": {200, `{"imdb_id": null}`},
+		})
+		repo := &tmdbRepo{Client: httpClientMock, apiKey: "abc", imageHost: "https://image"}
+
+		result, err := repo.SearchMovies(context.TODO(), "iron man", 1, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, &model.MovieSearch{
+				Search: []model.SearchDetail{
+					{Title: "Iron Man", Year: "2008", ImdbID: "tt0371746", Type: "movie", Poster: "https://image/iron.jpg"},
+					{Title: "Iron Man 2", Year: "2010", ImdbID: "tt1228705", Type: "movie", Poster: "N/A"},
+				},
+				TotalResults: "3",
+				Response:     "True",
+			}, result)
+		}
+
+		httpClientMock.AssertCalled(t, "Do", testify.MatchedBy(func(req *http.Request) bool {
+			query := req.URL.Query()
+			return req.URL.Path == "/search/movie" && query.Get("query") == "iron man" && query.Get("page") == "1" && query.Get("api_key") == "abc"
+		}))
+	})
+
+	t.Run("[SearchMovies] series are searched as TV shows, with their year", func(t *testing.T) {
+		httpClientMock := tmdbAnswering(tmdbRoutes{
+			"/search/tv": {200, `{"results": [], "total_results": 0}`},
+		})
+		repo := &tmdbRepo{Client: httpClientMock}
+
+		result, err := repo.SearchMovies(context.TODO(), "friends", 2, model.SearchFilter{Type: "series", Year: 1994})
+		if assert.Nil(t, err) {
+			assert.Equal(t, &model.MovieSearch{Response: "False", Error: "Movie not found!"}, result)
+		}
+		httpClientMock.AssertCalled(t, "Do", testify.MatchedBy(func(req *http.Request) bool {
+			query := req.URL.Query()
+			return req.URL.Path == "/search/tv" && query.Get("first_air_date_year") == "1994" && query.Get("page") == "1"
+		}))
+	})
+
+	t.Run("[SearchMovies] episodes are never found", func(t *testing.T) {
+		httpClientMock := &mocks.HTTPClient{}
+		repo := &tmdbRepo{Client: httpClientMock}
+
+		result, err := repo.SearchMovies(context.TODO(), "pilot", 1, model.SearchFilter{Type: "episode"})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "Movie not found!", result.Error)
+		}
+		httpClientMock.AssertNotCalled(t, "Do", testify.Anything)
+	})
+
+	t.Run("[SearchMovies] quota exceeded and upstream errors", func(t *testing.T) {
+		repo := &tmdbRepo{Client: tmdbAnswering(tmdbRoutes{"/search/movie": {429, `{"status_code": 25}`}})}
+		_, err := repo.SearchMovies(context.TODO(), "iron", 1, model.SearchFilter{})
+		assert.Equal(t, model.ErrQuotaExceeded, err)
+
+		repo = &tmdbRepo{Client: tmdbAnswering(tmdbRoutes{"/search/movie": {401, `{"status_code": 7}`}})}
+		_, err = repo.SearchMovies(context.TODO(), "iron", 1, model.SearchFilter{})
+		assert.Equal(t, model.ErrUpstream, err)
+	})
+}
+
+func TestTMDbGetMovieDetailByID(t *testing.T) {
+	t.Run("[GetMovieDetailByID] detail and credits, like OMDb", func(t *testing.T) {
+		repo := &tmdbRepo{
+			Client: tmdbAnswering(tmdbRoutes{
+				"/find/tt0371746": {200, tmdbFindJsonResponse},
+				"/movie/1726":     {200, tmdbMovieJsonResponse},
+			}),
+			imageHost: "https://image",
+		}
+
+		detail, err := repo.GetMovieDetailByID(context.TODO(), "tt0371746")
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Equal(t, "Iron Man", detail.Title)
+		assert.Equal(t, "2008", detail.Year)
+		assert.Equal(t, "30 Apr 2008", detail.Released)
+		assert.Equal(t, "126 min", detail.Runtime)
+		assert.Equal(t, "Action, Science Fiction", detail.Genre)
+		assert.Equal(t, "Jon Favreau", detail.Director)
+		assert.Equal(t, "Mark Fergus, Hawk Ostby", detail.Writer)
+		assert.Equal(t, "Robert Downey Jr., Terrence Howard, Jeff Bridges, Gwyneth Paltrow", detail.Actors)
+		assert.Equal(t, "English, Persian", detail.Language)
+		assert.Equal(t, "https://image/iron.jpg", detail.Poster)
+		assert.Equal(t, []model.MovieRating{{Source: "TMDb", Value: "7.6/10"}}, detail.Ratings)
+		assert.Equal(t, "$585,174,222", detail.BoxOffice)
+		assert.Equal(t, "N/A", detail.Website)
+		assert.Equal(t, "N/A", detail.ImdbRating)
+		assert.Equal(t, "tt0371746", detail.ImdbID)
+		assert.Equal(t, "movie", detail.Type)
+		assert.Equal(t, "True", detail.Response)
+	})
+
+	t.Run("[GetMovieDetailByID] series years", func(t *testing.T) {
+		repo := &tmdbRepo{Client: tmdbAnswering(tmdbRoutes{
+			"/find/tt0108778": {200, `{"movie_results": [], "tv_results": [{"id": 1668}]}`},
+			"/tv/1668":        {200, `{"name": "Friends", "first_air_date": "1994-09-22", "last_air_date": "2004-05-06", "status": "Ended", "episode_run_time": [22]}`},
+		})}
+
+		detail, err := repo.GetMovieDetailByID(context.TODO(), "tt0108778")
+		if assert.Nil(t, err) {
+			assert.Equal(t, "Friends", detail.Title)
+			assert.Equal(t, "1994–2004", detail.Year)
+			assert.Equal(t, "22 min", detail.Runtime)
+			assert.Equal(t, "series", detail.Type)
+		}
+	})
+
+	t.Run("[GetMovieDetailByID] unknown ID", func(t *testing.T) {
+		repo := &tmdbRepo{Client: tmdbAnswering(tmdbRoutes{
+			"/find/tt0000001": {200, `{"movie_results": [], "tv_results": []}`},
+		})}
+
+		detail, err := repo.GetMovieDetailByID(context.TODO(), "tt0000001")
+		if assert.Nil(t, err) {
+			assert.Equal(t, &model.MovieDetail{Response: "False", Error: "Incorrect IMDb ID."}, detail)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// watchlistFileRepo keeps every watchlist in memory and in a JSON file, rewritten on each change.
+// The file is replaced through a rename so a crash never leaves it half written
+type watchlistFileRepo struct {
+	mutex    *sync.Mutex
+	fileName string
+	lists    map[string]*model.Watchlist
+}
+
+// NewWatchlistFileRepo loads the watchlists saved in fileName, which is created on the first change
+func NewWatchlistFileRepo(fileName string) (model.WatchlistRepository, error) {
+	repo := &watchlistFileRepo{
+		mutex:    &sync.Mutex{},
+		fileName: fileName,
+		lists:    map[string]*model.Watchlist{},
+	}
+
+	content, err := ioutil.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return repo, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lists []*model.Watchlist
+	if err := json.Unmarshal(content, &lists); err != nil {
+		return nil, fmt.Errorf("reading watchlists from %s: %w", fileName, err)
+	}
+	for _, list := range lists {
+		repo.lists[list.ID] = list
+	}
+
+	return repo, nil
+}
+
+func (repo *watchlistFileRepo) Create(ctx context.Context, list *model.Watchlist) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if _, ok := repo.lists[list.ID]; ok {
+		return fmt.Errorf("watchlist %s already exists", list.ID)
+	}
+
+	return repo.save(copyWatchlist(list))
+}
+
+func (repo *watchlistFileRepo) Get(ctx context.Context, id string) (*model.Watchlist, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	list, ok := repo.lists[id]
+	if !ok {
+		return nil, model.ErrWatchlistNotFound
+	}
+
+	return copyWatchlist(list), nil
+}
+
+func (repo *watchlistFileRepo) ListByOwner(ctx context.Context, owner string) ([]*model.Watchlist, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	lists := []*model.Watchlist{}
+	for _, list := range repo.lists {
+		if list.Owner == owner {
+			lists = append(lists, copyWatchlist(list))
+		}
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].CreatedAt.Before(lists[j].CreatedAt)
+	})
+
+	return lists, nil
+}
+
+func (repo *watchlistFileRepo) Update(ctx context.Context, id string, update func(list *model.Watchlist) error) (*model.Watchlist, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	list, ok := repo.lists[id]
+	if !ok {
+		return nil, model.ErrWatchlistNotFound
+	}
+
+	// update works on a copy so a failed update or save leaves the watchlist as it was
+	updated := copyWatchlist(list)
+	if err := update(updated); err != nil {
+		return nil, err
+	}
+	updated.ID = list.ID
+	if err := repo.save(updated); err != nil {
+		return nil, err
+	}
+
+	return copyWatchlist(updated), nil
+}
+
+// save writes the watchlists with list in place to the file, then keeps list in memory.
+// The caller holds the mutex
+func (repo *watchlistFileRepo) save(list *model.Watchlist) error {
+	lists := make([]*model.Watchlist, 0, len(repo.lists)+1)
+	for id, saved := range repo.lists {
+		if id != list.ID {
+			lists = append(lists, saved)
+		}
+	}
+	lists = append(lists, list)
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].ID < lists[j].ID
+	})
+
+	content, err := json.MarshalIndent(lists, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomically(repo.fileName, content); err != nil {
+		return err
+	}
+
+	repo.lists[list.ID] = list
+	return nil
+}
+
+// Check makes sure the directory of the file can still be written to
+func (repo *watchlistFileRepo) Check(ctx context.Context) error {
+	f, err := ioutil.TempFile(filepath.Dir(repo.fileName), ".watchlists-check-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+
+	return os.Remove(f.Name())
+}
+
+func writeFileAtomically(fileName string, content []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(fileName), "."+filepath.Base(fileName)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), fileName)
+}
+
+// copyWatchlist keeps the watchlists in memory from being changed through what the repository returns
+func copyWatchlist(list *model.Watchlist) *model.Watchlist {
+	copied := *list
+	copied.Items = make([]model.WatchlistItem, len(list.Items))
+	copy(copied.Items, list.Items)
+
+	return &copied
+}
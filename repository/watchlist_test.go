@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zenkobert/sbtest-2/common"
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+func newWatchlist(id, owner string, createdAt time.Time) *model.Watchlist {
+	return &model.Watchlist{
+		ID:        id,
+		Owner:     owner,
+		Name:      "list " + id,
+		CreatedAt: createdAt,
+		Items:     []model.WatchlistItem{},
+	}
+}
+
+func TestWatchlistFileRepo(t *testing.T) {
+	created := time.Date(2021, 9, 3, 16, 20, 0, 0, time.UTC)
+
+	t.Run("[NewWatchlistFileRepo] missing file is an empty store", func(t *testing.T) {
+		repo, err := NewWatchlistFileRepo(filepath.Join(t.TempDir(), "watchlists.json"))
+		if assert.Nil(t, err) {
+			lists, err := repo.ListByOwner(context.TODO(), "user-1")
+			assert.Nil(t, err)
+			assert.Empty(t, lists)
+		}
+	})
+
+	t.Run("[NewWatchlistFileRepo] corrupted file", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "watchlists.json")
+		ioutil.WriteFile(fileName, []byte("{"), 0644)
+
+		_, err := NewWatchlistFileRepo(fileName)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "reading watchlists from "+fileName)
+		}
+	})
+
+	t.Run("[Create] watchlists survive a restart", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "watchlists.json")
+		repo, _ := NewWatchlistFileRepo(fileName)
+		assert.Nil(t, repo.Create(context.TODO(), newWatchlist("b", "user-1", created.Add(time.Hour))))
+		assert.Nil(t, repo.Create(context.TODO(), newWatchlist("a", "user-1", created)))
+		assert.Nil(t, repo.Create(context.TODO(), newWatchlist("c", "user-2", created)))
+		_, err := repo.Update(context.TODO(), "a", func(list *model.Watchlist) error {
+			list.Items = append(list.Items, model.WatchlistItem{ImdbID: "tt0371746", AddedAt: created, WatchedOn: "2021-09-04"})
+			return nil
+		})
+		assert.Nil(t, err)
+
+		reopened, err := NewWatchlistFileRepo(fileName)
+		if assert.Nil(t, err) {
+			lists, err := reopened.ListByOwner(context.TODO(), "user-1")
+			if assert.Nil(t, err) && assert.Len(t, lists, 2) {
+				// oldest first
+				assert.Equal(t, "a", lists[0].ID)
+				assert.Equal(t, "b", lists[1].ID)
+				assert.Equal(t, []model.WatchlistItem{{ImdbID: "tt0371746", AddedAt: created, WatchedOn: "2021-09-04"}}, lists[0].Items)
+			}
+		}
+
+		// no temporary file is left behind
+		files, _ := ioutil.ReadDir(filepath.Dir(fileName))
+		assert.Len(t, files, 1)
+	})
+
+	t.Run("[Create] existing id", func(t *testing.T) {
+		repo, _ := NewWatchlistFileRepo(filepath.Join(t.TempDir(), "watchlists.json"))
+		assert.Nil(t, repo.Create(context.TODO(), newWatchlist("a", "user-1", created)))
+		assert.Error(t, repo.Create(context.TODO(), newWatchlist("a", "user-2", created)))
+
+		list, _ := repo.Get(context.TODO(), "a")
+		assert.Equal(t, "user-1", list.Owner)
+	})
+
+	t.Run("[Get] unknown id", func(t *testing.T) {
+		repo, _ := NewWatchlistFileRepo(filepath.Join(t.TempDir(), "watchlists.json"))
+		_, err := repo.Get(context.TODO(), "a")
+		assert.Equal(t, model.ErrWatchlistNotFound, err)
+
+		_, err = repo.Update(context.TODO(), "a", func(list *model.Watchlist) error { return nil })
+		assert.Equal(t, model.ErrWatchlistNotFound, err)
+	})
+
+	t.Run("[Get] returned watchlists don't change the stored ones", func(t *testing.T) {
+		repo, _ := NewWatchlistFileRepo(filepath.Join(t.TempDir(), "watchlists.json"))
+		repo.Create(context.TODO(), newWatchlist("a", "user-1", created))
+		repo.Update(context.TODO(), "a", func(list *model.Watchlist) error {
+			list.Items = append(list.Items, model.WatchlistItem{ImdbID: "tt0371746"})
+			return nil
+		})
+
+		list, _ := repo.Get(context.TODO(), "a")
+		list.Name = "changed"
+		list.Items[0].WatchedOn = "2021-09-04"
+
+		list, _ = repo.Get(context.TODO(), "a")
+		assert.Equal(t, "list a", list.Name)
+		assert.Empty(t, list.Items[0].WatchedOn)
+	})
+
+	t.Run("[Update] failed update saves nothing", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "watchlists.json")
+		repo, _ := NewWatchlistFileRepo(fileName)
+		repo.Create(context.TODO(), newWatchlist("a", "user-1", created))
+
+		_, err := repo.Update(context.TODO(), "a", func(list *model.Watchlist) error {
+			list.Name = "changed"
+			return errors.New("error")
+		})
+		assert.Equal(t, "error", err.Error())
+
+		list, _ := repo.Get(context.TODO(), "a")
+		assert.Equal(t, "list a", list.Name)
+	})
+
+	t.Run("[Create] write error keeps nothing", func(t *testing.T) {
+		dir := t.TempDir()
+		repo, _ := NewWatchlistFileRepo(filepath.Join(dir, "missing", "watchlists.json"))
+
+		err := repo.Create(context.TODO(), newWatchlist("a", "user-1", created))
+		assert.Error(t, err)
+
+		_, err = repo.Get(context.TODO(), "a")
+		assert.Equal(t, model.ErrWatchlistNotFound, err)
+	})
+
+	t.Run("[Check] directory of the file", func(t *testing.T) {
+		dir := t.TempDir()
+		repo, _ := NewWatchlistFileRepo(filepath.Join(dir, "watchlists.json"))
+		checker := repo.(common.HealthChecker)
+		assert.Nil(t, checker.Check(context.TODO()))
+
+		os.RemoveAll(dir)
+		assert.Error(t, checker.Check(context.TODO()))
+	})
+}
@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"unicode"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// maxImportCandidates bounds the movies reported for an ambiguous row
+const maxImportCandidates = 5
+
+// reasons of the import issues
+const (
+	reasonNoTitle       = "the row has neither an IMDb ID nor a title"
+	reasonUnknownImdbID = "OMDb doesn't know the IMDb ID"
+	reasonNoMatch       = "no movie matches the title and year"
+	reasonAmbiguous     = "several movies match the title and year"
+	reasonQuotaReached  = "not imported, the OMDb request limit is reached"
+	reasonWatchlistFull = "not imported, the watchlist is full"
+	reasonInterrupted   = "not imported, the import was interrupted"
+)
+
+// defaultImportNames name the watchlist an import creates when the caller doesn't, by format
+var defaultImportNames = map[string]string{
+	model.ImportLetterboxd: "Imported from Letterboxd",
+	model.ImportIMDb:       "Imported from IMDb",
+}
+
+// errImportStopped stops an import once OMDb answers that its request limit is reached
+var errImportStopped = errors.New("import stopped")
+
+type importUsecase struct {
+	MovieRepo        model.MovieRepository
+	WatchlistUsecase model.WatchlistUsecase
+	ReviewUsecase    model.ReviewUsecase
+
+	maxRows int
+	serving context.Context
+}
+
+// NewImportUsecase looks the movies of an import up with movieRepo, which should be throttled so a large import
+// doesn't use the OMDb quota up at once. The usecases given should look movies up with it as well. Imports
+// take a while, they stop once serving is done so the rows imported so far are reported while the server drains
+func NewImportUsecase(serving context.Context, movieRepo model.MovieRepository, watchlistUsecase model.WatchlistUsecase, reviewUsecase model.ReviewUsecase, maxRows int) model.ImportUsecase {
+	return &importUsecase{
+		MovieRepo:        movieRepo,
+		WatchlistUsecase: watchlistUsecase,
+		ReviewUsecase:    reviewUsecase,
+		maxRows:          maxRows,
+		serving:          serving,
+	}
+}
+
+func (usecase *importUsecase) ImportHistory(ctx context.Context, req model.ImportRequest) (*model.ImportResult, error) {
+	if _, ok := model.CallerFrom(ctx); !ok {
+		return nil, model.ErrUnauthenticated
+	}
+
+	format, rows, issues, err := parseHistory(req.Format, req.Content, req.Unwatched)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows)+len(issues) > usecase.maxRows {
+		return nil, model.ErrImportTooLarge
+	}
+
+	result := &model.ImportResult{Rows: len(rows) + len(issues), Failed: issues}
+	if len(rows) == 0 {
+		return result, nil
+	}
+	list, err := usecase.watchlist(ctx, req, format)
+	if err != nil {
+		return nil, err
+	}
+	result.WatchlistID = list.ID
+
+	// the rows left are reported rather than the work done so far lost when the caller gives up or the server
+	// shuts down
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-usecase.serving.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// the movies of the rows already resolved by title and year
+	resolved := map[string]string{}
+	items := len(list.Items)
+	for i, row := range rows {
+		if usecase.interrupted(ctx) {
+			result.Failed = append(result.Failed, skippedRows(rows[i:], reasonInterrupted)...)
+			return result, nil
+		}
+		if items >= maxWatchlistItems {
+			// looking the rows left up would use the OMDb quota for movies that can't be added
+			result.Failed = append(result.Failed, skippedRows(rows[i:], reasonWatchlistFull)...)
+			return result, nil
+		}
+
+		imdbID, issue, err := usecase.resolve(ctx, row, resolved)
+		if err == nil && issue == nil {
+			items, err = usecase.apply(ctx, result, list.ID, row, imdbID, items)
+		}
+
+		switch {
+		case err != nil && usecase.interrupted(ctx):
+			result.Failed = append(result.Failed, skippedRows(rows[i:], reasonInterrupted)...)
+			return result, nil
+		case errors.Is(err, errImportStopped) || errors.Is(err, model.ErrQuotaExceeded):
+			result.Failed = append(result.Failed, skippedRows(rows[i:], reasonQuotaReached)...)
+			return result, nil
+		case err != nil:
+			result.Failed = append(result.Failed, model.ImportIssue{Row: row, Reason: importFailure(err)})
+		case issue != nil && len(issue.Candidates) > 0:
+			result.Ambiguous = append(result.Ambiguous, *issue)
+		case issue != nil:
+			result.Unmatched = append(result.Unmatched, *issue)
+		}
+	}
+
+	return result, nil
+}
+
+// interrupted tells whether the import should stop, the caller gone or the server shutting down
+func (usecase *importUsecase) interrupted(ctx context.Context) bool {
+	return ctx.Err() != nil || usecase.serving.Err() != nil
+}
+
+// skippedRows are the issues of the rows not imported for reason
+func skippedRows(rows []model.ImportRow, reason string) []model.ImportIssue {
+	issues := make([]model.ImportIssue, 0, len(rows))
+	for _, row := range rows {
+		issues = append(issues, model.ImportIssue{Row: row, Reason: reason})
+	}
+
+	return issues
+}
+
+// watchlist returns the watchlist of the caller with ID req.WatchlistID, or a new one
+func (usecase *importUsecase) watchlist(ctx context.Context, req model.ImportRequest, format string) (*model.Watchlist, error) {
+	if req.WatchlistID == "" {
+		name := req.WatchlistName
+		if name == "" {
+			name = defaultImportNames[format]
+		}
+		return usecase.WatchlistUsecase.CreateWatchlist(ctx, name)
+	}
+
+	// listing the items would look every movie up
+	lists, err := usecase.WatchlistUsecase.Watchlists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		if list.ID == req.WatchlistID {
+			return list, nil
+		}
+	}
+
+	return nil, model.ErrWatchlistNotFound
+}
+
+// resolve returns the IMDb ID of the movie of row, by its IMDb ID or else by its title and year, or an issue
+// telling why there is none
+func (usecase *importUsecase) resolve(ctx context.Context, row model.ImportRow, resolved map[string]string) (string, *model.ImportIssue, error) {
+	if row.ImdbID != "" {
+		detail, err := usecase.MovieRepo.GetMovieDetailByID(ctx, row.ImdbID)
+		if err != nil {
+			return "", nil, err
+		}
+		if detail.Error != "" {
+			return "", &model.ImportIssue{Row: row, Reason: reasonUnknownImdbID}, nil
+		}
+		return row.ImdbID, nil, nil
+	}
+
+	title := normalizeTitle(row.Title)
+	if title == "" {
+		return "", &model.ImportIssue{Row: row, Reason: reasonNoTitle}, nil
+	}
+	key := title + "|" + strconv.Itoa(row.Year)
+	if imdbID, ok := resolved[key]; ok {
+		return imdbID, nil, nil
+	}
+
+	searchword, err := model.NormalizeSearchword(row.Title)
+	if err != nil {
+		return "", &model.ImportIssue{Row: row, Reason: reasonNoMatch}, nil
+	}
+	search, err := usecase.MovieRepo.SearchMovies(ctx, searchword, 1, model.SearchFilter{Type: "movie", Year: row.Year})
+	if err != nil {
+		return "", nil, err
+	}
+	if search.Error != "" || len(search.Search) == 0 {
+		return "", &model.ImportIssue{Row: row, Reason: reasonNoMatch}, nil
+	}
+
+	var exact []model.SearchDetail
+	for _, movie := range search.Search {
+		if normalizeTitle(movie.Title) == title {
+			exact = append(exact, movie)
+		}
+	}
+	candidates := exact
+	switch {
+	case len(exact) == 1:
+		resolved[key] = exact[0].ImdbID
+		return exact[0].ImdbID, nil, nil
+	case len(exact) == 0 && len(search.Search) == 1:
+		// the only result, its title written differently
+		resolved[key] = search.Search[0].ImdbID
+		return search.Search[0].ImdbID, nil, nil
+	case len(exact) == 0:
+		candidates = search.Search
+	}
+	if len(candidates) > maxImportCandidates {
+		candidates = candidates[:maxImportCandidates]
+	}
+
+	return "", &model.ImportIssue{Row: row, Reason: reasonAmbiguous, Candidates: candidates}, nil
+}
+
+// apply adds the movie of row to the watchlist with ID listID, which has items items, marks it as watched and rates it.
+// It returns the number of items of the watchlist once done
+func (usecase *importUsecase) apply(ctx context.Context, result *model.ImportResult, listID string, row model.ImportRow, imdbID string, items int) (int, error) {
+	var failures []string
+	fail := func(err error) error {
+		if errors.Is(err, model.ErrQuotaExceeded) || ctx.Err() != nil {
+			return errImportStopped
+		}
+		failures = append(failures, importFailure(err))
+		return nil
+	}
+
+	list, err := usecase.WatchlistUsecase.AddItem(ctx, listID, imdbID)
+	switch {
+	case err != nil:
+		if err := fail(err); err != nil {
+			return items, err
+		}
+	case len(list.Items) > items:
+		result.Added++
+		items = len(list.Items)
+	}
+
+	if err == nil && row.WatchedOn != "" {
+		if _, err := usecase.WatchlistUsecase.MarkWatched(ctx, listID, imdbID, row.WatchedOn); err != nil {
+			if err := fail(err); err != nil {
+				return items, err
+			}
+		} else {
+			result.Watched++
+		}
+	}
+
+	if row.Rating > 0 {
+		if _, err := usecase.ReviewUsecase.RateMovie(ctx, imdbID, row.Rating); err != nil {
+			if err := fail(err); err != nil {
+				return items, err
+			}
+		} else {
+			result.Rated++
+		}
+	}
+
+	if len(failures) > 0 {
+		result.Failed = append(result.Failed, model.ImportIssue{Row: row, Reason: strings.Join(failures, ", ")})
+	}
+	return items, nil
+}
+
+// importFailure tells why a row failed to import
+func importFailure(err error) string {
+	switch {
+	case errors.Is(err, model.ErrWatchlistFull):
+		return "the watchlist is full"
+	case errors.Is(err, model.ErrMovieNotFound):
+		return "OMDb doesn't know the movie"
+	case errors.Is(err, model.ErrUpstream):
+		return "OMDb answered with an error"
+	case model.IsTimeout(err):
+		return "OMDb did not answer in time"
+	}
+
+	log.Println("import:", err)
+	return "unexpected error"
+}
+
+// normalizeTitle keeps the lower case letters and digits of title, so titles written differently compare equal
+func normalizeTitle(title string) string {
+	var normalized strings.Builder
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			normalized.WriteRune(r)
+		}
+	}
+
+	return normalized.String()
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// historyColumns are the columns of a watch history export read by the import, by format
+var historyColumns = map[string]struct {
+	// required columns identify the format when it isn't given
+	required  string
+	title     string
+	year      string
+	imdbID    string
+	rating    string
+	watchedOn []string
+}{
+	// watched.csv, diary.csv, ratings.csv and watchlist.csv of a Letterboxd export, ratings are 0.5 to 5 stars
+	model.ImportLetterboxd: {required: "letterboxd uri", title: "name", year: "year", rating: "rating", watchedOn: []string{"watched date", "date"}},
+	// ratings.csv and the watchlist export of IMDb
+	model.ImportIMDb: {required: "const", title: "title", year: "year", imdbID: "const", rating: "your rating", watchedOn: []string{"date rated"}},
+}
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// parseHistory reads the rows of a watch history export in format, detected from the header when empty.
+// Rows with values that can't be read are returned as issues, unwatched rows have neither rating nor day
+func parseHistory(format string, content []byte, unwatched bool) (string, []model.ImportRow, []model.ImportIssue, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, utf8BOM)))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return "", nil, nil, fmt.Errorf("%w: the file is empty", model.ErrInvalidImport)
+	}
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("%w: %s", model.ErrInvalidImport, err)
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if format == "" {
+		for _, candidate := range []string{model.ImportLetterboxd, model.ImportIMDb} {
+			if _, ok := index[historyColumns[candidate].required]; ok {
+				format = candidate
+				break
+			}
+		}
+	}
+	columns, ok := historyColumns[format]
+	if !ok {
+		return "", nil, nil, fmt.Errorf("%w: unknown format, expected a Letterboxd or IMDb export", model.ErrInvalidImport)
+	}
+	if _, ok := index[columns.required]; !ok {
+		return "", nil, nil, fmt.Errorf("%w: a %s export has a %q column", model.ErrInvalidImport, format, columns.required)
+	}
+
+	var rows []model.ImportRow
+	var issues []model.ImportIssue
+	for number := 1; ; number++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("%w: %s", model.ErrInvalidImport, err)
+		}
+		value := func(column string) string {
+			if i, ok := index[column]; ok && column != "" && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := model.ImportRow{Number: number, Title: value(columns.title), ImdbID: value(columns.imdbID)}
+		reason := ""
+		if year := value(columns.year); year != "" {
+			if row.Year, err = strconv.Atoi(year); err != nil {
+				reason = fmt.Sprintf("invalid year %q", year)
+			}
+		}
+		if row.Rating, err = parseRating(format, value(columns.rating)); err != nil {
+			reason = err.Error()
+		}
+		for _, column := range columns.watchedOn {
+			if row.WatchedOn = value(column); row.WatchedOn != "" {
+				break
+			}
+		}
+		if _, err := time.Parse(model.WatchedOnLayout, row.WatchedOn); row.WatchedOn != "" && err != nil {
+			reason = fmt.Sprintf("invalid date %q", row.WatchedOn)
+		}
+		if unwatched {
+			row.Rating = 0
+			row.WatchedOn = ""
+		}
+
+		if reason != "" {
+			issues = append(issues, model.ImportIssue{Row: row, Reason: reason})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return format, rows, issues, nil
+}
+
+// parseRating reads rating on the scale of format as a rating from 1 to 10, 0 when it is empty
+func parseRating(format, rating string) (int, error) {
+	if rating == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseFloat(rating, 64)
+	if format == model.ImportLetterboxd {
+		// half stars
+		value *= 2
+	}
+	if err != nil || value != math.Trunc(value) || value < minRating || value > maxRating {
+		return 0, fmt.Errorf("invalid rating %q", rating)
+	}
+
+	return int(value), nil
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+const (
+	letterboxdDiary = "\xef\xbb\xbfDate,Name,Year,Letterboxd URI,Rating,Rewatch,Tags,Watched Date\n" +
+		"2021-09-05,Iron Man,2008,https://boxd.it/1,4.5,,,2021-09-04\n" +
+		"2021-09-06,\"Crouching Tiger, Hidden Dragon\",2000,https://boxd.it/2,,Yes,,\n"
+	imdbRatings = "Const,Your Rating,Date Rated,Title,URL,Title Type,IMDb Rating,Runtime (mins),Year,Genres,Num Votes,Release Date,Directors\n" +
+		"tt0371746,8,2021-09-04,Iron Man,https://www.imdb.com/title/tt0371746/,movie,7.9,126,2008,Action,1000000,2008-04-14,Jon Favreau\n"
+)
+
+func TestParseHistory(t *testing.T) {
+	t.Run("[parseHistory] Letterboxd diary, stars to ratings out of 10", func(t *testing.T) {
+		format, rows, issues, err := parseHistory("", []byte(letterboxdDiary), false)
+		assert.Nil(t, err)
+		assert.Equal(t, model.ImportLetterboxd, format)
+		assert.Empty(t, issues)
+		assert.Equal(t, []model.ImportRow{
+			{Number: 1, Title: "Iron Man", Year: 2008, Rating: 9, WatchedOn: "2021-09-04"},
+			// without a watched day, the day it was logged
+			{Number: 2, Title: "Crouching Tiger, Hidden Dragon", Year: 2000, WatchedOn: "2021-09-06"},
+		}, rows)
+	})
+
+	t.Run("[parseHistory] IMDb ratings", func(t *testing.T) {
+		format, rows, _, err := parseHistory("", []byte(imdbRatings), false)
+		assert.Nil(t, err)
+		assert.Equal(t, model.ImportIMDb, format)
+		assert.Equal(t, []model.ImportRow{{Number: 1, Title: "Iron Man", Year: 2008, ImdbID: "tt0371746", Rating: 8, WatchedOn: "2021-09-04"}}, rows)
+	})
+
+	t.Run("[parseHistory] unwatched rows have neither rating nor day", func(t *testing.T) {
+		_, rows, _, _ := parseHistory(model.ImportIMDb, []byte(imdbRatings), true)
+		assert.Equal(t, []model.ImportRow{{Number: 1, Title: "Iron Man", Year: 2008, ImdbID: "tt0371746"}}, rows)
+	})
+
+	t.Run("[parseHistory] rows with invalid values are issues", func(t *testing.T) {
+		content := "Date,Name,Year,Letterboxd URI,Rating\n" +
+			"2021-09-05,Iron Man,2008,https://boxd.it/1,4.2\n" +
+			"2021-09-05,Iron Man,two thousand,https://boxd.it/1,\n" +
+			"05/09/2021,Iron Man,2008,https://boxd.it/1,\n" +
+			"2021-09-05,Iron Man,2008,https://boxd.it/1,6\n" +
+			"2021-09-05,Iron Man\n"
+
+		_, rows, issues, err := parseHistory(model.ImportLetterboxd, []byte(content), false)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.ImportRow{{Number: 5, Title: "Iron Man", WatchedOn: "2021-09-05"}}, rows)
+		if assert.Len(t, issues, 4) {
+			assert.Equal(t, `invalid rating "4.2"`, issues[0].Reason)
+			assert.Equal(t, `invalid year "two thousand"`, issues[1].Reason)
+			assert.Equal(t, `invalid date "05/09/2021"`, issues[2].Reason)
+			assert.Equal(t, `invalid rating "6"`, issues[3].Reason)
+			assert.Equal(t, 4, issues[3].Row.Number)
+		}
+	})
+
+	t.Run("[parseHistory] not a watch history", func(t *testing.T) {
+		for _, testCase := range []struct {
+			format, content, message string
+		}{
+			{"", "", "invalid import: the file is empty"},
+			{"", "Title,Year\nIron Man,2008\n", "invalid import: unknown format, expected a Letterboxd or IMDb export"},
+			{"trakt", imdbRatings, "invalid import: unknown format, expected a Letterboxd or IMDb export"},
+			{model.ImportLetterboxd, imdbRatings, `invalid import: a letterboxd export has a "letterboxd uri" column`},
+			{"", "Const,Title\n\"tt0371746,Iron Man\n", "invalid import: "},
+		} {
+			_, _, _, err := parseHistory(testCase.format, []byte(testCase.content), false)
+			if assert.Error(t, err) {
+				assert.True(t, errors.Is(err, model.ErrInvalidImport))
+				assert.Contains(t, err.Error(), testCase.message)
+			}
+		}
+	})
+}
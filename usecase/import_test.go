@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+// importTarget mocks the usecases an import saves to, adding to a watchlist that grows with every new movie
+func importTarget() (*mocks.WatchlistUsecase, *mocks.ReviewUsecase) {
+	list := &model.Watchlist{ID: "list-2", Items: []model.WatchlistItem{}}
+	watchlists := &mocks.WatchlistUsecase{}
+	watchlists.On("CreateWatchlist", testify.Anything, testify.Anything).Return(list, nil)
+	watchlists.On("AddItem", testify.Anything, "list-2", testify.Anything).Return(func(_ context.Context, _, imdbID string) *model.Watchlist {
+		if list.Item(imdbID) < 0 {
+			list.Items = append(list.Items, model.WatchlistItem{ImdbID: imdbID})
+		}
+		copied := *list
+		return &copied
+	}, nil)
+	watchlists.On("MarkWatched", testify.Anything, "list-2", testify.Anything, testify.Anything).Return(list, nil)
+
+	reviews := &mocks.ReviewUsecase{}
+	reviews.On("RateMovie", testify.Anything, testify.Anything, testify.Anything).Return(&model.Review{}, nil)
+
+	return watchlists, reviews
+}
+
+// titledMovies mocks OMDb knowing Iron Man once and Crash twice, and every IMDb ID but tt0000000
+func titledMovies() *mocks.MovieRepository {
+	movieRepo := knownMovies()
+	movieRepo.On("SearchMovies", testify.Anything, "Iron Man", uint32(1), model.SearchFilter{Type: "movie", Year: 2008}).Return(&model.MovieSearch{
+		Search:   []model.SearchDetail{{Title: "Iron Man", Year: "2008", ImdbID: "tt0371746"}, {Title: "Iron Man: Rise of Technovore", Year: "2008", ImdbID: "tt2654124"}},
+		Response: "True",
+	}, nil)
+	movieRepo.On("SearchMovies", testify.Anything, "Crash", uint32(1), testify.Anything).Return(&model.MovieSearch{
+		Search:   []model.SearchDetail{{Title: "Crash", Year: "2004", ImdbID: "tt0375679"}, {Title: "Crash", Year: "1996", ImdbID: "tt0115964"}},
+		Response: "True",
+	}, nil)
+	movieRepo.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{Response: "False", Error: "Movie not found!"}, nil)
+
+	return movieRepo
+}
+
+func TestImportHistory(t *testing.T) {
+	letterboxd := "Date,Name,Year,Letterboxd URI,Rating,Watched Date\n" +
+		"2021-09-05,Iron Man,2008,https://boxd.it/1,4.5,2021-09-04\n" +
+		"2021-09-06,Crash,,https://boxd.it/2,3,\n" +
+		"2021-09-07,Not A Movie,2008,https://boxd.it/3,,\n" +
+		"2021-09-08,IRON MAN,2008,https://boxd.it/1,,2021-09-08\n" +
+		"2021-09-09,Iron Man,2008,https://boxd.it/1,4.2,\n"
+
+	t.Run("[ImportHistory] Letterboxd rows resolved by title and year", func(t *testing.T) {
+		watchlists, reviews := importTarget()
+		movieRepo := titledMovies()
+
+		result, err := NewImportUsecase(context.TODO(), movieRepo, watchlists, reviews, 10).ImportHistory(asCaller("user-1"), model.ImportRequest{Content: []byte(letterboxd)})
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Equal(t, "list-2", result.WatchlistID)
+		assert.Equal(t, 5, result.Rows)
+		assert.Equal(t, 1, result.Added)
+		assert.Equal(t, 2, result.Watched)
+		assert.Equal(t, 1, result.Rated)
+		watchlists.AssertCalled(t, "CreateWatchlist", testify.Anything, "Imported from Letterboxd")
+		watchlists.AssertCalled(t, "MarkWatched", testify.Anything, "list-2", "tt0371746", "2021-09-08")
+		reviews.AssertCalled(t, "RateMovie", testify.Anything, "tt0371746", 9)
+		// the second Iron Man row is resolved without looking it up again
+		movieRepo.AssertNumberOfCalls(t, "SearchMovies", 3)
+
+		if assert.Len(t, result.Ambiguous, 1) {
+			assert.Equal(t, "Crash", result.Ambiguous[0].Row.Title)
+			assert.Len(t, result.Ambiguous[0].Candidates, 2)
+		}
+		if assert.Len(t, result.Unmatched, 1) {
+			assert.Equal(t, 3, result.Unmatched[0].Row.Number)
+			assert.Equal(t, reasonNoMatch, result.Unmatched[0].Reason)
+		}
+		if assert.Len(t, result.Failed, 1) {
+			assert.Equal(t, 5, result.Failed[0].Row.Number)
+		}
+	})
+
+	t.Run("[ImportHistory] IMDb rows resolved by ID into an existing watchlist", func(t *testing.T) {
+		watchlists, reviews := importTarget()
+		watchlists.On("Watchlists", testify.Anything).Return([]*model.Watchlist{{ID: "list-1"}, {ID: "list-2", Items: []model.WatchlistItem{{ImdbID: "tt0371746"}}}}, nil)
+		content := "Const,Your Rating,Date Rated,Title,Year\n" +
+			"tt0371746,8,2021-09-04,Iron Man,2008\n" +
+			"tt1228705,6,2021-09-05,Iron Man 2,2010\n" +
+			"tt0000000,6,2021-09-05,Lost,2010\n"
+
+		result, err := NewImportUsecase(context.TODO(), titledMovies(), watchlists, reviews, 10).ImportHistory(asCaller("user-1"), model.ImportRequest{Content: []byte(content), WatchlistID: "list-2"})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "list-2", result.WatchlistID)
+			// Iron Man was in the watchlist already
+			assert.Equal(t, 1, result.Added)
+			assert.Equal(t, 2, result.Rated)
+			if assert.Len(t, result.Unmatched, 1) {
+				assert.Equal(t, reasonUnknownImdbID, result.Unmatched[0].Reason)
+			}
+			watchlists.AssertNotCalled(t, "CreateWatchlist", testify.Anything, testify.Anything)
+		}
+
+		_, err = NewImportUsecase(context.TODO(), titledMovies(), watchlists, reviews, 10).ImportHistory(asCaller("user-1"), model.ImportRequest{Content: []byte(content), WatchlistID: "list-3"})
+		assert.Equal(t, model.ErrWatchlistNotFound, err)
+	})
+
+	t.Run("[ImportHistory] full watchlist still rates", func(t *testing.T) {
+		watchlists := &mocks.WatchlistUsecase{}
+		watchlists.On("CreateWatchlist", testify.Anything, "Mine").Return(&model.Watchlist{ID: "list-2"}, nil)
+		watchlists.On("AddItem", testify.Anything, testify.Anything, testify.Anything).Return(nil, model.ErrWatchlistFull)
+		_, reviews := importTarget()
+
+		result, err := NewImportUsecase(context.TODO(), titledMovies(), watchlists, reviews, 10).ImportHistory(asCaller("user-1"), model.ImportRequest{Content: []byte(imdbRatings), WatchlistName: "Mine"})
+		if assert.Nil(t, err) && assert.Len(t, result.Failed, 1) {
+			assert.Equal(t, "the watchlist is full", result.Failed[0].Reason)
+			assert.Equal(t, 1, result.Rated)
+			watchlists.AssertNotCalled(t, "MarkWatched", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+		}
+	})
+
+	t.Run("[ImportHistory] rows left once the watchlist is full are not looked up", func(t *testing.T) {
+		items := make([]model.WatchlistItem, maxWatchlistItems-1)
+		for i := range items {
+			items[i].ImdbID = "tt" + strconv.Itoa(9000000+i)
+		}
+		list := &model.Watchlist{ID: "list-2", Items: items}
+		watchlists := &mocks.WatchlistUsecase{}
+		watchlists.On("Watchlists", testify.Anything).Return([]*model.Watchlist{list}, nil)
+		watchlists.On("AddItem", testify.Anything, "list-2", testify.Anything).Return(func(_ context.Context, _, imdbID string) *model.Watchlist {
+			full := *list
+			full.Items = append(append([]model.WatchlistItem{}, list.Items...), model.WatchlistItem{ImdbID: imdbID})
+			return &full
+		}, nil)
+		watchlists.On("MarkWatched", testify.Anything, "list-2", testify.Anything, testify.Anything).Return(list, nil)
+		_, reviews := importTarget()
+		movieRepo := titledMovies()
+		content := "Const,Your Rating,Date Rated,Title,Year\n" +
+			"tt0371746,8,2021-09-04,Iron Man,2008\n" +
+			"tt1228705,6,2021-09-05,Iron Man 2,2010\n" +
+			"tt1300854,7,2021-09-06,Iron Man 3,2013\n"
+
+		result, err := NewImportUsecase(context.TODO(), movieRepo, watchlists, reviews, 10).ImportHistory(asCaller("user-1"), model.ImportRequest{Content: []byte(content), WatchlistID: "list-2"})
+		if assert.Nil(t, err) {
+			assert.Equal(t, 1, result.Added)
+			if assert.Len(t, result.Failed, 2) {
+				assert.Equal(t, reasonWatchlistFull, result.Failed[0].Reason)
+				assert.Equal(t, "tt1300854", result.Failed[1].Row.ImdbID)
+			}
+			movieRepo.AssertNumberOfCalls(t, "GetMovieDetailByID", 1)
+			watchlists.AssertNumberOfCalls(t, "AddItem", 1)
+		}
+	})
+
+	t.Run("[ImportHistory] stops once the OMDb quota is reached", func(t *testing.T) {
+		watchlists, reviews := importTarget()
+		movieRepo := &mocks.MovieRepository{}
+		movieRepo.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(nil, model.ErrQuotaExceeded)
+
+		result, err := NewImportUsecase(context.TODO(), movieRepo, watchlists, reviews, 10).ImportHistory(asCaller("user-1"), model.ImportRequest{Content: []byte(letterboxd)})
+		if assert.Nil(t, err) {
+			// the row with an invalid rating, then every row left
+			assert.Len(t, result.Failed, 5)
+			assert.Equal(t, reasonQuotaReached, result.Failed[1].Reason)
+			movieRepo.AssertNumberOfCalls(t, "SearchMovies", 1)
+		}
+	})
+
+	t.Run("[ImportHistory] the rows left once the server shuts down are reported", func(t *testing.T) {
+		serving, shutdown := context.WithCancel(context.TODO())
+		watchlists, reviews := importTarget()
+		movieRepo := &mocks.MovieRepository{}
+		movieRepo.On("GetMovieDetailByID", testify.Anything, "tt0371746").Return(&model.MovieDetail{ImdbID: "tt0371746"}, nil)
+		movieRepo.On("GetMovieDetailByID", testify.Anything, "tt1228705").Run(func(testify.Arguments) {
+			shutdown()
+		}).Return(nil, context.Canceled)
+
+		content := "Const,Your Rating,Date Rated,Title,Year\n" +
+			"tt0371746,8,2021-09-04,Iron Man,2008\n" +
+			"tt1228705,6,2021-09-05,Iron Man 2,2010\n" +
+			"tt0000000,6,2021-09-05,Lost,2010\n"
+
+		result, err := NewImportUsecase(serving, movieRepo, watchlists, reviews, 10).ImportHistory(asCaller("user-1"), model.ImportRequest{Content: []byte(content)})
+		if assert.Nil(t, err) {
+			assert.Equal(t, 1, result.Added)
+			// the row looked up when the server shut down isn't done either
+			if assert.Len(t, result.Failed, result.Rows-1) {
+				assert.Equal(t, 2, result.Failed[0].Row.Number)
+				assert.Equal(t, reasonInterrupted, result.Failed[0].Reason)
+			}
+			movieRepo.AssertNotCalled(t, "GetMovieDetailByID", testify.Anything, "tt0000000")
+		}
+	})
+
+	t.Run("[ImportHistory] invalid imports", func(t *testing.T) {
+		watchlists, reviews := importTarget()
+		usecase := NewImportUsecase(context.TODO(), titledMovies(), watchlists, reviews, 4)
+
+		_, err := usecase.ImportHistory(context.TODO(), model.ImportRequest{Content: []byte(letterboxd)})
+		assert.Equal(t, model.ErrUnauthenticated, err)
+
+		_, err = usecase.ImportHistory(asCaller("user-1"), model.ImportRequest{Content: []byte(letterboxd)})
+		assert.Equal(t, model.ErrImportTooLarge, err)
+
+		_, err = usecase.ImportHistory(asCaller("user-1"), model.ImportRequest{Content: []byte("Title\nIron Man\n")})
+		assert.ErrorIs(t, err, model.ErrInvalidImport)
+	})
+
+	t.Run("[ImportHistory] nothing to import creates no watchlist", func(t *testing.T) {
+		watchlists, reviews := importTarget()
+
+		result, err := NewImportUsecase(context.TODO(), titledMovies(), watchlists, reviews, 10).ImportHistory(asCaller("user-1"), model.ImportRequest{Content: []byte("Const,Title\n")})
+		if assert.Nil(t, err) {
+			assert.Empty(t, result.WatchlistID)
+			watchlists.AssertNotCalled(t, "CreateWatchlist", testify.Anything, testify.Anything)
+		}
+	})
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	t.Run("[normalizeTitle] letters and digits in lower case", func(t *testing.T) {
+		assert.Equal(t, "ironman2", normalizeTitle("Iron Man 2"))
+		assert.Equal(t, normalizeTitle("Crouching Tiger, Hidden Dragon"), normalizeTitle("crouching tiger hidden dragon"))
+		assert.Equal(t, "amélie", normalizeTitle("Amélie"))
+		assert.Empty(t, normalizeTitle(" - "))
+	})
+}
@@ -1,28 +1,131 @@
 package usecase
 
 import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
 	"github.com/zenkobert/sbtest-2/common"
 	model "github.com/zenkobert/sbtest-2/domain"
 )
 
 type movieUsecase struct {
-	MovieRepo model.MovieRepository
-	MovieDB   common.DummyDB
+	MovieRepo  model.MovieRepository
+	MovieDB    common.DummyDB
+	MovieIndex model.MovieIndex
+	Completer  model.TitleCompleter
+	// Weights rank the similar movies, see model.SimilarityWeights
+	Weights model.SimilarityWeights
 }
 
-func NewMovieUsecase(movieRepo model.MovieRepository, movieDB common.DummyDB) model.MovieUsecase {
+func NewMovieUsecase(movieRepo model.MovieRepository, movieDB common.DummyDB, movieIndex model.MovieIndex, completer model.TitleCompleter, weights model.SimilarityWeights) model.MovieUsecase {
 	return &movieUsecase{
-		MovieRepo: movieRepo,
-		MovieDB:   movieDB,
+		MovieRepo:  movieRepo,
+		MovieDB:    movieDB,
+		MovieIndex: movieIndex,
+		Completer:  completer,
+		Weights:    weights,
 	}
 }
 
-func (usecase *movieUsecase) SearchMovies(title string, page uint32) (result *model.MovieSearch, err error) {
-	return usecase.MovieRepo.SearchMovies(title, page)
+// SearchMovies searches a title ending with a year, Alien 1979, as the movies of that year first, then as a
+// whole when that finds nothing, Wonder Woman 1984. It answers with the full-text index when the repository
+// finds no title matching, the words searched may be in the plot or the cast of a movie fetched before
+func (usecase *movieUsecase) SearchMovies(ctx context.Context, title string, page uint32, filter model.SearchFilter) (result *model.MovieSearch, err error) {
+	if withoutYear, year := model.SearchYear(title); year != 0 && filter.Year == 0 {
+		result, err = usecase.MovieRepo.SearchMovies(ctx, withoutYear, page, model.SearchFilter{Type: filter.Type, Year: year})
+		if err != nil || result == nil {
+			return result, err
+		}
+		if result.Error == "" {
+			usecase.complete(ctx, result)
+			return result, nil
+		}
+	}
+
+	result, err = usecase.MovieRepo.SearchMovies(ctx, title, page, filter)
+	if err != nil || result == nil {
+		return result, err
+	}
+	if result.Error == "" {
+		usecase.complete(ctx, result)
+		return result, nil
+	}
+	// past the last page of a search nothing is found either, that page isn't the one of the index
+	if page > 1 {
+		return result, nil
+	}
+
+	found, err := usecase.MovieIndex.Search(ctx, title, page, filter)
+	if err != nil {
+		log.Println("full-text search of", title, ":", err)
+		return result, nil
+	}
+	if len(found.Hits) == 0 {
+		return result, nil
+	}
+
+	// the index only answers the first page, the total is the movies on it so clients don't ask for the next
+	supplemented := &model.MovieSearch{
+		Search:       make([]model.SearchDetail, 0, len(found.Hits)),
+		TotalResults: strconv.Itoa(len(found.Hits)),
+		Response:     "True",
+	}
+	for _, hit := range found.Hits {
+		supplemented.Search = append(supplemented.Search, hit.Movie)
+	}
+
+	return supplemented, nil
 }
 
-func (usecase *movieUsecase) GetMovieDetailByID(id string) (detail *model.MovieDetail, err error) {
-	return usecase.MovieRepo.GetMovieDetailByID(id)
+// complete adds the titles found to the completer, they complete the ones typed next, ranked by their
+// details once fetched
+func (usecase *movieUsecase) complete(ctx context.Context, result *model.MovieSearch) {
+	completions := make([]model.Completion, 0, len(result.Search))
+	for _, movie := range result.Search {
+		completions = append(completions, model.Completion{Title: movie.Title, ImdbID: movie.ImdbID, Year: movie.Year, Type: movie.Type})
+	}
+	usecase.Completer.Add(ctx, completions...)
+}
+
+func (usecase *movieUsecase) GetMovieDetailByID(ctx context.Context, id string) (detail *model.MovieDetail, err error) {
+	detail, err = usecase.MovieRepo.GetMovieDetailByID(ctx, id)
+	if err == nil && detail != nil && detail.Error == "" {
+		votes, _ := strconv.Atoi(strings.ReplaceAll(detail.ImdbVotes, ",", ""))
+		rating, _ := strconv.ParseFloat(detail.ImdbRating, 64)
+		usecase.Completer.Add(ctx, model.Completion{
+			Title:      detail.Title,
+			ImdbID:     detail.ImdbID,
+			Year:       detail.Year,
+			Type:       detail.Type,
+			Popularity: model.TitlePopularity(votes, rating),
+		})
+	}
+
+	return detail, err
+}
+
+func (usecase *movieUsecase) FullTextSearch(ctx context.Context, query string, page uint32, filter model.SearchFilter) (result *model.FullTextResult, err error) {
+	return usecase.MovieIndex.Search(ctx, query, page, filter)
+}
+
+func (usecase *movieUsecase) Autocomplete(ctx context.Context, prefix string, max int) (completions []model.Completion, err error) {
+	return usecase.Completer.Complete(ctx, prefix, max), nil
+}
+
+// SimilarMovies compares the movie of id to the ones of the full-text index, the movies fetched so far.
+// There are no watchlists to exclude the movies of here, see WithWatchlistExclusion
+func (usecase *movieUsecase) SimilarMovies(ctx context.Context, id string, max int, excludeWatchlisted bool) (movies []model.SimilarMovie, err error) {
+	detail, err := usecase.GetMovieDetailByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if detail == nil || detail.Error != "" {
+		return nil, model.ErrMovieNotFound
+	}
+
+	return usecase.MovieIndex.Similar(ctx, detail, usecase.Weights, max)
 }
 
 func (usecase *movieUsecase) LogToDB(record string) error {
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -11,14 +12,25 @@ import (
 	"github.com/zenkobert/sbtest-2/domain/mocks"
 )
 
+// learning is a completer learning up to two titles at a time
+func learning() *mocks.TitleCompleter {
+	completer := &mocks.TitleCompleter{}
+	completer.On("Add", testify.Anything, testify.Anything).Return()
+	completer.On("Add", testify.Anything, testify.Anything, testify.Anything).Return()
+	return completer
+}
+
 func TestNewMovieUsecase(t *testing.T) {
 	t.Run("[NewMovieUsecase]", func(t *testing.T) {
 		expected := &movieUsecase{
-			MovieRepo: &mocks.MovieRepository{},
-			MovieDB:   &commonMock.DummyDB{},
+			MovieRepo:  &mocks.MovieRepository{},
+			MovieDB:    &commonMock.DummyDB{},
+			MovieIndex: &mocks.MovieIndex{},
+			Completer:  &mocks.TitleCompleter{},
+			Weights:    model.SimilarityWeights{Genres: 3},
 		}
 
-		actual := NewMovieUsecase(&mocks.MovieRepository{}, &commonMock.DummyDB{})
+		actual := NewMovieUsecase(&mocks.MovieRepository{}, &commonMock.DummyDB{}, &mocks.MovieIndex{}, &mocks.TitleCompleter{}, model.SimilarityWeights{Genres: 3})
 		assert.Equal(t, expected, actual)
 	})
 }
@@ -27,11 +39,11 @@ func TestSearchMovies(t *testing.T) {
 	t.Run("[SearchMovies] movieRepo returns error", func(t *testing.T) {
 		movieRepoMock := &mocks.MovieRepository{}
 		movieDBMock := &commonMock.DummyDB{}
-		movieRepoMock.On("SearchMovies", testify.Anything, testify.Anything).Return(&model.MovieSearch{}, errors.New("error"))
+		movieRepoMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{}, errors.New("error"))
 		movieDBMock.On("Log", testify.Anything).Return(nil)
 
-		usecase := NewMovieUsecase(movieRepoMock, movieDBMock)
-		_, err := usecase.SearchMovies("test", 1)
+		usecase := NewMovieUsecase(movieRepoMock, movieDBMock, &mocks.MovieIndex{}, learning(), model.SimilarityWeights{})
+		_, err := usecase.SearchMovies(context.TODO(), "test", 1, model.SearchFilter{})
 		if assert.Error(t, err) {
 			assert.Equal(t, "error", err.Error())
 		}
@@ -48,26 +60,152 @@ func TestSearchMovies(t *testing.T) {
 
 		movieRepoMock := &mocks.MovieRepository{}
 		movieDBMock := &commonMock.DummyDB{}
-		movieRepoMock.On("SearchMovies", testify.Anything, testify.Anything).Return(expectedResult, nil)
+		movieRepoMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(expectedResult, nil)
 		movieDBMock.On("Log", testify.Anything).Return(nil)
 
-		usecase := NewMovieUsecase(movieRepoMock, movieDBMock)
-		result, err := usecase.SearchMovies("test", 1)
+		usecase := NewMovieUsecase(movieRepoMock, movieDBMock, &mocks.MovieIndex{}, learning(), model.SimilarityWeights{})
+		result, err := usecase.SearchMovies(context.TODO(), "test", 1, model.SearchFilter{})
 		if assert.Nil(t, err) {
 			assert.Equal(t, expectedResult, result)
 		}
 	})
 }
 
+func TestSearchMoviesYear(t *testing.T) {
+	found := &model.MovieSearch{
+		Search:       []model.SearchDetail{{Title: "Alien", Year: "1979", ImdbID: "tt0078748", Type: "movie"}},
+		TotalResults: "1",
+		Response:     "True",
+	}
+	notFound := &model.MovieSearch{Response: "False", Error: "Movie not found!"}
+
+	t.Run("[SearchMovies] a year ending the title searched as the year of the movies", func(t *testing.T) {
+		for _, title := range []string{"Alien 1979", "Alien (1979)"} {
+			movieRepoMock := &mocks.MovieRepository{}
+			movieRepoMock.On("SearchMovies", testify.Anything, "Alien", uint32(1), model.SearchFilter{Type: "movie", Year: 1979}).Return(found, nil)
+
+			usecase := NewMovieUsecase(movieRepoMock, &commonMock.DummyDB{}, &mocks.MovieIndex{}, learning(), model.SimilarityWeights{})
+			result, err := usecase.SearchMovies(context.TODO(), title, 1, model.SearchFilter{Type: "movie"})
+			if assert.Nil(t, err, title) {
+				assert.Equal(t, found, result, title)
+			}
+		}
+	})
+
+	t.Run("[SearchMovies] a year that is part of the title", func(t *testing.T) {
+		wonderWoman := &model.MovieSearch{
+			Search:       []model.SearchDetail{{Title: "Wonder Woman 1984", Year: "2020", ImdbID: "tt7126948", Type: "movie"}},
+			TotalResults: "1",
+			Response:     "True",
+		}
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("SearchMovies", testify.Anything, "Wonder Woman", uint32(1), model.SearchFilter{Year: 1984}).Return(notFound, nil)
+		movieRepoMock.On("SearchMovies", testify.Anything, "Wonder Woman 1984", uint32(1), model.SearchFilter{}).Return(wonderWoman, nil)
+
+		usecase := NewMovieUsecase(movieRepoMock, &commonMock.DummyDB{}, &mocks.MovieIndex{}, learning(), model.SimilarityWeights{})
+		result, err := usecase.SearchMovies(context.TODO(), "Wonder Woman 1984", 1, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, wonderWoman, result)
+		}
+	})
+
+	t.Run("[SearchMovies] years kept in the title", func(t *testing.T) {
+		// a year already filtered by, only a year, no movie year
+		for title, filter := range map[string]model.SearchFilter{
+			"Alien 1979":        {Year: 1986},
+			"1917":              {},
+			"Blade Runner 2049": {},
+			"Apollo 13":         {},
+		} {
+			movieRepoMock := &mocks.MovieRepository{}
+			movieRepoMock.On("SearchMovies", testify.Anything, title, uint32(1), filter).Return(found, nil)
+
+			usecase := NewMovieUsecase(movieRepoMock, &commonMock.DummyDB{}, &mocks.MovieIndex{}, learning(), model.SimilarityWeights{})
+			_, err := usecase.SearchMovies(context.TODO(), title, 1, filter)
+			assert.Nil(t, err, title)
+			movieRepoMock.AssertNumberOfCalls(t, "SearchMovies", 1)
+		}
+	})
+}
+
+func TestSearchMoviesFullText(t *testing.T) {
+	notFound := &model.MovieSearch{Response: "False", Error: "Movie not found!"}
+	hits := &model.FullTextResult{
+		Hits: []model.FullTextHit{
+			{Movie: model.SearchDetail{Title: "Heat", Year: "1995", ImdbID: "tt0113277", Type: "movie"}, Score: 9.1, Fields: []string{"actors", "plot"}},
+			{Movie: model.SearchDetail{Title: "Dog Day Afternoon", Year: "1975", ImdbID: "tt0072890", Type: "movie"}, Score: 4.2, Fields: []string{"actors"}},
+		},
+		Total: 12,
+	}
+
+	t.Run("[SearchMovies] index answers when no title matches", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("SearchMovies", testify.Anything, "heist al pacino", uint32(1), model.SearchFilter{Year: 1995}).Return(notFound, nil)
+		movieIndexMock := &mocks.MovieIndex{}
+		movieIndexMock.On("Search", testify.Anything, "heist al pacino", uint32(1), model.SearchFilter{Year: 1995}).Return(hits, nil)
+
+		usecase := NewMovieUsecase(movieRepoMock, &commonMock.DummyDB{}, movieIndexMock, learning(), model.SimilarityWeights{})
+		result, err := usecase.SearchMovies(context.TODO(), "heist al pacino", 1, model.SearchFilter{Year: 1995})
+		if assert.Nil(t, err) {
+			// a single page, the index isn't paged through
+			assert.Equal(t, &model.MovieSearch{
+				Search:       []model.SearchDetail{hits.Hits[0].Movie, hits.Hits[1].Movie},
+				TotalResults: "2",
+				Response:     "True",
+			}, result)
+		}
+	})
+
+	t.Run("[SearchMovies] past the last page is not asked to the index", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("SearchMovies", testify.Anything, "heat", uint32(4), model.SearchFilter{}).Return(notFound, nil)
+		movieIndexMock := &mocks.MovieIndex{}
+
+		usecase := NewMovieUsecase(movieRepoMock, &commonMock.DummyDB{}, movieIndexMock, learning(), model.SimilarityWeights{})
+		result, err := usecase.SearchMovies(context.TODO(), "heat", 4, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, notFound, result)
+		}
+		movieIndexMock.AssertNotCalled(t, "Search", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("[SearchMovies] index finding nothing or failing leaves the answer as is", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(notFound, nil)
+		movieIndexMock := &mocks.MovieIndex{}
+		movieIndexMock.On("Search", testify.Anything, "nothing", testify.Anything, testify.Anything).Return(&model.FullTextResult{}, nil)
+		movieIndexMock.On("Search", testify.Anything, "broken", testify.Anything, testify.Anything).Return(nil, errors.New("error"))
+
+		usecase := NewMovieUsecase(movieRepoMock, &commonMock.DummyDB{}, movieIndexMock, learning(), model.SimilarityWeights{})
+		for _, title := range []string{"nothing", "broken"} {
+			result, err := usecase.SearchMovies(context.TODO(), title, 1, model.SearchFilter{})
+			if assert.Nil(t, err) {
+				assert.Equal(t, notFound, result)
+			}
+		}
+	})
+
+	t.Run("[FullTextSearch] searches the index", func(t *testing.T) {
+		movieIndexMock := &mocks.MovieIndex{}
+		movieIndexMock.On("Search", testify.Anything, "heist al pacino", uint32(1), model.SearchFilter{}).Return(hits, nil)
+
+		usecase := NewMovieUsecase(&mocks.MovieRepository{}, &commonMock.DummyDB{}, movieIndexMock, learning(), model.SimilarityWeights{})
+		result, err := usecase.FullTextSearch(context.TODO(), "heist al pacino", 1, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, hits, result)
+		}
+	})
+}
+
 func TestGetMovieDetailByID(t *testing.T) {
 	t.Run("[GetMovieDetailByID] movieRepo returns error", func(t *testing.T) {
 		movieRepoMock := &mocks.MovieRepository{}
 		movieDBMock := &commonMock.DummyDB{}
-		movieRepoMock.On("GetMovieDetailByID", testify.Anything).Return(&model.MovieDetail{}, errors.New("error"))
+		movieRepoMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(&model.MovieDetail{}, errors.New("error"))
 		movieDBMock.On("Log", testify.Anything).Return(nil)
 
-		usecase := NewMovieUsecase(movieRepoMock, movieDBMock)
-		_, err := usecase.GetMovieDetailByID("id")
+		usecase := NewMovieUsecase(movieRepoMock, movieDBMock, &mocks.MovieIndex{}, learning(), model.SimilarityWeights{})
+		_, err := usecase.GetMovieDetailByID(context.TODO(), "id")
 		if assert.Error(t, err) {
 			assert.Equal(t, "error", err.Error())
 		}
@@ -85,25 +223,102 @@ func TestGetMovieDetailByID(t *testing.T) {
 
 		movieRepoMock := &mocks.MovieRepository{}
 		movieDBMock := &commonMock.DummyDB{}
-		movieRepoMock.On("GetMovieDetailByID", testify.Anything).Return(expectedResult, nil)
+		movieRepoMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(expectedResult, nil)
 		movieDBMock.On("Log", testify.Anything).Return(nil)
 
-		usecase := NewMovieUsecase(movieRepoMock, movieDBMock)
-		result, err := usecase.GetMovieDetailByID("id")
+		usecase := NewMovieUsecase(movieRepoMock, movieDBMock, &mocks.MovieIndex{}, learning(), model.SimilarityWeights{})
+		result, err := usecase.GetMovieDetailByID(context.TODO(), "id")
 		if assert.Nil(t, err) {
 			assert.Equal(t, expectedResult, result)
 		}
 	})
 }
 
+func TestSimilarMovies(t *testing.T) {
+	weights := model.SimilarityWeights{Genres: 3, Director: 2}
+	detail := &model.MovieDetail{Title: "The Dark Knight", ImdbID: "tt0468569", Genre: "Action, Crime, Drama", Response: "True"}
+
+	t.Run("[SimilarMovies] ranked by the index with the weights", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("GetMovieDetailByID", testify.Anything, "tt0468569").Return(detail, nil)
+		similar := []model.SimilarMovie{{Movie: model.SearchDetail{Title: "Batman Begins", ImdbID: "tt0372784"}, Score: 5, Shared: []string{"genres", "director"}}}
+		movieIndexMock := &mocks.MovieIndex{}
+		movieIndexMock.On("Similar", testify.Anything, detail, weights, 5).Return(similar, nil)
+
+		result, err := NewMovieUsecase(movieRepoMock, &commonMock.DummyDB{}, movieIndexMock, learning(), weights).SimilarMovies(context.TODO(), "tt0468569", 5, false)
+		if assert.Nil(t, err) {
+			assert.Equal(t, similar, result)
+		}
+	})
+
+	t.Run("[SimilarMovies] unknown movie", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(&model.MovieDetail{Response: "False", Error: "Incorrect IMDb ID."}, nil)
+
+		_, err := NewMovieUsecase(movieRepoMock, &commonMock.DummyDB{}, &mocks.MovieIndex{}, learning(), weights).SimilarMovies(context.TODO(), "tt0000000", 5, false)
+		assert.Equal(t, model.ErrMovieNotFound, err)
+	})
+
+	t.Run("[SimilarMovies] movieRepo returns error", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(nil, model.ErrUpstream)
+
+		_, err := NewMovieUsecase(movieRepoMock, &commonMock.DummyDB{}, &mocks.MovieIndex{}, learning(), weights).SimilarMovies(context.TODO(), "tt0468569", 5, false)
+		assert.Equal(t, model.ErrUpstream, err)
+	})
+}
+
 func TestLogToDB(t *testing.T) {
 	t.Run("[LogToDB] return error", func(t *testing.T) {
 		movieRepoMock := &mocks.MovieRepository{}
 		movieDBMock := &commonMock.DummyDB{}
 		movieDBMock.On("Log", testify.Anything).Return(errors.New("error"))
 
-		usecase := movieUsecase{movieRepoMock, movieDBMock}
+		usecase := movieUsecase{movieRepoMock, movieDBMock, &mocks.MovieIndex{}, &mocks.TitleCompleter{}, model.SimilarityWeights{}}
 		err := usecase.LogToDB("")
 		assert.Error(t, err)
 	})
 }
+
+func TestAutocomplete(t *testing.T) {
+	t.Run("[SearchMovies] titles found complete the ones typed next", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(&model.MovieSearch{
+			Search:   []model.SearchDetail{{Title: "Iron Man", Year: "2008", ImdbID: "tt0371746", Type: "movie"}},
+			Response: "True",
+		}, nil)
+		completer := learning()
+
+		NewMovieUsecase(movieRepoMock, &commonMock.DummyDB{}, &mocks.MovieIndex{}, completer, model.SimilarityWeights{}).SearchMovies(context.TODO(), "iron man", 1, model.SearchFilter{})
+		completer.AssertCalled(t, "Add", testify.Anything, model.Completion{Title: "Iron Man", ImdbID: "tt0371746", Year: "2008", Type: "movie"})
+	})
+
+	t.Run("[GetMovieDetailByID] details rank the titles by their votes and rating", func(t *testing.T) {
+		movieRepoMock := &mocks.MovieRepository{}
+		movieRepoMock.On("GetMovieDetailByID", testify.Anything, "tt0371746").Return(&model.MovieDetail{
+			Title: "Iron Man", Year: "2008", ImdbID: "tt0371746", Type: "movie", ImdbRating: "7.9", ImdbVotes: "1,107,231", Response: "True",
+		}, nil)
+		movieRepoMock.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(&model.MovieDetail{Response: "False", Error: "Incorrect IMDb ID."}, nil)
+		completer := learning()
+
+		usecase := NewMovieUsecase(movieRepoMock, &commonMock.DummyDB{}, &mocks.MovieIndex{}, completer, model.SimilarityWeights{})
+		usecase.GetMovieDetailByID(context.TODO(), "tt0371746")
+		usecase.GetMovieDetailByID(context.TODO(), "tt0000000")
+
+		completer.AssertCalled(t, "Add", testify.Anything, model.Completion{
+			Title: "Iron Man", ImdbID: "tt0371746", Year: "2008", Type: "movie", Popularity: model.TitlePopularity(1107231, 7.9),
+		})
+		completer.AssertNumberOfCalls(t, "Add", 1)
+	})
+
+	t.Run("[Autocomplete] completes with the completer", func(t *testing.T) {
+		completions := []model.Completion{{Title: "Iron Man", ImdbID: "tt0371746", Year: "2008", Type: "movie", Popularity: 4.8}}
+		completer := &mocks.TitleCompleter{}
+		completer.On("Complete", testify.Anything, "iron", 5).Return(completions)
+
+		result, err := NewMovieUsecase(&mocks.MovieRepository{}, &commonMock.DummyDB{}, &mocks.MovieIndex{}, completer, model.SimilarityWeights{}).Autocomplete(context.TODO(), "iron", 5)
+		if assert.Nil(t, err) {
+			assert.Equal(t, completions, result)
+		}
+	})
+}
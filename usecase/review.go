@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+const (
+	minRating = 1
+	maxRating = 10
+	// maxReviewText is the longest text of a review or moderation note, in characters
+	maxReviewText = 1000
+	// defaultReviewPageSize is the size of a page of reviews when the caller doesn't choose, maxReviewPageSize the largest one
+	defaultReviewPageSize = 10
+	maxReviewPageSize     = 50
+)
+
+type reviewUsecase struct {
+	ReviewRepo model.ReviewRepository
+	MovieRepo  model.MovieRepository
+
+	moderators map[string]bool
+	now        func() time.Time
+}
+
+// NewReviewUsecase lets the callers listed in moderators hide reviews and list hidden ones
+func NewReviewUsecase(reviewRepo model.ReviewRepository, movieRepo model.MovieRepository, moderators []string) model.ReviewUsecase {
+	usecase := &reviewUsecase{
+		ReviewRepo: reviewRepo,
+		MovieRepo:  movieRepo,
+		moderators: map[string]bool{},
+		now:        time.Now,
+	}
+	for _, moderator := range moderators {
+		usecase.moderators[moderator] = true
+	}
+
+	return usecase
+}
+
+func (usecase *reviewUsecase) SubmitReview(ctx context.Context, imdbID string, rating int, text string) (*model.Review, error) {
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) > maxReviewText {
+		return nil, model.ErrInvalidReview
+	}
+
+	return usecase.save(ctx, imdbID, rating, func(review *model.Review) {
+		review.Text = text
+	})
+}
+
+func (usecase *reviewUsecase) RateMovie(ctx context.Context, imdbID string, rating int) (*model.Review, error) {
+	return usecase.save(ctx, imdbID, rating, func(*model.Review) {})
+}
+
+// save gives rating to the review of the caller for the movie, created when there is none, and lets change
+// update the rest of it
+func (usecase *reviewUsecase) save(ctx context.Context, imdbID string, rating int, change func(review *model.Review)) (*model.Review, error) {
+	caller, ok := model.CallerFrom(ctx)
+	if !ok {
+		return nil, model.ErrUnauthenticated
+	}
+
+	if rating < minRating || rating > maxRating {
+		return nil, model.ErrInvalidRating
+	}
+
+	detail, err := usecase.MovieRepo.GetMovieDetailByID(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.Error != "" {
+		return nil, model.ErrMovieNotFound
+	}
+
+	now := usecase.now().UTC()
+	return usecase.ReviewRepo.Upsert(ctx, reviewID(imdbID, caller), func(review *model.Review) error {
+		if review.CreatedAt.IsZero() {
+			review.ImdbID = imdbID
+			review.Author = caller
+			review.State = model.ReviewPublished
+			review.CreatedAt = now
+		}
+		// a hidden review stays hidden when its author changes it
+		review.Rating = rating
+		review.UpdatedAt = now
+		change(review)
+		return nil
+	})
+}
+
+func (usecase *reviewUsecase) DeleteReview(ctx context.Context, imdbID string) error {
+	caller, ok := model.CallerFrom(ctx)
+	if !ok {
+		return model.ErrUnauthenticated
+	}
+
+	return usecase.ReviewRepo.Delete(ctx, reviewID(imdbID, caller))
+}
+
+func (usecase *reviewUsecase) Reviews(ctx context.Context, imdbID string, page, pageSize int, includeHidden bool) (*model.ReviewPage, error) {
+	if includeHidden {
+		if err := usecase.checkModerator(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	switch {
+	case pageSize < 1:
+		pageSize = defaultReviewPageSize
+	case pageSize > maxReviewPageSize:
+		pageSize = maxReviewPageSize
+	}
+
+	reviews, err := usecase.ReviewRepo.ByMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ReviewPage{Reviews: []*model.Review{}}
+	result.Votes, result.Average = communityRating(reviews)
+
+	listed := reviews[:0]
+	for _, review := range reviews {
+		if includeHidden || review.State == model.ReviewPublished {
+			listed = append(listed, review)
+		}
+	}
+	result.Total = len(listed)
+	if start := (page - 1) * pageSize; start < len(listed) {
+		end := start + pageSize
+		if end > len(listed) {
+			end = len(listed)
+		}
+		result.Reviews = listed[start:end]
+	}
+
+	return result, nil
+}
+
+func (usecase *reviewUsecase) ModerateReview(ctx context.Context, id, state, note string) (*model.Review, error) {
+	if err := usecase.checkModerator(ctx); err != nil {
+		return nil, err
+	}
+	caller, _ := model.CallerFrom(ctx)
+
+	if state != model.ReviewPublished && state != model.ReviewHidden {
+		return nil, model.ErrInvalidReviewState
+	}
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxReviewText {
+		return nil, model.ErrInvalidReview
+	}
+
+	now := usecase.now().UTC()
+	return usecase.ReviewRepo.Upsert(ctx, id, func(review *model.Review) error {
+		// moderating never creates a review
+		if review.CreatedAt.IsZero() {
+			return model.ErrReviewNotFound
+		}
+
+		review.State = state
+		review.ModerationNote = note
+		review.ModeratedBy = caller
+		review.ModeratedAt = now
+		return nil
+	})
+}
+
+func (usecase *reviewUsecase) checkModerator(ctx context.Context) error {
+	caller, ok := model.CallerFrom(ctx)
+	if !ok {
+		return model.ErrUnauthenticated
+	}
+	if !usecase.moderators[caller] {
+		return model.ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// reviewID is the ID of the review of author for the movie with IMDb ID imdbID, a user has one review per movie
+func reviewID(imdbID, author string) string {
+	sum := sha256.Sum256([]byte(imdbID + "\x00" + author))
+	return hex.EncodeToString(sum[:16])
+}
+
+// communityRating counts the published reviews and averages their rating
+func communityRating(reviews []*model.Review) (votes int, average float64) {
+	sum := 0
+	for _, review := range reviews {
+		if review.State == model.ReviewPublished {
+			votes++
+			sum += review.Rating
+		}
+	}
+	if votes == 0 {
+		return 0, 0
+	}
+
+	return votes, float64(sum) / float64(votes)
+}
+
+// communityRatedUsecase adds the community rating of a movie to its details, see model.CommunityRatingSource.
+// The other calls go to the embedded usecase
+type communityRatedUsecase struct {
+	model.MovieUsecase
+	ReviewRepo model.ReviewRepository
+}
+
+func WithCommunityRatings(movieUsecase model.MovieUsecase, reviewRepo model.ReviewRepository) model.MovieUsecase {
+	return &communityRatedUsecase{
+		MovieUsecase: movieUsecase,
+		ReviewRepo:   reviewRepo,
+	}
+}
+
+func (usecase *communityRatedUsecase) GetMovieDetailByID(ctx context.Context, id string) (detail *model.MovieDetail, err error) {
+	detail, err = usecase.MovieUsecase.GetMovieDetailByID(ctx, id)
+	if err != nil || detail == nil || detail.Error != "" {
+		return detail, err
+	}
+
+	reviews, err := usecase.ReviewRepo.ByMovie(ctx, id)
+	if err != nil {
+		// the details are still worth answering without the community rating
+		log.Println("community rating of", id, ":", err)
+		return detail, nil
+	}
+	votes, average := communityRating(reviews)
+	if votes == 0 {
+		return detail, nil
+	}
+
+	// the detail may be shared with the cache, the rating goes to a copy
+	rated := *detail
+	rated.Ratings = make([]model.MovieRating, len(detail.Ratings), len(detail.Ratings)+1)
+	copy(rated.Ratings, detail.Ratings)
+	rated.Ratings = append(rated.Ratings, model.CommunityRating(average))
+
+	return &rated, nil
+}
@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+// storedReviews mocks a repository holding reviews, upserts are applied to a copy of the review like the repository does
+func storedReviews(reviews ...model.Review) *mocks.ReviewRepository {
+	repo := &mocks.ReviewRepository{}
+	byMovie := map[string][]*model.Review{}
+	for i := range reviews {
+		review := reviews[i]
+		byMovie[review.ImdbID] = append(byMovie[review.ImdbID], &review)
+	}
+	repo.On("ByMovie", testify.Anything, testify.Anything).Return(func(_ context.Context, imdbID string) []*model.Review {
+		return append([]*model.Review{}, byMovie[imdbID]...)
+	}, nil)
+
+	// the mock gets the review then the error from two functions, the error is the one of the change the first ran
+	var changeErr error
+	repo.On("Upsert", testify.Anything, testify.Anything, testify.Anything).Return(
+		func(_ context.Context, id string, change func(*model.Review) error) *model.Review {
+			review := &model.Review{}
+			for _, stored := range reviews {
+				if stored.ID == id {
+					copied := stored
+					review = &copied
+				}
+			}
+			if changeErr = change(review); changeErr != nil {
+				return nil
+			}
+			review.ID = id
+			return review
+		},
+		func(context.Context, string, func(*model.Review) error) error {
+			return changeErr
+		},
+	)
+
+	return repo
+}
+
+func ironManReview(id, author string, rating int, state string) model.Review {
+	return model.Review{ID: id, ImdbID: "tt0371746", Author: author, Rating: rating, State: state, CreatedAt: created, UpdatedAt: created}
+}
+
+func newTestReviewUsecase(reviewRepo model.ReviewRepository, movieRepo model.MovieRepository) *reviewUsecase {
+	usecase := NewReviewUsecase(reviewRepo, movieRepo, []string{"moderator"}).(*reviewUsecase)
+	usecase.now = func() time.Time { return added }
+
+	return usecase
+}
+
+func TestReviewID(t *testing.T) {
+	t.Run("[reviewID] one per author and movie", func(t *testing.T) {
+		assert.Regexp(t, `^[0-9a-f]{32}$`, reviewID("tt0371746", "user-1"))
+		assert.Equal(t, reviewID("tt0371746", "user-1"), reviewID("tt0371746", "user-1"))
+		assert.NotEqual(t, reviewID("tt0371746", "user-1"), reviewID("tt0371746", "user-2"))
+		assert.NotEqual(t, reviewID("tt0371746", "user-1"), reviewID("tt1228705", "user-1"))
+	})
+}
+
+func TestSubmitReview(t *testing.T) {
+	t.Run("[SubmitReview] new review of the caller", func(t *testing.T) {
+		reviewRepo := storedReviews()
+
+		review, err := newTestReviewUsecase(reviewRepo, knownMovies()).SubmitReview(asCaller("user-1"), "tt0371746", 8, " Great suit ")
+		if assert.Nil(t, err) {
+			assert.Equal(t, &model.Review{
+				ID:        reviewID("tt0371746", "user-1"),
+				ImdbID:    "tt0371746",
+				Author:    "user-1",
+				Rating:    8,
+				Text:      "Great suit",
+				State:     model.ReviewPublished,
+				CreatedAt: added,
+				UpdatedAt: added,
+			}, review)
+		}
+	})
+
+	t.Run("[SubmitReview] replaces the rating and text, hidden reviews stay hidden", func(t *testing.T) {
+		stored := ironManReview(reviewID("tt0371746", "user-1"), "user-1", 3, model.ReviewHidden)
+		stored.Text = "spoilers"
+
+		review, err := newTestReviewUsecase(storedReviews(stored), knownMovies()).SubmitReview(asCaller("user-1"), "tt0371746", 7, "")
+		if assert.Nil(t, err) {
+			assert.Equal(t, 7, review.Rating)
+			assert.Empty(t, review.Text)
+			assert.Equal(t, created, review.CreatedAt)
+			assert.Equal(t, added, review.UpdatedAt)
+			assert.Equal(t, model.ReviewHidden, review.State)
+		}
+	})
+
+	t.Run("[SubmitReview] invalid review", func(t *testing.T) {
+		usecase := newTestReviewUsecase(storedReviews(), knownMovies())
+
+		_, err := usecase.SubmitReview(context.TODO(), "tt0371746", 8, "")
+		assert.Equal(t, model.ErrUnauthenticated, err)
+
+		for _, rating := range []int{0, 11, -1} {
+			_, err = usecase.SubmitReview(asCaller("user-1"), "tt0371746", rating, "")
+			assert.Equal(t, model.ErrInvalidRating, err)
+		}
+
+		_, err = usecase.SubmitReview(asCaller("user-1"), "tt0371746", 8, string(make([]rune, maxReviewText+1)))
+		assert.Equal(t, model.ErrInvalidReview, err)
+
+		_, err = usecase.SubmitReview(asCaller("user-1"), "tt0000000", 8, "")
+		assert.Equal(t, model.ErrMovieNotFound, err)
+	})
+
+	t.Run("[SubmitReview] lookup error", func(t *testing.T) {
+		movieRepo := &mocks.MovieRepository{}
+		movieRepo.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(nil, model.ErrQuotaExceeded)
+
+		_, err := newTestReviewUsecase(storedReviews(), movieRepo).SubmitReview(asCaller("user-1"), "tt0371746", 8, "")
+		assert.Equal(t, model.ErrQuotaExceeded, err)
+	})
+}
+
+func TestRateMovie(t *testing.T) {
+	t.Run("[RateMovie] keeps the text of the review", func(t *testing.T) {
+		stored := ironManReview(reviewID("tt0371746", "user-1"), "user-1", 3, model.ReviewPublished)
+		stored.Text = "Great suit"
+
+		review, err := newTestReviewUsecase(storedReviews(stored), knownMovies()).RateMovie(asCaller("user-1"), "tt0371746", 9)
+		if assert.Nil(t, err) {
+			assert.Equal(t, 9, review.Rating)
+			assert.Equal(t, "Great suit", review.Text)
+			assert.Equal(t, added, review.UpdatedAt)
+		}
+	})
+
+	t.Run("[RateMovie] new review without text", func(t *testing.T) {
+		review, err := newTestReviewUsecase(storedReviews(), knownMovies()).RateMovie(asCaller("user-1"), "tt0371746", 9)
+		if assert.Nil(t, err) {
+			assert.Equal(t, reviewID("tt0371746", "user-1"), review.ID)
+			assert.Equal(t, model.ReviewPublished, review.State)
+			assert.Empty(t, review.Text)
+		}
+
+		_, err = newTestReviewUsecase(storedReviews(), knownMovies()).RateMovie(asCaller("user-1"), "tt0371746", 0)
+		assert.Equal(t, model.ErrInvalidRating, err)
+	})
+}
+
+func TestDeleteReview(t *testing.T) {
+	t.Run("[DeleteReview] review of the caller", func(t *testing.T) {
+		reviewRepo := &mocks.ReviewRepository{}
+		reviewRepo.On("Delete", testify.Anything, reviewID("tt0371746", "user-1")).Return(nil)
+		reviewRepo.On("Delete", testify.Anything, testify.Anything).Return(model.ErrReviewNotFound)
+		usecase := newTestReviewUsecase(reviewRepo, nil)
+
+		assert.Nil(t, usecase.DeleteReview(asCaller("user-1"), "tt0371746"))
+		assert.Equal(t, model.ErrReviewNotFound, usecase.DeleteReview(asCaller("user-2"), "tt0371746"))
+		assert.Equal(t, model.ErrUnauthenticated, usecase.DeleteReview(context.TODO(), "tt0371746"))
+	})
+}
+
+func TestReviews(t *testing.T) {
+	reviews := []model.Review{
+		ironManReview("a", "user-1", 8, model.ReviewPublished),
+		ironManReview("b", "user-2", 1, model.ReviewHidden),
+		ironManReview("c", "user-3", 9, model.ReviewPublished),
+		ironManReview("d", "user-4", 6, model.ReviewPublished),
+	}
+
+	t.Run("[Reviews] published reviews page by page", func(t *testing.T) {
+		usecase := newTestReviewUsecase(storedReviews(reviews...), nil)
+
+		page, err := usecase.Reviews(context.TODO(), "tt0371746", 1, 2, false)
+		if assert.Nil(t, err) && assert.Len(t, page.Reviews, 2) {
+			assert.Equal(t, "a", page.Reviews[0].ID)
+			assert.Equal(t, "c", page.Reviews[1].ID)
+			assert.Equal(t, 3, page.Total)
+			assert.Equal(t, 3, page.Votes)
+			assert.InDelta(t, 23.0/3, page.Average, 0.001)
+		}
+
+		page, _ = usecase.Reviews(context.TODO(), "tt0371746", 2, 2, false)
+		if assert.Len(t, page.Reviews, 1) {
+			assert.Equal(t, "d", page.Reviews[0].ID)
+		}
+
+		page, _ = usecase.Reviews(context.TODO(), "tt0371746", 3, 2, false)
+		assert.Empty(t, page.Reviews)
+		assert.Equal(t, 3, page.Total)
+	})
+
+	t.Run("[Reviews] default page and page size", func(t *testing.T) {
+		usecase := newTestReviewUsecase(storedReviews(reviews...), nil)
+
+		page, _ := usecase.Reviews(context.TODO(), "tt0371746", 0, 0, false)
+		assert.Len(t, page.Reviews, 3)
+
+		page, _ = usecase.Reviews(context.TODO(), "tt1228705", 1, maxReviewPageSize+1, false)
+		assert.Empty(t, page.Reviews)
+		assert.Equal(t, 0, page.Votes)
+	})
+
+	t.Run("[Reviews] hidden reviews for moderators only", func(t *testing.T) {
+		usecase := newTestReviewUsecase(storedReviews(reviews...), nil)
+
+		page, err := usecase.Reviews(asCaller("moderator"), "tt0371746", 1, 10, true)
+		if assert.Nil(t, err) {
+			assert.Len(t, page.Reviews, 4)
+			assert.Equal(t, 4, page.Total)
+			// hidden reviews don't count towards the community rating
+			assert.Equal(t, 3, page.Votes)
+		}
+
+		_, err = usecase.Reviews(asCaller("user-1"), "tt0371746", 1, 10, true)
+		assert.Equal(t, model.ErrPermissionDenied, err)
+		_, err = usecase.Reviews(context.TODO(), "tt0371746", 1, 10, true)
+		assert.Equal(t, model.ErrUnauthenticated, err)
+	})
+
+	t.Run("[Reviews] repository error", func(t *testing.T) {
+		reviewRepo := &mocks.ReviewRepository{}
+		reviewRepo.On("ByMovie", testify.Anything, testify.Anything).Return(nil, errors.New("error"))
+
+		_, err := newTestReviewUsecase(reviewRepo, nil).Reviews(context.TODO(), "tt0371746", 1, 10, false)
+		assert.Equal(t, "error", err.Error())
+	})
+}
+
+func TestModerateReview(t *testing.T) {
+	t.Run("[ModerateReview] hidden by a moderator", func(t *testing.T) {
+		stored := ironManReview("a", "user-1", 8, model.ReviewPublished)
+
+		review, err := newTestReviewUsecase(storedReviews(stored), nil).ModerateReview(asCaller("moderator"), "a", model.ReviewHidden, " spoilers ")
+		if assert.Nil(t, err) {
+			assert.Equal(t, model.ReviewHidden, review.State)
+			assert.Equal(t, "spoilers", review.ModerationNote)
+			assert.Equal(t, "moderator", review.ModeratedBy)
+			assert.Equal(t, added, review.ModeratedAt)
+			assert.Equal(t, 8, review.Rating)
+		}
+	})
+
+	t.Run("[ModerateReview] not allowed or invalid", func(t *testing.T) {
+		usecase := newTestReviewUsecase(storedReviews(ironManReview("a", "user-1", 8, model.ReviewPublished)), nil)
+
+		_, err := usecase.ModerateReview(context.TODO(), "a", model.ReviewHidden, "")
+		assert.Equal(t, model.ErrUnauthenticated, err)
+		_, err = usecase.ModerateReview(asCaller("user-1"), "a", model.ReviewHidden, "")
+		assert.Equal(t, model.ErrPermissionDenied, err)
+		_, err = usecase.ModerateReview(asCaller("moderator"), "a", "deleted", "")
+		assert.Equal(t, model.ErrInvalidReviewState, err)
+		_, err = usecase.ModerateReview(asCaller("moderator"), "a", model.ReviewHidden, string(make([]rune, maxReviewText+1)))
+		assert.Equal(t, model.ErrInvalidReview, err)
+		_, err = usecase.ModerateReview(asCaller("moderator"), "b", model.ReviewHidden, "")
+		assert.Equal(t, model.ErrReviewNotFound, err)
+	})
+}
+
+func TestWithCommunityRatings(t *testing.T) {
+	imdb := model.MovieRating{Source: "Internet Movie Database", Value: "7.9/10"}
+	detailOf := func() *mocks.MovieUsecase {
+		movieUsecase := &mocks.MovieUsecase{}
+		movieUsecase.On("GetMovieDetailByID", testify.Anything, "tt0000000").Return(&model.MovieDetail{Response: "False", Error: "Incorrect IMDb ID."}, nil)
+		movieUsecase.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(func(_ context.Context, id string) *model.MovieDetail {
+			return &model.MovieDetail{ImdbID: id, Ratings: []model.MovieRating{imdb}, Response: "True"}
+		}, nil)
+		return movieUsecase
+	}
+
+	t.Run("[GetMovieDetailByID] community rating after the OMDb ones", func(t *testing.T) {
+		usecase := WithCommunityRatings(detailOf(), storedReviews(
+			ironManReview("a", "user-1", 8, model.ReviewPublished),
+			ironManReview("b", "user-2", 1, model.ReviewHidden),
+			ironManReview("c", "user-3", 9, model.ReviewPublished),
+		))
+
+		detail, err := usecase.GetMovieDetailByID(context.TODO(), "tt0371746")
+		assert.Nil(t, err)
+		assert.Equal(t, []model.MovieRating{imdb, {Source: model.CommunityRatingSource, Value: "8.5/10"}}, detail.Ratings)
+	})
+
+	t.Run("[GetMovieDetailByID] no published reviews or unknown movie", func(t *testing.T) {
+		usecase := WithCommunityRatings(detailOf(), storedReviews(ironManReview("b", "user-2", 1, model.ReviewHidden)))
+
+		detail, _ := usecase.GetMovieDetailByID(context.TODO(), "tt0371746")
+		assert.Equal(t, []model.MovieRating{imdb}, detail.Ratings)
+
+		detail, _ = usecase.GetMovieDetailByID(context.TODO(), "tt0000000")
+		assert.Empty(t, detail.Ratings)
+	})
+
+	t.Run("[GetMovieDetailByID] repository error keeps the details", func(t *testing.T) {
+		reviewRepo := &mocks.ReviewRepository{}
+		reviewRepo.On("ByMovie", testify.Anything, testify.Anything).Return(nil, errors.New("error"))
+
+		detail, err := WithCommunityRatings(detailOf(), reviewRepo).GetMovieDetailByID(context.TODO(), "tt0371746")
+		assert.Nil(t, err)
+		assert.Equal(t, []model.MovieRating{imdb}, detail.Ratings)
+	})
+
+	t.Run("[GetMovieDetailByID] shared details are not changed", func(t *testing.T) {
+		shared := &model.MovieDetail{ImdbID: "tt0371746", Ratings: make([]model.MovieRating, 1, 2)}
+		shared.Ratings[0] = imdb
+		movieUsecase := &mocks.MovieUsecase{}
+		movieUsecase.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(shared, nil)
+
+		usecase := WithCommunityRatings(movieUsecase, storedReviews(ironManReview("a", "user-1", 8, model.ReviewPublished)))
+		usecase.GetMovieDetailByID(context.TODO(), "tt0371746")
+		usecase.GetMovieDetailByID(context.TODO(), "tt0371746")
+
+		assert.Equal(t, []model.MovieRating{imdb}, shared.Ratings)
+		// not even the spare capacity of the ratings
+		assert.Equal(t, model.MovieRating{}, shared.Ratings[:2][1])
+	})
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// watchlistExcludingUsecase leaves the movies the caller listed to watch out of their similar movies when
+// asked to, they know about those already. The other calls go to the embedded usecase
+type watchlistExcludingUsecase struct {
+	model.MovieUsecase
+	WatchlistRepo model.WatchlistRepository
+}
+
+func WithWatchlistExclusion(movieUsecase model.MovieUsecase, watchlistRepo model.WatchlistRepository) model.MovieUsecase {
+	return &watchlistExcludingUsecase{
+		MovieUsecase:  movieUsecase,
+		WatchlistRepo: watchlistRepo,
+	}
+}
+
+// SimilarMovies asks for as many more movies as the watchlists of the caller have, so leaving those out
+// still answers max movies when there are enough
+func (usecase *watchlistExcludingUsecase) SimilarMovies(ctx context.Context, id string, max int, excludeWatchlisted bool) (movies []model.SimilarMovie, err error) {
+	if !excludeWatchlisted {
+		return usecase.MovieUsecase.SimilarMovies(ctx, id, max, false)
+	}
+
+	caller, ok := model.CallerFrom(ctx)
+	if !ok {
+		return nil, model.ErrUnauthenticated
+	}
+	lists, err := usecase.WatchlistRepo.ListByOwner(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+	listed := map[string]bool{}
+	for _, list := range lists {
+		for _, item := range list.Items {
+			listed[item.ImdbID] = true
+		}
+	}
+
+	fetch := max
+	if max > 0 {
+		fetch += len(listed)
+	}
+	similar, err := usecase.MovieUsecase.SimilarMovies(ctx, id, fetch, false)
+	if err != nil {
+		return nil, err
+	}
+
+	movies = make([]model.SimilarMovie, 0, len(similar))
+	for _, movie := range similar {
+		if listed[movie.Movie.ImdbID] {
+			continue
+		}
+		if max > 0 && len(movies) == max {
+			break
+		}
+		movies = append(movies, movie)
+	}
+
+	return movies, nil
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+func TestWithWatchlistExclusion(t *testing.T) {
+	similar := []model.SimilarMovie{
+		{Movie: model.SearchDetail{Title: "Batman Begins", ImdbID: "tt0372784"}, Score: 8},
+		{Movie: model.SearchDetail{Title: "Inception", ImdbID: "tt1375666"}, Score: 4.5},
+		{Movie: model.SearchDetail{Title: "Heat", ImdbID: "tt0113277"}, Score: 4.4},
+		{Movie: model.SearchDetail{Title: "The Notebook", ImdbID: "tt0332280"}, Score: 2.6},
+	}
+	watching := func() *mocks.WatchlistRepository {
+		watchlistRepo := &mocks.WatchlistRepository{}
+		watchlistRepo.On("ListByOwner", testify.Anything, "user-1").Return([]*model.Watchlist{
+			{ID: "list-1", Owner: "user-1", Items: []model.WatchlistItem{{ImdbID: "tt0372784"}, {ImdbID: "tt0000001"}}},
+			{ID: "list-2", Owner: "user-1", Items: []model.WatchlistItem{{ImdbID: "tt0113277"}, {ImdbID: "tt0372784"}}},
+		}, nil)
+		return watchlistRepo
+	}
+
+	t.Run("[SimilarMovies] watchlisted movies left out, up to max still", func(t *testing.T) {
+		movieUsecase := &mocks.MovieUsecase{}
+		// three movies are listed, asking for as many more
+		movieUsecase.On("SimilarMovies", testify.Anything, "tt0468569", 5, false).Return(similar, nil)
+
+		result, err := WithWatchlistExclusion(movieUsecase, watching()).SimilarMovies(asCaller("user-1"), "tt0468569", 2, true)
+		if assert.Nil(t, err) {
+			assert.Equal(t, []model.SimilarMovie{similar[1], similar[3]}, result)
+		}
+	})
+
+	t.Run("[SimilarMovies] nothing left out unless asked", func(t *testing.T) {
+		movieUsecase := &mocks.MovieUsecase{}
+		movieUsecase.On("SimilarMovies", testify.Anything, "tt0468569", 2, false).Return(similar[:2], nil)
+		watchlistRepo := watching()
+
+		result, err := WithWatchlistExclusion(movieUsecase, watchlistRepo).SimilarMovies(context.TODO(), "tt0468569", 2, false)
+		if assert.Nil(t, err) {
+			assert.Equal(t, similar[:2], result)
+		}
+		watchlistRepo.AssertNotCalled(t, "ListByOwner", testify.Anything, testify.Anything)
+	})
+
+	t.Run("[SimilarMovies] leaving watchlisted movies out needs a caller", func(t *testing.T) {
+		movieUsecase := &mocks.MovieUsecase{}
+
+		_, err := WithWatchlistExclusion(movieUsecase, watching()).SimilarMovies(context.TODO(), "tt0468569", 2, true)
+		assert.Equal(t, model.ErrUnauthenticated, err)
+		movieUsecase.AssertNotCalled(t, "SimilarMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("[SimilarMovies] movieUsecase returns error", func(t *testing.T) {
+		movieUsecase := &mocks.MovieUsecase{}
+		movieUsecase.On("SimilarMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(nil, model.ErrMovieNotFound)
+
+		_, err := WithWatchlistExclusion(movieUsecase, watching()).SimilarMovies(asCaller("user-1"), "tt0000000", 2, true)
+		assert.Equal(t, model.ErrMovieNotFound, err)
+	})
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+)
+
+// suggestingUsecase tells how a searchword finding nothing may have been meant, from the titles of the
+// movies found before, and searches the best suggestion instead when retry is set. The other calls go to the
+// embedded usecase
+type suggestingUsecase struct {
+	model.MovieUsecase
+	Dictionary model.TitleDictionary
+	max        int
+	retry      bool
+}
+
+// WithSuggestions suggests up to max searchwords for the searches of movieUsecase finding nothing, see
+// model.MovieSearch. The titles found on the way are learnt by dictionary
+func WithSuggestions(movieUsecase model.MovieUsecase, dictionary model.TitleDictionary, max int, retry bool) model.MovieUsecase {
+	return &suggestingUsecase{
+		MovieUsecase: movieUsecase,
+		Dictionary:   dictionary,
+		max:          max,
+		retry:        retry,
+	}
+}
+
+func (usecase *suggestingUsecase) SearchMovies(ctx context.Context, title string, page uint32, filter model.SearchFilter) (result *model.MovieSearch, err error) {
+	result, err = usecase.MovieUsecase.SearchMovies(ctx, title, page, filter)
+	if err != nil || result == nil {
+		return result, err
+	}
+	if result.Error == "" {
+		usecase.learn(ctx, result)
+		return result, nil
+	}
+
+	// OMDb answers not found past the last page too, only a searchword finding nothing at all is misspelled
+	if page > 1 {
+		return result, nil
+	}
+
+	suggestions := usecase.Dictionary.Suggest(ctx, title, usecase.max)
+	if len(suggestions) == 0 {
+		return result, nil
+	}
+
+	if usecase.retry {
+		corrected, err := usecase.MovieUsecase.SearchMovies(ctx, suggestions[0], page, filter)
+		if err != nil {
+			return result, err
+		}
+		if corrected != nil && corrected.Error == "" {
+			// the result may be shared with the cache, the suggestions go to a copy
+			suggested := *corrected
+			suggested.Suggestions = suggestions
+			suggested.Corrected = suggestions[0]
+			return &suggested, nil
+		}
+	}
+
+	suggested := *result
+	suggested.Suggestions = suggestions
+	return &suggested, nil
+}
+
+func (usecase *suggestingUsecase) GetMovieDetailByID(ctx context.Context, id string) (detail *model.MovieDetail, err error) {
+	detail, err = usecase.MovieUsecase.GetMovieDetailByID(ctx, id)
+	if err == nil && detail != nil && detail.Error == "" {
+		usecase.Dictionary.Add(ctx, detail.Title)
+	}
+
+	return detail, err
+}
+
+func (usecase *suggestingUsecase) learn(ctx context.Context, result *model.MovieSearch) {
+	titles := make([]string, 0, len(result.Search))
+	for _, movie := range result.Search {
+		titles = append(titles, movie.Title)
+	}
+	usecase.Dictionary.Add(ctx, titles...)
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+func TestWithSuggestions(t *testing.T) {
+	found := &model.MovieSearch{
+		Search:       []model.SearchDetail{{Title: "Iron Man", ImdbID: "tt0371746"}, {Title: "Iron Man 2", ImdbID: "tt1228705"}},
+		TotalResults: "2",
+		Response:     "True",
+	}
+	notFound := &model.MovieSearch{Response: "False", Error: "Movie not found!"}
+	searching := func() *mocks.MovieUsecase {
+		movieUsecase := &mocks.MovieUsecase{}
+		movieUsecase.On("SearchMovies", testify.Anything, "iron man", uint32(1), model.SearchFilter{}).Return(found, nil)
+		movieUsecase.On("SearchMovies", testify.Anything, testify.Anything, testify.Anything, testify.Anything).Return(notFound, nil)
+		return movieUsecase
+	}
+	suggesting := func(suggestions ...string) *mocks.TitleDictionary {
+		dictionary := &mocks.TitleDictionary{}
+		dictionary.On("Add", testify.Anything, testify.Anything, testify.Anything).Return()
+		dictionary.On("Add", testify.Anything, testify.Anything).Return()
+		dictionary.On("Suggest", testify.Anything, testify.Anything, 5).Return(suggestions)
+		return dictionary
+	}
+
+	t.Run("[SearchMovies] titles found are learnt", func(t *testing.T) {
+		dictionary := suggesting()
+		result, err := WithSuggestions(searching(), dictionary, 5, false).SearchMovies(context.TODO(), "iron man", 1, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, found, result)
+		}
+		dictionary.AssertCalled(t, "Add", testify.Anything, "Iron Man", "Iron Man 2")
+		dictionary.AssertNotCalled(t, "Suggest", testify.Anything, testify.Anything, testify.Anything)
+	})
+
+	t.Run("[SearchMovies] nothing found, with suggestions", func(t *testing.T) {
+		movieUsecase := searching()
+		result, err := WithSuggestions(movieUsecase, suggesting("iron man", "iron men"), 5, false).SearchMovies(context.TODO(), "iron mann", 1, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, "Movie not found!", result.Error)
+			assert.Equal(t, []string{"iron man", "iron men"}, result.Suggestions)
+			assert.Empty(t, result.Corrected)
+		}
+		movieUsecase.AssertNumberOfCalls(t, "SearchMovies", 1)
+		// the result may be shared with the cache
+		assert.Nil(t, notFound.Suggestions)
+	})
+
+	t.Run("[SearchMovies] best suggestion searched instead", func(t *testing.T) {
+		result, err := WithSuggestions(searching(), suggesting("iron man", "iron men"), 5, true).SearchMovies(context.TODO(), "iron mann", 1, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, found.Search, result.Search)
+			assert.Equal(t, "iron man", result.Corrected)
+			assert.Equal(t, []string{"iron man", "iron men"}, result.Suggestions)
+		}
+		assert.Nil(t, found.Suggestions)
+	})
+
+	t.Run("[SearchMovies] best suggestion finding nothing either, or nothing to suggest", func(t *testing.T) {
+		result, _ := WithSuggestions(searching(), suggesting("iron men"), 5, true).SearchMovies(context.TODO(), "iron mann", 1, model.SearchFilter{})
+		assert.Equal(t, []string{"iron men"}, result.Suggestions)
+		assert.Empty(t, result.Corrected)
+
+		result, _ = WithSuggestions(searching(), suggesting(), 5, true).SearchMovies(context.TODO(), "zzzz", 1, model.SearchFilter{})
+		assert.Equal(t, notFound, result)
+	})
+
+	t.Run("[SearchMovies] nothing found past the first page is no misspelling", func(t *testing.T) {
+		movieUsecase := searching()
+		dictionary := suggesting("iron man")
+
+		result, err := WithSuggestions(movieUsecase, dictionary, 5, true).SearchMovies(context.TODO(), "iron mann", 2, model.SearchFilter{})
+		if assert.Nil(t, err) {
+			assert.Equal(t, notFound, result)
+		}
+		dictionary.AssertNotCalled(t, "Suggest", testify.Anything, testify.Anything, testify.Anything)
+		movieUsecase.AssertNumberOfCalls(t, "SearchMovies", 1)
+	})
+
+	t.Run("[GetMovieDetailByID] titles of the details are learnt", func(t *testing.T) {
+		movieUsecase := &mocks.MovieUsecase{}
+		movieUsecase.On("GetMovieDetailByID", testify.Anything, "tt0371746").Return(&model.MovieDetail{Title: "Iron Man", Response: "True"}, nil)
+		movieUsecase.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(&model.MovieDetail{Response: "False", Error: "Incorrect IMDb ID."}, nil)
+		dictionary := suggesting()
+
+		usecase := WithSuggestions(movieUsecase, dictionary, 5, false)
+		usecase.GetMovieDetailByID(context.TODO(), "tt0371746")
+		usecase.GetMovieDetailByID(context.TODO(), "tt0000000")
+
+		dictionary.AssertCalled(t, "Add", testify.Anything, "Iron Man")
+		dictionary.AssertNumberOfCalls(t, "Add", 1)
+	})
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	model "github.com/zenkobert/sbtest-2/domain"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// maxWatchlistItems bounds a watchlist, listing its items looks every movie up on OMDb
+	maxWatchlistItems = 100
+	// maxWatchlistName is the longest name of a watchlist, in characters
+	maxWatchlistName = 100
+	// maxConcurrentLookups bounds the OMDb requests in flight when the items of a watchlist are listed
+	maxConcurrentLookups = 5
+)
+
+type watchlistUsecase struct {
+	WatchlistRepo model.WatchlistRepository
+	MovieRepo     model.MovieRepository
+
+	now   func() time.Time
+	newID func() (string, error)
+}
+
+func NewWatchlistUsecase(watchlistRepo model.WatchlistRepository, movieRepo model.MovieRepository) model.WatchlistUsecase {
+	return &watchlistUsecase{
+		WatchlistRepo: watchlistRepo,
+		MovieRepo:     movieRepo,
+		now:           time.Now,
+		newID:         randomID,
+	}
+}
+
+func (usecase *watchlistUsecase) CreateWatchlist(ctx context.Context, name string) (*model.Watchlist, error) {
+	caller, ok := model.CallerFrom(ctx)
+	if !ok {
+		return nil, model.ErrUnauthenticated
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxWatchlistName {
+		return nil, model.ErrInvalidWatchlist
+	}
+
+	id, err := usecase.newID()
+	if err != nil {
+		return nil, err
+	}
+
+	list := &model.Watchlist{
+		ID:        id,
+		Owner:     caller,
+		Name:      name,
+		CreatedAt: usecase.now().UTC(),
+		Items:     []model.WatchlistItem{},
+	}
+	if err := usecase.WatchlistRepo.Create(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (usecase *watchlistUsecase) Watchlists(ctx context.Context) ([]*model.Watchlist, error) {
+	caller, ok := model.CallerFrom(ctx)
+	if !ok {
+		return nil, model.ErrUnauthenticated
+	}
+
+	return usecase.WatchlistRepo.ListByOwner(ctx, caller)
+}
+
+func (usecase *watchlistUsecase) Items(ctx context.Context, id string) (*model.Watchlist, error) {
+	caller, ok := model.CallerFrom(ctx)
+	if !ok {
+		return nil, model.ErrUnauthenticated
+	}
+
+	list, err := usecase.WatchlistRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if list.Owner != caller {
+		return nil, model.ErrWatchlistNotFound
+	}
+
+	// each item is written by its own lookup only, so they don't need a lock
+	group, groupCtx := errgroup.WithContext(ctx)
+	slots := make(chan struct{}, maxConcurrentLookups)
+	for i := range list.Items {
+		item := &list.Items[i]
+		slots <- struct{}{}
+		group.Go(func() error {
+			defer func() { <-slots }()
+
+			detail, err := usecase.MovieRepo.GetMovieDetailByID(groupCtx, item.ImdbID)
+			if err != nil {
+				return err
+			}
+			// a movie OMDb no longer knows stays in the list without details
+			if detail.Error == "" {
+				item.Movie = detail
+			}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (usecase *watchlistUsecase) AddItem(ctx context.Context, id, imdbID string) (*model.Watchlist, error) {
+	caller, ok := model.CallerFrom(ctx)
+	if !ok {
+		return nil, model.ErrUnauthenticated
+	}
+
+	// the watchlist is checked first, so nobody can look movies up through watchlists of other users
+	list, err := usecase.WatchlistRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if list.Owner != caller {
+		return nil, model.ErrWatchlistNotFound
+	}
+	if list.Item(imdbID) >= 0 {
+		return list, nil
+	}
+
+	detail, err := usecase.MovieRepo.GetMovieDetailByID(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.Error != "" {
+		return nil, model.ErrMovieNotFound
+	}
+
+	return usecase.update(ctx, caller, id, func(list *model.Watchlist) error {
+		// adding a movie twice keeps it where it was
+		if list.Item(imdbID) >= 0 {
+			return nil
+		}
+		if len(list.Items) >= maxWatchlistItems {
+			return model.ErrWatchlistFull
+		}
+
+		list.Items = append(list.Items, model.WatchlistItem{ImdbID: imdbID, AddedAt: usecase.now().UTC()})
+		return nil
+	})
+}
+
+func (usecase *watchlistUsecase) RemoveItem(ctx context.Context, id, imdbID string) (*model.Watchlist, error) {
+	caller, ok := model.CallerFrom(ctx)
+	if !ok {
+		return nil, model.ErrUnauthenticated
+	}
+
+	return usecase.update(ctx, caller, id, func(list *model.Watchlist) error {
+		i := list.Item(imdbID)
+		if i < 0 {
+			return model.ErrItemNotFound
+		}
+
+		list.Items = append(list.Items[:i], list.Items[i+1:]...)
+		return nil
+	})
+}
+
+func (usecase *watchlistUsecase) ReorderItems(ctx context.Context, id string, imdbIDs []string) (*model.Watchlist, error) {
+	caller, ok := model.CallerFrom(ctx)
+	if !ok {
+		return nil, model.ErrUnauthenticated
+	}
+
+	return usecase.update(ctx, caller, id, func(list *model.Watchlist) error {
+		if len(imdbIDs) != len(list.Items) {
+			return model.ErrInvalidOrder
+		}
+
+		reordered := make([]model.WatchlistItem, 0, len(imdbIDs))
+		seen := map[string]bool{}
+		for _, imdbID := range imdbIDs {
+			i := list.Item(imdbID)
+			if i < 0 || seen[imdbID] {
+				return model.ErrInvalidOrder
+			}
+			seen[imdbID] = true
+			reordered = append(reordered, list.Items[i])
+		}
+
+		list.Items = reordered
+		return nil
+	})
+}
+
+func (usecase *watchlistUsecase) MarkWatched(ctx context.Context, id, imdbID, watchedOn string) (*model.Watchlist, error) {
+	caller, ok := model.CallerFrom(ctx)
+	if !ok {
+		return nil, model.ErrUnauthenticated
+	}
+
+	if watchedOn != "" {
+		if _, err := time.Parse(model.WatchedOnLayout, watchedOn); err != nil {
+			return nil, model.ErrInvalidDate
+		}
+	}
+
+	return usecase.update(ctx, caller, id, func(list *model.Watchlist) error {
+		i := list.Item(imdbID)
+		if i < 0 {
+			return model.ErrItemNotFound
+		}
+
+		list.Items[i].WatchedOn = watchedOn
+		return nil
+	})
+}
+
+// update applies change to the watchlist with ID id when caller owns it
+func (usecase *watchlistUsecase) update(ctx context.Context, caller, id string, change func(list *model.Watchlist) error) (*model.Watchlist, error) {
+	return usecase.WatchlistRepo.Update(ctx, id, func(list *model.Watchlist) error {
+		if list.Owner != caller {
+			return model.ErrWatchlistNotFound
+		}
+
+		return change(list)
+	})
+}
+
+// randomID returns 128 random bits in hex, IDs can't be guessed to find the watchlists of other users
+func randomID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testify "github.com/stretchr/testify/mock"
+	model "github.com/zenkobert/sbtest-2/domain"
+	"github.com/zenkobert/sbtest-2/domain/mocks"
+)
+
+var (
+	created = time.Date(2021, 9, 3, 16, 20, 0, 0, time.UTC)
+	added   = created.Add(time.Minute)
+)
+
+func asCaller(caller string) context.Context {
+	return model.WithCaller(context.TODO(), caller)
+}
+
+// storedWatchlist mocks a repository holding list, updates are applied to a copy of it like the repository does
+func storedWatchlist(list model.Watchlist) *mocks.WatchlistRepository {
+	copyOf := func() *model.Watchlist {
+		copied := list
+		copied.Items = append([]model.WatchlistItem{}, list.Items...)
+		return &copied
+	}
+
+	repo := &mocks.WatchlistRepository{}
+	repo.On("Get", testify.Anything, list.ID).Return(func(context.Context, string) *model.Watchlist {
+		return copyOf()
+	}, nil)
+	repo.On("Get", testify.Anything, testify.Anything).Return(nil, model.ErrWatchlistNotFound)
+
+	// the mock gets the watchlist then the error from two functions, the error is the one of the update the first ran
+	var updateErr error
+	repo.On("Update", testify.Anything, list.ID, testify.Anything).Return(
+		func(_ context.Context, _ string, update func(*model.Watchlist) error) *model.Watchlist {
+			updated := copyOf()
+			if updateErr = update(updated); updateErr != nil {
+				return nil
+			}
+			return updated
+		},
+		func(context.Context, string, func(*model.Watchlist) error) error {
+			return updateErr
+		},
+	)
+	repo.On("Update", testify.Anything, testify.Anything, testify.Anything).Return(nil, model.ErrWatchlistNotFound)
+
+	return repo
+}
+
+func ironManList() model.Watchlist {
+	return model.Watchlist{
+		ID:        "list-1",
+		Owner:     "user-1",
+		Name:      "Weekend",
+		CreatedAt: created,
+		Items: []model.WatchlistItem{
+			{ImdbID: "tt0371746", AddedAt: added},
+			{ImdbID: "tt1228705", AddedAt: added},
+			{ImdbID: "tt1300854", AddedAt: added},
+		},
+	}
+}
+
+func knownMovies() *mocks.MovieRepository {
+	movieRepo := &mocks.MovieRepository{}
+	movieRepo.On("GetMovieDetailByID", testify.Anything, "tt0000000").Return(&model.MovieDetail{Response: "False", Error: "Incorrect IMDb ID."}, nil)
+	movieRepo.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(func(_ context.Context, id string) *model.MovieDetail {
+		return &model.MovieDetail{ImdbID: id, Title: "title " + id, Response: "True"}
+	}, nil)
+
+	return movieRepo
+}
+
+func newTestWatchlistUsecase(watchlistRepo model.WatchlistRepository, movieRepo model.MovieRepository) *watchlistUsecase {
+	usecase := NewWatchlistUsecase(watchlistRepo, movieRepo).(*watchlistUsecase)
+	usecase.now = func() time.Time { return added.Add(time.Hour) }
+	usecase.newID = func() (string, error) { return "list-2", nil }
+
+	return usecase
+}
+
+func TestRandomID(t *testing.T) {
+	t.Run("[randomID] 128 bits in hex, different every time", func(t *testing.T) {
+		first, err := randomID()
+		assert.Nil(t, err)
+		assert.Regexp(t, `^[0-9a-f]{32}$`, first)
+
+		second, _ := randomID()
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestCreateWatchlist(t *testing.T) {
+	t.Run("[CreateWatchlist] owned by the caller", func(t *testing.T) {
+		watchlistRepo := &mocks.WatchlistRepository{}
+		watchlistRepo.On("Create", testify.Anything, testify.Anything).Return(nil)
+
+		list, err := newTestWatchlistUsecase(watchlistRepo, nil).CreateWatchlist(asCaller("user-1"), "  Weekend ")
+		if assert.Nil(t, err) {
+			expected := &model.Watchlist{ID: "list-2", Owner: "user-1", Name: "Weekend", CreatedAt: added.Add(time.Hour), Items: []model.WatchlistItem{}}
+			assert.Equal(t, expected, list)
+			watchlistRepo.AssertCalled(t, "Create", testify.Anything, expected)
+		}
+	})
+
+	t.Run("[CreateWatchlist] unauthenticated", func(t *testing.T) {
+		_, err := newTestWatchlistUsecase(&mocks.WatchlistRepository{}, nil).CreateWatchlist(context.TODO(), "Weekend")
+		assert.Equal(t, model.ErrUnauthenticated, err)
+	})
+
+	t.Run("[CreateWatchlist] empty or too long name", func(t *testing.T) {
+		usecase := newTestWatchlistUsecase(&mocks.WatchlistRepository{}, nil)
+		for _, name := range []string{"", "   ", string(make([]rune, 101))} {
+			_, err := usecase.CreateWatchlist(asCaller("user-1"), name)
+			assert.Equal(t, model.ErrInvalidWatchlist, err)
+		}
+	})
+
+	t.Run("[CreateWatchlist] repository error", func(t *testing.T) {
+		watchlistRepo := &mocks.WatchlistRepository{}
+		watchlistRepo.On("Create", testify.Anything, testify.Anything).Return(errors.New("error"))
+
+		_, err := newTestWatchlistUsecase(watchlistRepo, nil).CreateWatchlist(asCaller("user-1"), "Weekend")
+		assert.Equal(t, "error", err.Error())
+	})
+}
+
+func TestWatchlists(t *testing.T) {
+	t.Run("[Watchlists] of the caller only", func(t *testing.T) {
+		list := ironManList()
+		watchlistRepo := &mocks.WatchlistRepository{}
+		watchlistRepo.On("ListByOwner", testify.Anything, "user-1").Return([]*model.Watchlist{&list}, nil)
+
+		lists, err := newTestWatchlistUsecase(watchlistRepo, nil).Watchlists(asCaller("user-1"))
+		assert.Nil(t, err)
+		assert.Equal(t, []*model.Watchlist{&list}, lists)
+
+		_, err = newTestWatchlistUsecase(watchlistRepo, nil).Watchlists(context.TODO())
+		assert.Equal(t, model.ErrUnauthenticated, err)
+	})
+}
+
+func TestItems(t *testing.T) {
+	t.Run("[Items] hydrated with the movie details", func(t *testing.T) {
+		list := ironManList()
+		list.Items = append(list.Items, model.WatchlistItem{ImdbID: "tt0000000"})
+
+		result, err := newTestWatchlistUsecase(storedWatchlist(list), knownMovies()).Items(asCaller("user-1"), "list-1")
+		if assert.Nil(t, err) && assert.Len(t, result.Items, 4) {
+			for i, item := range result.Items[:3] {
+				assert.Equal(t, list.Items[i].ImdbID, item.ImdbID)
+				if assert.NotNil(t, item.Movie) {
+					assert.Equal(t, "title "+item.ImdbID, item.Movie.Title)
+				}
+			}
+			// OMDb no longer knows the movie
+			assert.Nil(t, result.Items[3].Movie)
+		}
+	})
+
+	t.Run("[Items] bounded concurrent lookups", func(t *testing.T) {
+		list := ironManList()
+		list.Items = nil
+		for i := 0; i < 3*maxConcurrentLookups; i++ {
+			list.Items = append(list.Items, model.WatchlistItem{ImdbID: "tt0371746"})
+		}
+
+		var inFlight, most int32
+		movieRepo := &mocks.MovieRepository{}
+		movieRepo.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(func(context.Context, string) *model.MovieDetail {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				seen := atomic.LoadInt32(&most)
+				if n <= seen || atomic.CompareAndSwapInt32(&most, seen, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return &model.MovieDetail{}
+		}, nil)
+
+		_, err := newTestWatchlistUsecase(storedWatchlist(list), movieRepo).Items(asCaller("user-1"), "list-1")
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, most, int32(maxConcurrentLookups))
+	})
+
+	t.Run("[Items] lookup error", func(t *testing.T) {
+		movieRepo := &mocks.MovieRepository{}
+		movieRepo.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(nil, model.ErrQuotaExceeded)
+
+		_, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), movieRepo).Items(asCaller("user-1"), "list-1")
+		assert.True(t, errors.Is(err, model.ErrQuotaExceeded))
+	})
+
+	t.Run("[Items] watchlist of another user is not found", func(t *testing.T) {
+		usecase := newTestWatchlistUsecase(storedWatchlist(ironManList()), knownMovies())
+		_, err := usecase.Items(asCaller("user-2"), "list-1")
+		assert.Equal(t, model.ErrWatchlistNotFound, err)
+
+		_, err = usecase.Items(context.TODO(), "list-1")
+		assert.Equal(t, model.ErrUnauthenticated, err)
+	})
+}
+
+func TestAddItem(t *testing.T) {
+	t.Run("[AddItem] appended to the watchlist", func(t *testing.T) {
+		result, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), knownMovies()).AddItem(asCaller("user-1"), "list-1", "tt4154756")
+		if assert.Nil(t, err) && assert.Len(t, result.Items, 4) {
+			assert.Equal(t, model.WatchlistItem{ImdbID: "tt4154756", AddedAt: added.Add(time.Hour)}, result.Items[3])
+		}
+	})
+
+	t.Run("[AddItem] movie already in the watchlist stays where it is", func(t *testing.T) {
+		movieRepo := &mocks.MovieRepository{}
+		result, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), movieRepo).AddItem(asCaller("user-1"), "list-1", "tt0371746")
+		if assert.Nil(t, err) {
+			assert.Equal(t, ironManList().Items, result.Items)
+			movieRepo.AssertNotCalled(t, "GetMovieDetailByID", testify.Anything, testify.Anything)
+		}
+	})
+
+	t.Run("[AddItem] movie unknown to OMDb", func(t *testing.T) {
+		_, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), knownMovies()).AddItem(asCaller("user-1"), "list-1", "tt0000000")
+		assert.Equal(t, model.ErrMovieNotFound, err)
+	})
+
+	t.Run("[AddItem] lookup error", func(t *testing.T) {
+		movieRepo := &mocks.MovieRepository{}
+		movieRepo.On("GetMovieDetailByID", testify.Anything, testify.Anything).Return(nil, model.ErrUpstream)
+
+		_, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), movieRepo).AddItem(asCaller("user-1"), "list-1", "tt4154756")
+		assert.Equal(t, model.ErrUpstream, err)
+	})
+
+	t.Run("[AddItem] full watchlist", func(t *testing.T) {
+		list := ironManList()
+		list.Items = make([]model.WatchlistItem, maxWatchlistItems)
+
+		_, err := newTestWatchlistUsecase(storedWatchlist(list), knownMovies()).AddItem(asCaller("user-1"), "list-1", "tt4154756")
+		assert.Equal(t, model.ErrWatchlistFull, err)
+	})
+
+	t.Run("[AddItem] watchlist of another user, without looking the movie up", func(t *testing.T) {
+		movieRepo := &mocks.MovieRepository{}
+		_, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), movieRepo).AddItem(asCaller("user-2"), "list-1", "tt4154756")
+		assert.Equal(t, model.ErrWatchlistNotFound, err)
+		movieRepo.AssertNotCalled(t, "GetMovieDetailByID", testify.Anything, testify.Anything)
+	})
+}
+
+func TestRemoveItem(t *testing.T) {
+	t.Run("[RemoveItem] others keep their order", func(t *testing.T) {
+		result, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), nil).RemoveItem(asCaller("user-1"), "list-1", "tt1228705")
+		if assert.Nil(t, err) {
+			assert.Equal(t, []model.WatchlistItem{ironManList().Items[0], ironManList().Items[2]}, result.Items)
+		}
+	})
+
+	t.Run("[RemoveItem] movie not in the watchlist", func(t *testing.T) {
+		_, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), nil).RemoveItem(asCaller("user-1"), "list-1", "tt4154756")
+		assert.Equal(t, model.ErrItemNotFound, err)
+	})
+
+	t.Run("[RemoveItem] watchlist of another user or unknown", func(t *testing.T) {
+		usecase := newTestWatchlistUsecase(storedWatchlist(ironManList()), nil)
+		_, err := usecase.RemoveItem(asCaller("user-2"), "list-1", "tt0371746")
+		assert.Equal(t, model.ErrWatchlistNotFound, err)
+
+		_, err = usecase.RemoveItem(asCaller("user-1"), "list-3", "tt0371746")
+		assert.Equal(t, model.ErrWatchlistNotFound, err)
+	})
+}
+
+func TestReorderItems(t *testing.T) {
+	t.Run("[ReorderItems] in the new order", func(t *testing.T) {
+		result, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), nil).ReorderItems(asCaller("user-1"), "list-1", []string{"tt1300854", "tt0371746", "tt1228705"})
+		if assert.Nil(t, err) {
+			items := ironManList().Items
+			assert.Equal(t, []model.WatchlistItem{items[2], items[0], items[1]}, result.Items)
+		}
+	})
+
+	t.Run("[ReorderItems] every item listed once", func(t *testing.T) {
+		usecase := newTestWatchlistUsecase(storedWatchlist(ironManList()), nil)
+		for _, order := range [][]string{
+			nil,
+			{"tt1300854", "tt0371746"},
+			{"tt1300854", "tt0371746", "tt0371746"},
+			{"tt1300854", "tt0371746", "tt4154756"},
+			{"tt1300854", "tt0371746", "tt1228705", "tt4154756"},
+		} {
+			_, err := usecase.ReorderItems(asCaller("user-1"), "list-1", order)
+			assert.Equal(t, model.ErrInvalidOrder, err, order)
+		}
+	})
+
+	t.Run("[ReorderItems] watchlist of another user", func(t *testing.T) {
+		_, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), nil).ReorderItems(asCaller("user-2"), "list-1", []string{"tt1300854", "tt0371746", "tt1228705"})
+		assert.Equal(t, model.ErrWatchlistNotFound, err)
+	})
+}
+
+func TestMarkWatched(t *testing.T) {
+	t.Run("[MarkWatched] watched on a day, then not watched", func(t *testing.T) {
+		list := ironManList()
+		list.Items[1].WatchedOn = "2021-09-01"
+		usecase := newTestWatchlistUsecase(storedWatchlist(list), nil)
+
+		result, err := usecase.MarkWatched(asCaller("user-1"), "list-1", "tt0371746", "2021-09-04")
+		if assert.Nil(t, err) {
+			assert.Equal(t, "2021-09-04", result.Items[0].WatchedOn)
+		}
+
+		result, err = usecase.MarkWatched(asCaller("user-1"), "list-1", "tt1228705", "")
+		if assert.Nil(t, err) {
+			assert.Empty(t, result.Items[1].WatchedOn)
+		}
+	})
+
+	t.Run("[MarkWatched] invalid date", func(t *testing.T) {
+		usecase := newTestWatchlistUsecase(storedWatchlist(ironManList()), nil)
+		for _, day := range []string{"04/09/2021", "2021-9-4", "2021-02-30", "2021-09-04T10:00:00Z"} {
+			_, err := usecase.MarkWatched(asCaller("user-1"), "list-1", "tt0371746", day)
+			assert.Equal(t, model.ErrInvalidDate, err, day)
+		}
+	})
+
+	t.Run("[MarkWatched] movie not in the watchlist", func(t *testing.T) {
+		_, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), nil).MarkWatched(asCaller("user-1"), "list-1", "tt4154756", "2021-09-04")
+		assert.Equal(t, model.ErrItemNotFound, err)
+	})
+
+	t.Run("[MarkWatched] unauthenticated", func(t *testing.T) {
+		_, err := newTestWatchlistUsecase(storedWatchlist(ironManList()), nil).MarkWatched(context.TODO(), "list-1", "tt0371746", "2021-09-04")
+		assert.Equal(t, model.ErrUnauthenticated, err)
+	})
+}